@@ -1,103 +1,159 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
 	"webserver/internal/config"
 	"webserver/internal/server"
 	v0 "webserver/internal/server/api/v0"
 	auth "webserver/internal/service/auth"
+	"webserver/internal/service/consistency"
 	space "webserver/internal/service/space"
+	"webserver/internal/service/storage/local"
+	"webserver/internal/service/storage/postgres/fulltext"
+	"webserver/internal/service/storage/postgres/pool"
+	"webserver/internal/service/storage/postgres/querylog"
 	space_db "webserver/internal/service/storage/postgres/space"
 	user_db "webserver/internal/service/storage/postgres/user"
 	worker "webserver/internal/service/storage/rabbit/worker"
+	"webserver/internal/service/storage/redis/conn"
+	"webserver/internal/service/storage/redis/expiry"
 	space_cache "webserver/internal/service/storage/redis/space"
 	user_cache "webserver/internal/service/storage/redis/user"
+	"webserver/internal/service/storage/search"
 	user "webserver/internal/service/user"
 
 	"webserver/internal/service/storage/elasticsearch"
 
 	"github.com/ex-rate/logger"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
+// elasticRetryInterval - как часто проверять, вернулся ли эластик, не ответивший при запуске
+const elasticRetryInterval = 30 * time.Second
+
 type App struct {
-	Cfg        *config.Config
-	Elastic    *elasticsearch.Client
-	SpaceRepo  *space_db.Repo
-	SpaceCache *space_cache.Cache
-	Rabbit     *worker.Worker
-	SpaceSrv   *space.Service
-	UserCache  *user_cache.Cache
-	UserRepo   *user_db.Repo
-	UserSrv    *user.Service
-	AuthSrv    *auth.Service
-	Handler    *v0.Handler
-	Server     *server.Server
+	Cfg         *config.Config
+	Postgres    *pool.DB
+	Elastic     *elasticsearch.Client
+	Fulltext    *fulltext.Repo
+	SpaceRepo   *space_db.Repo
+	Redis       redis.UniversalClient
+	SpaceCache  *space_cache.Cache
+	Rabbit      *worker.Worker
+	SpaceSrv    *space.Service
+	UserCache   *user_cache.Cache
+	UserRepo    *user_db.Repo
+	UserSrv     *user.Service
+	AuthSrv     *auth.Service
+	Consistency *consistency.Service
+	Handler     *v0.Handler
+	Server      *server.Server
 }
 
 func NewApp(ctx context.Context, configPath string) (*App, error) {
-	cfg, err := config.LoadConfig(configPath)
-	if err != nil {
-		logrus.Fatalf("error loading config: %+v", err)
-	}
+	cfg, log := setup(configPath)
 
-	lvl, err := logrus.ParseLevel(cfg.Logger.Level)
-	if err != nil {
-		logrus.Fatalf("error parsing level: %+v", err)
-	}
+	db := newPostgres(ctx, cfg, log)
 
-	// Создание логгера
-	loggerConfig := logger.Config{
-		Level:  lvl,
-		Output: logger.OutputType(cfg.Logger.Output),
-		Format: cfg.Logger.Format,
+	if err := checkSchema(ctx, db); err != nil {
+		return nil, err
 	}
 
-	log, err := logger.New(loggerConfig)
-	if err != nil {
-		logrus.Fatalf("error creating logger: %+v", err)
-	}
+	go db.Run(ctx)
 
-	log.Info("Logger initialized")
+	fulltextRepo := start(fulltext.New(ctx, db))
+
+	var (
+		elasticClient *elasticsearch.Client
+		spaceRepo     *space_db.Repo
+	)
+
+	if cfg.Storage.ElasticSearch.Disabled {
+		log.Warn("elastic is disabled in config, searching notes in postgres")
 
-	elasticClient := start(elasticsearch.New([]string{cfg.Storage.ElasticSearch.Address}))
+		spaceRepo = start(space_db.New(db, fulltextRepo))
+	} else {
+		elasticClient = start(elasticsearch.Open(elasticConfig(cfg)))
 
-	addr := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Storage.Postgres.User, cfg.Storage.Postgres.Password, cfg.Storage.Postgres.Host, cfg.Storage.Postgres.Port, cfg.Storage.Postgres.DBName)
+		searchLog := log.WithService("search")
+		selector := start(search.New(
+			search.WithPrimary(elasticClient),
+			search.WithFallback(fulltextRepo),
+			search.WithLogger(searchLog),
+		))
 
-	spaceRepo := start(space_db.New(addr, elasticClient))
+		// без эластика сервер работает: поиск идет в постгресе, пока эластик не вернется
+		if err := elasticClient.CheckVersion(ctx); errors.Is(err, elasticsearch.ErrUnavailable) {
+			log.Warnf("elastic is unavailable, searching notes in postgres: %+v", err)
+
+			selector.MarkUnavailable(err)
+
+			go bootstrapElastic(ctx, elasticClient, log)
+		} else {
+			startService(err, "elastic")
+			startService(elasticClient.Bootstrap(ctx), "elastic indices")
+		}
+
+		spaceRepo = start(space_db.New(db, selector))
+	}
+
+	redisClient := newRedis(ctx, cfg)
 
 	spaceCacheLog := log.WithService("space_cache")
-	spaceCache := start(space_cache.New(ctx, cfg.Storage.Redis.Address, spaceCacheLog))
+	spaceCache := start(space_cache.New(redisClient, expiry.New(cfg.Storage.Redis.SpaceTTL, cfg.Storage.Redis.TTLJitter), spaceCacheLog))
+
+	userCacheLog := log.WithService("user_cache")
+	userCache := start(user_cache.New(redisClient, expiry.New(cfg.Storage.Redis.UserTTL, cfg.Storage.Redis.TTLJitter), userCacheLog))
+
+	var (
+		spaces local.SpaceStore = spaceCache
+		users  local.UserStore  = userCache
+	)
+
+	if cfg.Storage.Redis.LocalCache.Size > 0 {
+		spaces, users = newLocalCache(cfg, spaceCache, userCache, log)
+	}
 
 	rabbitLog := log.WithService("rabbit")
 	rabbit := start(worker.New(
 		worker.WithAddress(cfg.Storage.RabbitMQ.Address),
 		worker.WithNotesExchange(cfg.Storage.RabbitMQ.NoteExchange),
 		worker.WithSpacesExchange(cfg.Storage.RabbitMQ.SpaceExchange),
+		worker.WithEventsExchange(cfg.Storage.RabbitMQ.EventsExchange),
+		worker.WithSpaceCache(spaces),
+		worker.WithUserCache(users),
 		worker.WithLogger(rabbitLog),
 	))
 
 	startService(rabbit.Connect(), "rabbit")
 
+	if len(cfg.Storage.RabbitMQ.EventsExchange) > 0 {
+		startService(rabbit.ConsumeEvents(ctx), "rabbit events")
+	}
+
 	spaceSrvLog := log.WithService("space_srv")
 	spaceSrv := start(space.New(
 		space.WithRepo(spaceRepo),
-		space.WithCache(spaceCache),
+		space.WithCache(spaces),
 		space.WithWorker(rabbit),
 		space.WithLogger(spaceSrvLog),
+		space.WithTrashRetention(cfg.Trash.Retention),
 	))
 
-	userCacheLog := log.WithService("user_cache")
-	userCache := start(user_cache.New(ctx, cfg.Storage.Redis.Address, userCacheLog))
-
-	userRepo := start(user_db.New(addr))
+	userRepo := start(user_db.New(db))
 
 	userSrvLog := log.WithService("user_srv")
 	userSrv := start(user.New(
 		user.WithRepo(userRepo),
-		user.WithCache(userCache),
+		user.WithCache(users),
 		user.WithLogger(userSrvLog),
 	))
 
@@ -107,6 +163,19 @@ func NewApp(ctx context.Context, configPath string) (*App, error) {
 		auth.WithLogger(authSrvLog),
 	))
 
+	var consistencySrv *consistency.Service
+
+	if cfg.Consistency.Interval > 0 && elasticClient != nil {
+		consistencyLog := log.WithService("consistency")
+		consistencySrv = start(consistency.New(
+			consistency.WithRepo(spaceRepo),
+			consistency.WithElastic(elasticClient),
+			consistency.WithLogger(consistencyLog),
+		))
+
+		go consistencySrv.Run(ctx, cfg.Consistency.Interval)
+	}
+
 	handlerLog := log.WithService("handler")
 	handler := start(v0.New(
 		v0.WithSpaceService(spaceSrv),
@@ -129,21 +198,214 @@ func NewApp(ctx context.Context, configPath string) (*App, error) {
 	startService(server.Start(), "server")
 
 	return &App{
-		Cfg:        cfg,
-		Elastic:    elasticClient,
-		SpaceRepo:  spaceRepo,
-		SpaceCache: spaceCache,
-		Rabbit:     rabbit,
-		SpaceSrv:   spaceSrv,
-		UserCache:  userCache,
-		UserRepo:   userRepo,
-		UserSrv:    userSrv,
-		AuthSrv:    authSrv,
-		Handler:    handler,
-		Server:     server,
+		Cfg:         cfg,
+		Postgres:    db,
+		Elastic:     elasticClient,
+		Fulltext:    fulltextRepo,
+		SpaceRepo:   spaceRepo,
+		Redis:       redisClient,
+		SpaceCache:  spaceCache,
+		Rabbit:      rabbit,
+		SpaceSrv:    spaceSrv,
+		UserCache:   userCache,
+		UserRepo:    userRepo,
+		UserSrv:     userSrv,
+		AuthSrv:     authSrv,
+		Consistency: consistencySrv,
+		Handler:     handler,
+		Server:      server,
 	}, nil
 }
 
+// newLocalCache ставит перед кэшами в редисе кэши в памяти экземпляра. Устаревшие записи каждый экземпляр удаляет сам
+// по событиям об изменениях от db-worker, поэтому локальный кэш требует storage.rabbitmq.events_exchange (см. config)
+func newLocalCache(cfg *config.Config, spaceCache *space_cache.Cache, userCache *user_cache.Cache, log *logger.Logger) (*local.SpaceCache, *local.UserCache) {
+	localCfg := local.Config{
+		Size: cfg.Storage.Redis.LocalCache.Size,
+		TTL:  cfg.Storage.Redis.LocalCache.TTL,
+	}
+
+	spaces := start(local.NewSpaceCache(spaceCache, localCfg, log.WithService("local_space_cache")))
+	users := start(local.NewUserCache(userCache, localCfg, log.WithService("local_user_cache")))
+
+	return spaces, users
+}
+
+// setup загружает конфиг и создает логгер
+func setup(configPath string) (*config.Config, *logger.Logger) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		logrus.Fatalf("error loading config: %+v", err)
+	}
+
+	lvl, err := logrus.ParseLevel(cfg.Logger.Level)
+	if err != nil {
+		logrus.Fatalf("error parsing level: %+v", err)
+	}
+
+	// Создание логгера
+	loggerConfig := logger.Config{
+		Level:  lvl,
+		Output: logger.OutputType(cfg.Logger.Output),
+		Format: cfg.Logger.Format,
+	}
+
+	log, err := logger.New(loggerConfig)
+	if err != nil {
+		logrus.Fatalf("error creating logger: %+v", err)
+	}
+
+	log.Info("Logger initialized")
+	log.Infof("effective config:\n%s", cfg)
+
+	return cfg, log
+}
+
+// newElastic создает клиент эластика. Команды, которым нужен эластик, не запускаются, если он выключен в конфиге
+func newElastic(ctx context.Context, cfg *config.Config) *elasticsearch.Client {
+	if cfg.Storage.ElasticSearch.Disabled {
+		logrus.Fatal("elastic is disabled in config")
+	}
+
+	return start(elasticsearch.New(ctx, elasticConfig(cfg)))
+}
+
+func elasticConfig(cfg *config.Config) elasticsearch.Config {
+	es := cfg.Storage.ElasticSearch
+
+	return elasticsearch.Config{
+		Addresses:      es.Nodes(),
+		Username:       es.Username,
+		Password:       es.Password,
+		APIKey:         es.APIKey,
+		CACertFile:     es.CACert,
+		RequestTimeout: es.RequestTimeout,
+		MaxRetries:     es.MaxRetries,
+		RetryOnStatus:  es.RetryOnStatus,
+	}
+}
+
+// bootstrapElastic ждет, пока эластик, не ответивший при запуске, станет доступен, и создает индексы.
+// Если версия кластера не поддерживается, перестает ждать: поиск так и будет идти в постгресе
+func bootstrapElastic(ctx context.Context, client *elasticsearch.Client, log *logger.Logger) {
+	ticker := time.NewTicker(elasticRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := client.CheckVersion(ctx)
+		if errors.Is(err, elasticsearch.ErrUnavailable) {
+			log.Debugf("elastic is still unavailable: %+v", err)
+			continue
+		}
+
+		if err != nil {
+			log.Errorf("error connecting elastic, searching notes in postgres: %+v", err)
+			return
+		}
+
+		if err := client.Bootstrap(ctx); err != nil {
+			log.Errorf("error creating elastic indices: %+v", err)
+			continue
+		}
+
+		log.Info("elastic is available, indices are ready")
+
+		return
+	}
+}
+
+// newRedis подключается к редису. Клиент общий для всех кэшей
+func newRedis(ctx context.Context, cfg *config.Config) redis.UniversalClient {
+	r := cfg.Storage.Redis
+
+	return start(conn.Open(ctx, conn.Config{
+		Addresses:        r.Nodes(),
+		Cluster:          r.Cluster,
+		MasterName:       r.MasterName,
+		Username:         r.Username,
+		Password:         r.Password,
+		SentinelUsername: r.SentinelUsername,
+		SentinelPassword: r.SentinelPassword,
+		DB:               r.DB,
+		TLS:              r.TLS.Enabled,
+		CACertFile:       r.TLS.CACert,
+		CertFile:         r.TLS.Cert,
+		KeyFile:          r.TLS.Key,
+		ServerName:       r.TLS.ServerName,
+		PoolSize:         r.PoolSize,
+		MinIdleConns:     r.MinIdleConns,
+		DialTimeout:      r.DialTimeout,
+		ReadTimeout:      r.ReadTimeout,
+		WriteTimeout:     r.WriteTimeout,
+		PoolTimeout:      r.PoolTimeout,
+	}))
+}
+
+// newPostgres подключается к основной базе и репликам. Пул соединений общий для всех репозиториев
+func newPostgres(ctx context.Context, cfg *config.Config, log *logger.Logger) *pool.DB {
+	pg := cfg.Storage.Postgres
+
+	// по умолчанию в лог попадают только медленные запросы и ошибки
+	queryLogLevel := logrus.WarnLevel
+	if len(pg.QueryLog.Level) > 0 {
+		lvl, err := logrus.ParseLevel(pg.QueryLog.Level)
+		if err != nil {
+			logrus.Fatalf("error parsing query log level: %+v", err)
+		}
+
+		queryLogLevel = lvl
+	}
+
+	return start(pool.Open(ctx, pool.Config{
+		DSN:                  postgresAddr(pg),
+		Replicas:             pg.Replicas,
+		MaxOpenConns:         pg.MaxOpenConns,
+		MaxIdleConns:         pg.MaxIdleConns,
+		ConnMaxLifetime:      pg.ConnMaxLifetime,
+		ConnMaxIdleTime:      pg.ConnMaxIdleTime,
+		StatementTimeout:     pg.StatementTimeout,
+		ReplicaCheckInterval: pg.ReplicaCheckInterval,
+		Log:                  log.WithService("postgres"),
+		QueryLog: querylog.Config{
+			Level:         queryLogLevel,
+			SlowThreshold: pg.QueryLog.SlowThreshold,
+			LogArgs:       pg.QueryLog.LogArgs,
+		},
+	}))
+}
+
+// postgresAddr собирает DSN основной базы. Если режим TLS не задан, соединение без TLS
+func postgresAddr(pg config.Postgres) string {
+	params := url.Values{}
+
+	params.Set("sslmode", cmp.Or(pg.SSLMode, "disable"))
+
+	if len(pg.SSLRootCert) > 0 {
+		params.Set("sslrootcert", pg.SSLRootCert)
+	}
+
+	if len(pg.SSLCert) > 0 {
+		params.Set("sslcert", pg.SSLCert)
+		params.Set("sslkey", pg.SSLKey)
+	}
+
+	addr := url.URL{
+		Scheme:   "postgresql",
+		User:     url.UserPassword(pg.User, pg.Password),
+		Host:     net.JoinHostPort(pg.Host, strconv.Itoa(pg.Port)),
+		Path:     pg.DBName,
+		RawQuery: params.Encode(),
+	}
+
+	return addr.String()
+}
+
 func startService(err error, name string) {
 	if err != nil {
 		// Используем logrus для критических ошибок, так как наш логгер может быть еще не инициализирован
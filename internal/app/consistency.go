@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"webserver/internal/model"
+	"webserver/internal/service/consistency"
+	space_db "webserver/internal/service/storage/postgres/space"
+)
+
+// CheckConsistency один раз сверяет заметки в базе и в эластике (команда consistency). Сервер при этом не запускается
+func CheckConsistency(ctx context.Context, configPath string, repair bool) (*model.ConsistencyReport, error) {
+	cfg, log := setup(configPath)
+
+	elasticClient := newElastic(ctx, cfg)
+
+	db := newPostgres(ctx, cfg, log)
+	defer db.Close()
+
+	spaceRepo := start(space_db.New(db, elasticClient))
+
+	consistencyLog := log.WithService("consistency")
+	consistencySrv := start(consistency.New(
+		consistency.WithRepo(spaceRepo),
+		consistency.WithElastic(elasticClient),
+		consistency.WithLogger(consistencyLog),
+	))
+
+	return consistencySrv.Check(ctx, repair)
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"webserver/internal/service/storage/postgres/migrations"
+	"webserver/internal/service/storage/postgres/pool"
+)
+
+// Migrator - миграции схемы для команды migrate вместе с пулом соединений, через который они применяются
+type Migrator struct {
+	*migrations.Migrator
+	db *pool.DB
+}
+
+// NewMigrator подключается к базе для команды migrate. Сервер при этом не запускается
+func NewMigrator(ctx context.Context, configPath string) *Migrator {
+	cfg, log := setup(configPath)
+
+	db := newPostgres(ctx, cfg, log)
+
+	return &Migrator{
+		Migrator: start(migrations.New(db.Primary())),
+		db:       db,
+	}
+}
+
+func (m *Migrator) Close() {
+	m.db.Close()
+}
+
+// checkSchema проверяет, что схема базы мигрирована до версии, с которой работает сервер
+func checkSchema(ctx context.Context, db *pool.DB) error {
+	migrator, err := migrations.New(db.Primary())
+	if err != nil {
+		return err
+	}
+
+	if err := migrator.Check(ctx); err != nil {
+		return fmt.Errorf("error checking db schema: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"webserver/internal/service/reindex"
+	space_db "webserver/internal/service/storage/postgres/space"
+)
+
+// Reindex перестраивает индекс заметок в эластике по данным из базы (команда reindex). Сервер при этом не запускается
+func Reindex(ctx context.Context, configPath string, batchSize int, params reindex.Params) (reindex.Result, error) {
+	cfg, log := setup(configPath)
+
+	elasticClient := newElastic(ctx, cfg)
+
+	db := newPostgres(ctx, cfg, log)
+	defer db.Close()
+
+	spaceRepo := start(space_db.New(db, elasticClient))
+
+	reindexLog := log.WithService("reindex")
+	reindexSrv := start(reindex.New(
+		reindex.WithRepo(spaceRepo),
+		reindex.WithElastic(elasticClient),
+		reindex.WithLogger(reindexLog),
+		reindex.WithBatchSize(batchSize),
+	))
+
+	return reindexSrv.Reindex(ctx, params)
+}
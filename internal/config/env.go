@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix - префикс переменных окружения, которые переопределяют поля конфига. Имя переменной - путь к полю в YAML:
+// WEBSERVER_STORAGE_POSTGRES_PASSWORD переопределяет storage.postgres.password.
+// Списки задаются через запятую: WEBSERVER_STORAGE_REDIS_ADDRESSES=redis-1:6379,redis-2:6379
+const EnvPrefix = "WEBSERVER"
+
+// fileSuffix - суффикс переменной с путем к файлу, из которого читается значение поля (секреты docker и kubernetes):
+// WEBSERVER_AUTH_SECRET_KEY_FILE=/run/secrets/secret_key
+const fileSuffix = "_FILE"
+
+// envRef - ссылка на переменную окружения в строковом значении YAML: ${VAR}. $${VAR} экранирует ссылку
+var envRef = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolate подставляет значения переменных окружения вместо ${VAR} в строковых полях и списках строк
+// уже разобранного конфига: значение попадает в поле как есть, даже если в нем есть #, : или кавычки.
+// $${VAR} заменяется на ${VAR}. Если переменная не задана, возвращает ошибку: пустое значение в конфиге легко не заметить
+func interpolate(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		var errs []error
+
+		for i := range v.NumField() {
+			errs = append(errs, interpolate(v.Field(i)))
+		}
+
+		return errors.Join(errs...)
+	case reflect.Slice:
+		var errs []error
+
+		for i := range v.Len() {
+			errs = append(errs, interpolate(v.Index(i)))
+		}
+
+		return errors.Join(errs...)
+	case reflect.String:
+		value, err := expand(v.String())
+		if err != nil {
+			return err
+		}
+
+		v.SetString(value)
+	}
+
+	return nil
+}
+
+// expand заменяет ${VAR} в строке значениями переменных окружения
+func expand(s string) (string, error) {
+	var errs []error
+
+	res := envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+
+		name := envRef.FindStringSubmatch(ref)[1]
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("environment variable %s is not set", name))
+			return ref
+		}
+
+		return value
+	})
+
+	return res, errors.Join(errs...)
+}
+
+// applyEnv переопределяет поля структуры значениями переменных окружения name_<yaml тег поля>
+func applyEnv(v reflect.Value, name string) error {
+	if v.Kind() == reflect.Struct {
+		var errs []error
+
+		for i := range v.NumField() {
+			tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+			if len(tag) == 0 || tag == "-" {
+				continue
+			}
+
+			errs = append(errs, applyEnv(v.Field(i), name+"_"+strings.ToUpper(tag)))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	value, ok, err := lookupEnv(name)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := setValue(v, value); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// lookupEnv возвращает значение переменной name или содержимое файла из переменной name_FILE.
+// Задавать обе переменные нельзя: непонятно, какое из значений нужно
+func lookupEnv(name string) (string, bool, error) {
+	value, ok := os.LookupEnv(name)
+
+	path, fromFile := os.LookupEnv(name + fileSuffix)
+	if !fromFile {
+		return value, ok, nil
+	}
+
+	if ok {
+		return "", false, fmt.Errorf("both %s and %s%s are set", name, name, fileSuffix)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading %s%s: %w", name, fileSuffix, err)
+	}
+
+	// секреты часто сохраняют в файл с переводом строки в конце
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// setValue записывает значение переменной в поле. Строки записываются как есть, остальное разбирается как YAML
+func setValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Slice:
+		var items []string
+		if len(value) > 0 {
+			items = strings.Split(value, ",")
+		}
+
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := setValue(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+
+		v.Set(slice)
+	default:
+		return yaml.Unmarshal([]byte(value), v.Addr().Interface())
+	}
+
+	return nil
+}
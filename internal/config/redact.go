@@ -0,0 +1,62 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+
+	"gopkg.in/yaml.v2"
+)
+
+// redacted заменяет секреты в конфиге, который пишется в лог
+const redacted = "[REDACTED]"
+
+// Redacted возвращает копию конфига без секретов: поля с тегом secret:"true" заменяются на [REDACTED],
+// в адресах с тегом secret:"url" скрывается пароль
+func (c Config) Redacted() Config {
+	redact(reflect.ValueOf(&c).Elem())
+
+	return c
+}
+
+// String возвращает конфиг без секретов в формате YAML
+func (c Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(data)
+}
+
+func redact(v reflect.Value) {
+	for i := range v.NumField() {
+		field := v.Field(i)
+
+		switch secret := v.Type().Field(i).Tag.Get("secret"); {
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		case secret == "true" && field.Len() > 0:
+			field.SetString(redacted)
+		case secret == "url" && field.Kind() == reflect.String:
+			field.SetString(redactURL(field.String()))
+		case secret == "url" && field.Kind() == reflect.Slice && field.Len() > 0:
+			// срез общий с исходным конфигом, поэтому собираем новый
+			urls := make([]string, field.Len())
+			for j := range urls {
+				urls[j] = redactURL(field.Index(j).String())
+			}
+
+			field.Set(reflect.ValueOf(urls))
+		}
+	}
+}
+
+// redactURL скрывает пароль в адресе. Если адрес не разбирается, скрывает его целиком
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+
+	return u.Redacted()
+}
@@ -11,4 +11,10 @@ var (
 	ErrNoNotesFoundByType = errors.New("no notes found by this type")
 	// ошибка о том, что заметки по тексту не найдены
 	ErrNoNotesFoundByText = errors.New("no notes found by text")
+	// ошибка о том, что в корзине пространства нет заметок
+	ErrNoNotesInTrash = errors.New("trash is empty")
+	// ошибка о том, что заметки нет в корзине (не удалена, либо истек срок хранения)
+	ErrNoteNotFoundInTrash = errors.New("note not found in trash")
+	// ошибка о том, что заметку переносят / копируют в то же пространство, где она уже лежит
+	ErrSameSpace = errors.New("source and target spaces are the same")
 )
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// максимальное количество операций в одном пакетном запросе
+const MaxBatchSize = 100
+
+var (
+	// ошибка о том, что в пакетном запросе нет операций
+	ErrEmptyBatch = errors.New("batch has no items")
+	// ошибка о том, что в пакетном запросе слишком много операций
+	ErrBatchTooLarge = fmt.Errorf("batch has more than %d items", MaxBatchSize)
+	// ошибка о том, что операция в пакетном запросе не поддерживается
+	ErrInvalidBatchOperation = errors.New("invalid batch operation")
+	// ошибка о том, что пакетный запрос отклонен целиком: хотя бы одна операция не прошла валидацию
+	ErrBatchRejected = errors.New("batch rejected: some items are invalid")
+)
+
+// операция в пакетном запросе
+type BatchOperation string
+
+const (
+	// создать заметку
+	BatchCreate BatchOperation = "create"
+	// обновить текст заметки
+	BatchUpdate BatchOperation = "update"
+	// удалить заметку (переместить в корзину)
+	BatchDelete BatchOperation = "delete"
+)
+
+// статус операции в пакетном запросе
+type BatchItemStatus string
+
+const (
+	// операция принята в обработку
+	BatchItemAccepted BatchItemStatus = "accepted"
+	// операция не прошла валидацию
+	BatchItemRejected BatchItemStatus = "rejected"
+	// операция валидна, но не отправлена, т.к. пакет отклонен целиком
+	BatchItemSkipped BatchItemStatus = "skipped"
+	// операцию не удалось отправить в обработку
+	BatchItemFailed BatchItemStatus = "failed"
+)
+
+//	{
+//	  "user_id": 12345678,
+//	  "atomic": true,
+//	  "items": [
+//	    {"operation": "create", "text": "new note", "type": "text"},
+//	    {"operation": "update", "note_id": "ed3a5b3a-b81e-4cad-acea-178e230a9b93", "text": "new text"},
+//	    {"operation": "delete", "note_id": "ed3a5b3a-b81e-4cad-acea-178e230a9b93"}
+//	  ]
+//	}
+//
+// запрос на пакетное создание / обновление / удаление заметок в пространстве
+type BatchNotesRequest struct {
+	UserID  int64           `json:"user_id"` // кто выполняет операции
+	SpaceID uuid.UUID       `json:"-"`       // айди пространства, берется из пути запроса
+	Atomic  bool            `json:"atomic"`  // принять либо все операции, либо ни одной
+	Items   []BatchNoteItem `json:"items"`
+}
+
+func (s *BatchNotesRequest) Validate() error {
+	if s.UserID == 0 {
+		return ErrFieldUserNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return ErrInvalidSpaceID
+	}
+
+	if len(s.Items) == 0 {
+		return ErrEmptyBatch
+	}
+
+	if len(s.Items) > MaxBatchSize {
+		return ErrBatchTooLarge
+	}
+
+	return nil
+}
+
+// операция в пакетном запросе
+type BatchNoteItem struct {
+	Operation BatchOperation `json:"operation"`
+	NoteID    uuid.UUID      `json:"note_id"` // айди заметки (для обновления и удаления)
+	Text      string         `json:"text"`    // текст заметки (для создания и обновления)
+	Type      NoteType       `json:"type"`    // тип заметки (для создания)
+	File      string         `json:"file"`    // название файла в Minio (если есть)
+}
+
+// результат операции в пакетном запросе
+type BatchNoteResult struct {
+	Index     int             `json:"index"` // номер операции в запросе
+	Operation BatchOperation  `json:"operation"`
+	NoteID    uuid.UUID       `json:"note_id"`
+	RequestID uuid.UUID       `json:"request_id"` // айди запроса для отслеживания (если операция принята)
+	Status    BatchItemStatus `json:"status"`
+	Error     string          `json:"error,omitempty"` // причина, по которой операция не принята
+}
+
+// ответ на пакетный запрос
+type BatchNotesResponse struct {
+	RequestID uuid.UUID         `json:"request_id"` // айди пакета, если он отправлен целиком (atomic)
+	Accepted  int               `json:"accepted"`   // сколько операций принято в обработку
+	Rejected  int               `json:"rejected"`   // сколько операций не принято
+	Results   []BatchNoteResult `json:"results"`
+}
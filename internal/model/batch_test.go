@@ -0,0 +1,74 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model BatchNotesRequest
+		err   error
+	}
+
+	items := []BatchNoteItem{{Operation: BatchCreate, Text: "new note", Type: TextNoteType}}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: BatchNotesRequest{
+				UserID:  1,
+				SpaceID: uuid.New(),
+				Items:   items,
+			},
+		},
+		{
+			name: "user not filled",
+			model: BatchNotesRequest{
+				SpaceID: uuid.New(),
+				Items:   items,
+			},
+			err: ErrFieldUserNotFilled,
+		},
+		{
+			name: "space not filled",
+			model: BatchNotesRequest{
+				UserID: 1,
+				Items:  items,
+			},
+			err: ErrInvalidSpaceID,
+		},
+		{
+			name: "no items",
+			model: BatchNotesRequest{
+				UserID:  1,
+				SpaceID: uuid.New(),
+			},
+			err: ErrEmptyBatch,
+		},
+		{
+			name: "too many items",
+			model: BatchNotesRequest{
+				UserID:  1,
+				SpaceID: uuid.New(),
+				Items:   make([]BatchNoteItem, MaxBatchSize+1),
+			},
+			err: ErrBatchTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
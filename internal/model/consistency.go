@@ -0,0 +1,99 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DriftKind - вид расхождения между заметкой в базе и документом в эластике
+type DriftKind string
+
+const (
+	// заметка есть в базе, но не в эластике: ее нельзя найти поиском
+	DriftMissingInIndex DriftKind = "missing_in_index"
+	// документ есть в эластике, но заметки нет в базе: поиск находит ее и молча пропускает
+	DriftStaleInIndex DriftKind = "stale_in_index"
+	// на одну заметку в эластике несколько документов
+	DriftDuplicate DriftKind = "duplicate"
+	// текст в эластике отличается от текста в базе
+	DriftTextMismatch DriftKind = "text_mismatch"
+	// в эластике заметка лежит в другом пространстве
+	DriftSpaceMismatch DriftKind = "space_mismatch"
+	// заметка в корзине находится поиском, или восстановленная заметка не находится
+	DriftDeletedMismatch DriftKind = "deleted_mismatch"
+)
+
+// DriftKinds - все виды расхождений
+var DriftKinds = []DriftKind{DriftMissingInIndex, DriftStaleInIndex, DriftDuplicate, DriftTextMismatch, DriftSpaceMismatch, DriftDeletedMismatch}
+
+// ConsistencyReport - отчет о проверке согласованности заметок в базе и в эластике
+type ConsistencyReport struct {
+	StartedAt    time.Time          `json:"started_at"`
+	FinishedAt   time.Time          `json:"finished_at"`
+	DBNotes      int                `json:"db_notes"`      // сколько заметок проверено в базе
+	IndexedNotes int                `json:"indexed_notes"` // сколько документов проверено в эластике
+	Drift        map[DriftKind]int  `json:"drift"`         // сколько расхождений каждого вида
+	Repaired     bool               `json:"repaired"`      // расхождения исправлены
+	Spaces       []SpaceConsistency `json:"spaces"`        // пространства с расхождениями, по возрастанию id
+	spaces       map[uuid.UUID]int  // индекс пространства в Spaces
+}
+
+// SpaceConsistency - расхождения в одном пространстве: id заметок по видам расхождений
+type SpaceConsistency struct {
+	SpaceID uuid.UUID                 `json:"space_id"`
+	Notes   map[DriftKind][]uuid.UUID `json:"notes"`
+}
+
+// NewConsistencyReport создает пустой отчет
+func NewConsistencyReport(startedAt time.Time) *ConsistencyReport {
+	drift := make(map[DriftKind]int, len(DriftKinds))
+	for _, kind := range DriftKinds {
+		drift[kind] = 0
+	}
+
+	return &ConsistencyReport{
+		StartedAt: startedAt,
+		Drift:     drift,
+		Spaces:    []SpaceConsistency{},
+		spaces:    map[uuid.UUID]int{},
+	}
+}
+
+// Add добавляет в отчет расхождение вида kind у заметки noteID в пространстве spaceID
+func (r *ConsistencyReport) Add(kind DriftKind, spaceID, noteID uuid.UUID) {
+	r.Drift[kind]++
+
+	i, ok := r.spaces[spaceID]
+	if !ok {
+		i = len(r.Spaces)
+		r.spaces[spaceID] = i
+		r.Spaces = append(r.Spaces, SpaceConsistency{SpaceID: spaceID, Notes: map[DriftKind][]uuid.UUID{}})
+	}
+
+	r.Spaces[i].Notes[kind] = append(r.Spaces[i].Notes[kind], noteID)
+}
+
+// TotalDrift возвращает общее количество расхождений
+func (r *ConsistencyReport) TotalDrift() int {
+	total := 0
+	for _, count := range r.Drift {
+		total += count
+	}
+
+	return total
+}
+
+// Finish завершает отчет: сортирует пространства по id
+func (r *ConsistencyReport) Finish(finishedAt time.Time) {
+	r.FinishedAt = finishedAt
+
+	sort.Slice(r.Spaces, func(i, j int) bool {
+		return r.Spaces[i].SpaceID.String() < r.Spaces[j].SpaceID.String()
+	})
+
+	for i, space := range r.Spaces {
+		r.spaces[space.SpaceID] = i
+	}
+}
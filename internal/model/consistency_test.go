@@ -0,0 +1,61 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyReport(t *testing.T) {
+	started := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+
+	spaceA := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	spaceB := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+	note1 := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	note2 := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	note3 := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	report := NewConsistencyReport(started)
+	report.DBNotes = 2
+	report.IndexedNotes = 2
+
+	// пространства добавляются не по порядку, в отчете они отсортированы
+	report.Add(DriftStaleInIndex, spaceB, note3)
+	report.Add(DriftMissingInIndex, spaceA, note1)
+	report.Add(DriftMissingInIndex, spaceA, note2)
+
+	report.Finish(started.Add(time.Second))
+
+	assert.Equal(t, 3, report.TotalDrift())
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"started_at":"2024-05-15T14:30:00Z",
+		"finished_at":"2024-05-15T14:30:01Z",
+		"db_notes":2,
+		"indexed_notes":2,
+		"drift":{"missing_in_index":2,"stale_in_index":1,"duplicate":0,"text_mismatch":0,"space_mismatch":0,"deleted_mismatch":0},
+		"repaired":false,
+		"spaces":[
+			{"space_id":"aaaaaaaa-0000-0000-0000-000000000000","notes":{"missing_in_index":[
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002"
+			]}},
+			{"space_id":"bbbbbbbb-0000-0000-0000-000000000000","notes":{"stale_in_index":["00000000-0000-0000-0000-000000000003"]}}
+		]
+	}`, string(data))
+}
+
+func TestConsistencyReport_Empty(t *testing.T) {
+	report := NewConsistencyReport(time.Now())
+	report.Finish(time.Now())
+
+	assert.Zero(t, report.TotalDrift())
+	assert.Empty(t, report.Spaces)
+}
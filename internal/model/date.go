@@ -0,0 +1,606 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Описание периода на естественном языке (русском или английском). Поддерживаются:
+//
+//	сегодня, вчера, позавчера / today, yesterday, the day before yesterday
+//	на этой / прошлой / позапрошлой неделе / this week, last week (так же месяц и год)
+//	3 дня назад, неделю назад / 3 days ago, a week ago - календарный день / неделя / месяц / год
+//	за последние 3 дня, за неделю / last 3 days, past week - скользящий период, включая сегодня
+//	в понедельник, в прошлую пятницу / monday, last friday
+//	в марте, в марте 2023 / in march, march 2023
+//	15 марта, 15 марта 2024, 15.03.2024, 2024-03-15 / march 15, 15 march 2024
+//	в 2023 году / in 2023
+//	с 1 по 15 марта, с понедельника по среду / from monday to wednesday, between march 1 and march 15
+//	с марта / since march - с начала периода без ограничения сверху
+//	до 15 марта / before march 15 - строго раньше периода, по 15 марта / until march 15 - включая период
+//
+// Даты без года, которые еще не наступили, относятся к прошлому году. Неделя начинается с понедельника.
+
+var (
+	// ошибка о том, что описание периода не удалось разобрать
+	ErrInvalidDateExpression = errors.New("unrecognized date expression")
+)
+
+// DateRange - период времени. Обе границы включительно, нулевая граница - без ограничения
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+type dateUnit int
+
+const (
+	dayUnit dateUnit = iota
+	weekUnit
+	monthUnit
+	yearUnit
+)
+
+var (
+	dateUnits = map[string]dateUnit{
+		"день": dayUnit, "дня": dayUnit, "дней": dayUnit,
+		"неделя": weekUnit, "неделе": weekUnit, "неделю": weekUnit, "недели": weekUnit, "недель": weekUnit,
+		"месяц": monthUnit, "месяце": monthUnit, "месяца": monthUnit, "месяцев": monthUnit,
+		"год": yearUnit, "году": yearUnit, "года": yearUnit, "лет": yearUnit,
+		"day": dayUnit, "days": dayUnit,
+		"week": weekUnit, "weeks": weekUnit,
+		"month": monthUnit, "months": monthUnit,
+		"year": yearUnit, "years": yearUnit,
+	}
+
+	// сдвиг относительно сегодняшнего дня
+	relativeDays = map[string]int{
+		"сегодня": 0, "вчера": -1, "позавчера": -2,
+		"today": 0, "yesterday": -1, "day before yesterday": -2,
+	}
+
+	// сдвиг календарного периода относительно текущего
+	periodOffsets = map[string]int{
+		"этот": 0, "этой": 0, "этом": 0, "эту": 0,
+		"текущий": 0, "текущей": 0, "текущем": 0, "текущую": 0,
+		"прошлый": -1, "прошлой": -1, "прошлом": -1, "прошлую": -1,
+		"прошедший": -1, "прошедшей": -1, "прошедшем": -1, "прошедшую": -1,
+		"предыдущий": -1, "предыдущей": -1, "предыдущем": -1, "предыдущую": -1,
+		"позапрошлый": -2, "позапрошлой": -2, "позапрошлом": -2, "позапрошлую": -2,
+		"this": 0, "current": 0, "last": -1, "previous": -1,
+	}
+
+	// слова, после которых идет скользящий период: "последние 3 дня", "past week"
+	rollingWords = map[string]bool{
+		"последний": true, "последнюю": true, "последние": true, "последних": true, "последней": true,
+		"past": true,
+	}
+
+	agoWords = map[string]bool{"назад": true, "ago": true}
+
+	// предлоги, которые не влияют на период. Значение - начинается ли после предлога скользящий период ("за неделю")
+	datePrepositions = map[string]bool{
+		"в": false, "во": false, "на": false, "за": true,
+		"in": false, "on": false, "during": false, "the": false, "over": true, "for": true, "within": true,
+	}
+
+	// слова, которые могут идти после года: "в 2023 году", "в марте 2023 г."
+	yearWords = map[string]bool{"год": true, "году": true, "года": true, "г": true, "г.": true}
+
+	numberWords = map[string]int{
+		"один": 1, "одну": 1, "одна": 1, "пару": 2, "два": 2, "две": 2, "три": 3, "четыре": 4, "пять": 5,
+		"шесть": 6, "семь": 7, "восемь": 8, "девять": 9, "десять": 10,
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	}
+
+	monthNames = map[string]time.Month{
+		"январь": time.January, "январе": time.January, "января": time.January,
+		"февраль": time.February, "феврале": time.February, "февраля": time.February,
+		"март": time.March, "марте": time.March, "марта": time.March,
+		"апрель": time.April, "апреле": time.April, "апреля": time.April,
+		"май": time.May, "мае": time.May, "мая": time.May,
+		"июнь": time.June, "июне": time.June, "июня": time.June,
+		"июль": time.July, "июле": time.July, "июля": time.July,
+		"август": time.August, "августе": time.August, "августа": time.August,
+		"сентябрь": time.September, "сентябре": time.September, "сентября": time.September,
+		"октябрь": time.October, "октябре": time.October, "октября": time.October,
+		"ноябрь": time.November, "ноябре": time.November, "ноября": time.November,
+		"декабрь": time.December, "декабре": time.December, "декабря": time.December,
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	}
+
+	weekdayNames = map[string]time.Weekday{
+		"понедельник": time.Monday, "понедельника": time.Monday,
+		"вторник": time.Tuesday, "вторника": time.Tuesday,
+		"среда": time.Wednesday, "среду": time.Wednesday, "среды": time.Wednesday,
+		"четверг": time.Thursday, "четверга": time.Thursday,
+		"пятница": time.Friday, "пятницу": time.Friday, "пятницы": time.Friday,
+		"суббота": time.Saturday, "субботу": time.Saturday, "субботы": time.Saturday,
+		"воскресенье": time.Sunday, "воскресенья": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+		"sunday": time.Sunday, "sun": time.Sunday,
+	}
+
+	// слова, которыми начинается период с двумя границами: "с 1 по 15 марта", "from monday to friday"
+	rangeStarts = map[string]bool{"с": true, "со": true, "from": true, "since": true, "between": true}
+	// слова между границами периода
+	rangeSeparators = map[string]bool{"по": true, "до": true, "-": true, "—": true, "to": true, "till": true, "until": true, "and": true}
+)
+
+// ParseDateExpression разбирает описание периода на естественном языке. Период считается относительно now
+// и в его часовом поясе, поэтому now нужно передавать в часовом поясе пользователя
+func ParseDateExpression(expr string, now time.Time) (DateRange, error) {
+	res, ok := parseDateRange(dateTokens(expr), now)
+	if !ok {
+		return DateRange{}, fmt.Errorf("%w: %q", ErrInvalidDateExpression, expr)
+	}
+
+	if !res.From.IsZero() && !res.To.IsZero() && res.From.After(res.To) {
+		return DateRange{}, fmt.Errorf("%w: %q: start of the period is after its end", ErrInvalidDateExpression, expr)
+	}
+
+	return res, nil
+}
+
+// dateTokens приводит описание к нижнему регистру и разбивает на слова, отбрасывая слова "год", "г." после года
+func dateTokens(expr string) []string {
+	expr = strings.ReplaceAll(strings.ToLower(expr), "ё", "е")
+	expr = strings.ReplaceAll(expr, ",", " ")
+
+	fields := strings.Fields(expr)
+	res := make([]string, 0, len(fields))
+
+	for i, field := range fields {
+		if yearWords[field] && i > 0 && isYear(fields[i-1]) {
+			continue
+		}
+
+		res = append(res, field)
+	}
+
+	return res
+}
+
+// parseDateRange разбирает период, у которого может быть явно указано начало и / или конец
+func parseDateRange(tokens []string, now time.Time) (DateRange, bool) {
+	if len(tokens) < 2 {
+		return parsePeriod(tokens, now)
+	}
+
+	switch first, rest := tokens[0], tokens[1:]; {
+	case rangeStarts[first]:
+		// разделитель ищем со второго слова: у каждой границы должно быть хотя бы одно слово
+		for i := 1; i < len(rest)-1; i++ {
+			if !rangeSeparators[rest[i]] {
+				continue
+			}
+
+			return parseBounds(rest[:i], rest[i+1:], now)
+		}
+
+		if first == "between" {
+			return DateRange{}, false
+		}
+
+		// открытый период: "с марта" - с начала марта без ограничения сверху
+		from, ok := parsePeriod(rest, now)
+
+		return DateRange{From: from.From}, ok
+	case first == "до" || first == "before":
+		// строго раньше периода
+		to, ok := parsePeriod(rest, now)
+
+		return DateRange{To: to.From.Add(-time.Second)}, ok
+	case first == "по" || first == "until" || first == "till":
+		to, ok := parsePeriod(rest, now)
+
+		return DateRange{To: to.To}, ok
+	}
+
+	return parsePeriod(tokens, now)
+}
+
+// parseBounds разбирает период с началом и концом. Если у начала не указан месяц или год,
+// они берутся из конца: "с 1 по 15 марта", "с марта по май 2023"
+func parseBounds(fromTokens, toTokens []string, now time.Time) (DateRange, bool) {
+	to, ok := parsePeriod(toTokens, now)
+	if !ok {
+		return DateRange{}, false
+	}
+
+	from, ok := parsePeriod(slices.Concat(fromTokens, inheritedTokens(fromTokens, toTokens)), now)
+	if !ok {
+		from, ok = parsePeriod(fromTokens, now)
+	}
+
+	return DateRange{From: from.From, To: to.To}, ok
+}
+
+// inheritedTokens возвращает слова конца периода, которых не хватает началу: месяц с годом или только год
+func inheritedTokens(fromTokens, toTokens []string) []string {
+	hasMonth := slices.ContainsFunc(fromTokens, isMonth)
+	hasYear := slices.ContainsFunc(fromTokens, isYear)
+
+	if !hasMonth {
+		if i := slices.IndexFunc(toTokens, isMonth); i >= 0 {
+			return toTokens[i:]
+		}
+	}
+
+	if last := toTokens[len(toTokens)-1]; !hasYear && isYear(last) {
+		return []string{last}
+	}
+
+	return nil
+}
+
+func isMonth(tok string) bool {
+	_, ok := monthNames[tok]
+	return ok
+}
+
+func isYear(tok string) bool {
+	_, ok := parseYear(tok)
+	return ok
+}
+
+// parsePeriod разбирает одиночный период: день, неделю, месяц, год или несколько последних дней / недель / ...
+func parsePeriod(tokens []string, now time.Time) (DateRange, bool) {
+	rolling := false
+
+	for len(tokens) > 0 {
+		startsRolling, ok := datePrepositions[tokens[0]]
+		if !ok {
+			break
+		}
+
+		rolling = rolling || startsRolling
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 {
+		return DateRange{}, false
+	}
+
+	if offset, ok := relativeDays[strings.Join(tokens, " ")]; ok {
+		return period(now, dayUnit, offset), true
+	}
+
+	if day, ok := parseDate(tokens, now); ok {
+		return period(day, dayUnit, 0), true
+	}
+
+	if month, ok := parseMonth(tokens, now); ok {
+		return period(month, monthUnit, 0), true
+	}
+
+	if len(tokens) == 1 {
+		if year, ok := parseYear(tokens[0]); ok {
+			return period(time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location()), yearUnit, 0), true
+		}
+	}
+
+	if day, ok := parseWeekday(tokens, now); ok {
+		return period(day, dayUnit, 0), true
+	}
+
+	// "на прошлой неделе", "this month"
+	if len(tokens) == 2 {
+		offset, okOffset := periodOffsets[tokens[0]]
+		unit, okUnit := dateUnits[tokens[1]]
+
+		if okOffset && okUnit {
+			return period(now, unit, offset), true
+		}
+	}
+
+	// "последние 3 дня", "past week", "last 3 days"
+	if rollingWords[tokens[0]] || (tokens[0] == "last" && len(tokens) == 3) {
+		rolling = true
+		tokens = tokens[1:]
+	}
+
+	count, unit, ago, ok := parseCountedUnit(tokens)
+	if !ok {
+		return DateRange{}, false
+	}
+
+	switch {
+	case ago:
+		return period(now, unit, -count), true
+	case rolling:
+		return rollingPeriod(now, unit, count), true
+	default:
+		return DateRange{}, false
+	}
+}
+
+// parseCountedUnit разбирает "[N] единиц [назад]": "3 дня назад", "неделю", "two weeks ago"
+func parseCountedUnit(tokens []string) (count int, unit dateUnit, ago bool, ok bool) {
+	if len(tokens) > 0 && agoWords[tokens[len(tokens)-1]] {
+		ago = true
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	count = 1
+
+	switch len(tokens) {
+	case 1:
+	case 2:
+		if count, ok = parseCount(tokens[0]); !ok {
+			return 0, 0, false, false
+		}
+
+		tokens = tokens[1:]
+	default:
+		return 0, 0, false, false
+	}
+
+	unit, ok = dateUnits[tokens[0]]
+
+	return count, unit, ago, ok
+}
+
+// parseDate разбирает конкретный день: "15 марта 2024", "march 15", "15.03.2024", "2024-03-15"
+func parseDate(tokens []string, now time.Time) (time.Time, bool) {
+	var (
+		day, year int
+		month     time.Month
+		ok        bool
+	)
+
+	switch len(tokens) {
+	case 1:
+		return parseNumericDate(tokens[0], now)
+	case 2, 3:
+		// "15 марта" или "march 15"
+		if day, ok = parseDay(tokens[0]); ok {
+			month, ok = monthNames[tokens[1]]
+		} else if month, ok = monthNames[tokens[0]]; ok {
+			day, ok = parseDay(tokens[1])
+		}
+
+		if !ok {
+			return time.Time{}, false
+		}
+
+		if len(tokens) == 3 {
+			if year, ok = parseYear(tokens[2]); !ok {
+				return time.Time{}, false
+			}
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	return dateOf(year, month, day, now)
+}
+
+// parseNumericDate разбирает даты в форматах 2024-03-15, 15.03.2024 и 15.03
+func parseNumericDate(tok string, now time.Time) (time.Time, bool) {
+	if t, err := time.ParseInLocation("2006-01-02", tok, now.Location()); err == nil {
+		return t, true
+	}
+
+	parts := strings.Split(tok, ".")
+	if len(parts) != 2 && len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	day, okDay := parseDay(parts[0])
+	month, okMonth := parseNumber(parts[1], 1, 12)
+
+	if !okDay || !okMonth {
+		return time.Time{}, false
+	}
+
+	year := 0
+	if len(parts) == 3 {
+		var ok bool
+		if year, ok = parseYear(parts[2]); !ok {
+			return time.Time{}, false
+		}
+	}
+
+	return dateOf(year, time.Month(month), day, now)
+}
+
+// dateOf возвращает начало дня. Если год не указан (0), берется последняя такая дата, не позже сегодняшнего дня
+func dateOf(year int, month time.Month, day int, now time.Time) (time.Time, bool) {
+	guessYear := year == 0
+	if guessYear {
+		year = now.Year()
+	}
+
+	t := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	if guessYear && t.After(now) {
+		t = time.Date(year-1, month, day, 0, 0, 0, 0, now.Location())
+	}
+
+	// 31 февраля и т.п.
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// parseMonth разбирает месяц: "марте", "march 2023". Если год не указан, берется последний такой месяц
+func parseMonth(tokens []string, now time.Time) (time.Time, bool) {
+	if len(tokens) == 0 || len(tokens) > 2 {
+		return time.Time{}, false
+	}
+
+	month, ok := monthNames[tokens[0]]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	year := now.Year()
+
+	if len(tokens) == 2 {
+		if year, ok = parseYear(tokens[1]); !ok {
+			return time.Time{}, false
+		}
+	} else if month > now.Month() {
+		year--
+	}
+
+	return time.Date(year, month, 1, 0, 0, 0, 0, now.Location()), true
+}
+
+// parseWeekday разбирает день недели: "в понедельник", "в прошлую пятницу", "this friday".
+// Без уточнения берется последний такой день, включая сегодняшний
+func parseWeekday(tokens []string, now time.Time) (time.Time, bool) {
+	var (
+		offset    int
+		hasOffset bool
+	)
+
+	if len(tokens) == 2 {
+		if offset, hasOffset = periodOffsets[tokens[0]]; !hasOffset {
+			return time.Time{}, false
+		}
+
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) != 1 {
+		return time.Time{}, false
+	}
+
+	weekday, ok := weekdayNames[tokens[0]]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if hasOffset {
+		week := shift(startOf(now, weekUnit), weekUnit, offset)
+		return week.AddDate(0, 0, weekdayIndex(weekday)), true
+	}
+
+	today := startOf(now, dayUnit)
+	diff := (weekdayIndex(now.Weekday()) - weekdayIndex(weekday) + 7) % 7
+
+	return today.AddDate(0, 0, -diff), true
+}
+
+func parseCount(tok string) (int, bool) {
+	if n, ok := numberWords[tok]; ok {
+		return n, true
+	}
+
+	return parseNumber(tok, 1, 10000)
+}
+
+// parseDay разбирает день месяца, в том числе с английским окончанием: 1st, 2nd, 15th
+func parseDay(tok string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if trimmed, ok := strings.CutSuffix(tok, suffix); ok {
+			tok = trimmed
+			break
+		}
+	}
+
+	return parseNumber(tok, 1, 31)
+}
+
+func parseYear(tok string) (int, bool) {
+	if len(tok) != 4 {
+		return 0, false
+	}
+
+	return parseNumber(tok, 1970, 9999)
+}
+
+func parseNumber(tok string, lowest, highest int) (int, bool) {
+	n, err := strconv.Atoi(tok)
+	if err != nil || n < lowest || n > highest {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// period возвращает календарный период, в который попадает t, сдвинутый на offset таких периодов
+func period(t time.Time, unit dateUnit, offset int) DateRange {
+	from := shift(startOf(t, unit), unit, offset)
+
+	return DateRange{
+		From: from,
+		To:   shift(from, unit, 1).Add(-time.Second),
+	}
+}
+
+// rollingPeriod возвращает последние count дней / недель / ..., включая сегодняшний день
+func rollingPeriod(now time.Time, unit dateUnit, count int) DateRange {
+	today := startOf(now, dayUnit)
+
+	return DateRange{
+		From: shift(today, unit, -count).AddDate(0, 0, 1),
+		To:   today.AddDate(0, 0, 1).Add(-time.Second),
+	}
+}
+
+// startOf возвращает начало дня / недели / месяца / года, в который попадает t
+func startOf(t time.Time, unit dateUnit) time.Time {
+	switch unit {
+	case weekUnit:
+		day := startOf(t, dayUnit)
+		return day.AddDate(0, 0, -weekdayIndex(t.Weekday()))
+	case monthUnit:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case yearUnit:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// shift сдвигает t на n дней / недель / месяцев / лет. При сдвиге на месяцы день не выходит за конец месяца:
+// 31 марта - 1 месяц = 29 февраля
+func shift(t time.Time, unit dateUnit, n int) time.Time {
+	switch unit {
+	case weekUnit:
+		return t.AddDate(0, 0, 7*n)
+	case monthUnit:
+		return addMonths(t, n)
+	case yearUnit:
+		return addMonths(t, 12*n)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// weekdayIndex возвращает номер дня недели, начиная с понедельника (0)
+func weekdayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,334 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateExpression(t *testing.T) {
+	type test struct {
+		name string
+		expr string
+		want DateRange
+		err  error
+	}
+
+	loc := time.FixedZone("MSK", 3*60*60)
+
+	// среда, 15 мая 2024
+	now := time.Date(2024, time.May, 15, 14, 30, 0, 0, loc)
+
+	start := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+
+	end := func(year int, month time.Month, day int) time.Time {
+		return start(year, month, day+1).Add(-time.Second)
+	}
+
+	day := func(year int, month time.Month, d int) DateRange {
+		return DateRange{From: start(year, month, d), To: end(year, month, d)}
+	}
+
+	tests := []test{
+		// относительные дни
+		{name: "сегодня", expr: "сегодня", want: day(2024, time.May, 15)},
+		{name: "вчера", expr: "Вчера", want: day(2024, time.May, 14)},
+		{name: "позавчера", expr: "позавчера", want: day(2024, time.May, 13)},
+		{name: "today", expr: "today", want: day(2024, time.May, 15)},
+		{name: "yesterday", expr: "Yesterday", want: day(2024, time.May, 14)},
+		{name: "the day before yesterday", expr: "the day before yesterday", want: day(2024, time.May, 13)},
+
+		// календарные периоды
+		{
+			name: "на этой неделе",
+			expr: "на этой неделе",
+			want: DateRange{From: start(2024, time.May, 13), To: end(2024, time.May, 19)},
+		},
+		{
+			name: "на прошлой неделе",
+			expr: "на прошлой неделе",
+			want: DateRange{From: start(2024, time.May, 6), To: end(2024, time.May, 12)},
+		},
+		{
+			name: "на позапрошлой неделе",
+			expr: "на позапрошлой неделе",
+			want: DateRange{From: start(2024, time.April, 29), To: end(2024, time.May, 5)},
+		},
+		{
+			name: "last week",
+			expr: "last week",
+			want: DateRange{From: start(2024, time.May, 6), To: end(2024, time.May, 12)},
+		},
+		{
+			name: "в этом месяце",
+			expr: "в этом месяце",
+			want: DateRange{From: start(2024, time.May, 1), To: end(2024, time.May, 31)},
+		},
+		{
+			name: "в прошлом месяце",
+			expr: "В ПРОШЛОМ МЕСЯЦЕ",
+			want: DateRange{From: start(2024, time.April, 1), To: end(2024, time.April, 30)},
+		},
+		{
+			name: "this year",
+			expr: "this year",
+			want: DateRange{From: start(2024, time.January, 1), To: end(2024, time.December, 31)},
+		},
+		{
+			name: "в прошлом году",
+			expr: "в прошлом году",
+			want: DateRange{From: start(2023, time.January, 1), To: end(2023, time.December, 31)},
+		},
+
+		// N периодов назад
+		{name: "3 дня назад", expr: "3 дня назад", want: day(2024, time.May, 12)},
+		{name: "пару дней назад", expr: "пару дней назад", want: day(2024, time.May, 13)},
+		{
+			name: "неделю назад",
+			expr: "неделю назад",
+			want: DateRange{From: start(2024, time.May, 6), To: end(2024, time.May, 12)},
+		},
+		{
+			name: "2 месяца назад",
+			expr: "2 месяца назад",
+			want: DateRange{From: start(2024, time.March, 1), To: end(2024, time.March, 31)},
+		},
+		{
+			name: "two weeks ago",
+			expr: "two weeks ago",
+			want: DateRange{From: start(2024, time.April, 29), To: end(2024, time.May, 5)},
+		},
+		{
+			name: "a year ago",
+			expr: "a year ago",
+			want: DateRange{From: start(2023, time.January, 1), To: end(2023, time.December, 31)},
+		},
+
+		// скользящие периоды
+		{
+			name: "за последние 3 дня",
+			expr: "за последние 3 дня",
+			want: DateRange{From: start(2024, time.May, 13), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "за неделю",
+			expr: "за неделю",
+			want: DateRange{From: start(2024, time.May, 9), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "за последний месяц",
+			expr: "за последний месяц",
+			want: DateRange{From: start(2024, time.April, 16), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "past week",
+			expr: "over the past week",
+			want: DateRange{From: start(2024, time.May, 9), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "last 3 days",
+			expr: "in the last 3 days",
+			want: DateRange{From: start(2024, time.May, 13), To: end(2024, time.May, 15)},
+		},
+
+		// дни недели
+		{name: "в понедельник", expr: "в понедельник", want: day(2024, time.May, 13)},
+		{name: "в среду - сегодня", expr: "в среду", want: day(2024, time.May, 15)},
+		{name: "в пятницу - прошедшая", expr: "в пятницу", want: day(2024, time.May, 10)},
+		{name: "во вторник", expr: "во вторник", want: day(2024, time.May, 14)},
+		{name: "в прошлый понедельник", expr: "в прошлый понедельник", want: day(2024, time.May, 6)},
+		{name: "friday", expr: "on Friday", want: day(2024, time.May, 10)},
+		{name: "this friday", expr: "this friday", want: day(2024, time.May, 17)},
+		{name: "last monday", expr: "last monday", want: day(2024, time.May, 6)},
+
+		// месяцы
+		{
+			name: "в марте",
+			expr: "в марте",
+			want: DateRange{From: start(2024, time.March, 1), To: end(2024, time.March, 31)},
+		},
+		{
+			name: "в июне - еще не наступил",
+			expr: "в июне",
+			want: DateRange{From: start(2023, time.June, 1), To: end(2023, time.June, 30)},
+		},
+		{
+			name: "в феврале високосного года",
+			expr: "в феврале",
+			want: DateRange{From: start(2024, time.February, 1), To: end(2024, time.February, 29)},
+		},
+		{
+			name: "в марте 2023 года",
+			expr: "в марте 2023 года",
+			want: DateRange{From: start(2023, time.March, 1), To: end(2023, time.March, 31)},
+		},
+		{
+			name: "in march 2023",
+			expr: "in March 2023",
+			want: DateRange{From: start(2023, time.March, 1), To: end(2023, time.March, 31)},
+		},
+
+		// конкретные даты
+		{name: "15 марта", expr: "15 марта", want: day(2024, time.March, 15)},
+		{name: "20 мая - еще не наступило", expr: "20 мая", want: day(2023, time.May, 20)},
+		{name: "15 марта 2023 года", expr: "15 марта 2023 года", want: day(2023, time.March, 15)},
+		{name: "15 марта 2023 г.", expr: "15 марта 2023 г.", want: day(2023, time.March, 15)},
+		{name: "15.03.2023", expr: "15.03.2023", want: day(2023, time.March, 15)},
+		{name: "15.03", expr: "15.03", want: day(2024, time.March, 15)},
+		{name: "2023-03-15", expr: "2023-03-15", want: day(2023, time.March, 15)},
+		{name: "march 15th, 2023", expr: "March 15th, 2023", want: day(2023, time.March, 15)},
+		{name: "15 march", expr: "15 march", want: day(2024, time.March, 15)},
+
+		// годы
+		{
+			name: "в 2023 году",
+			expr: "в 2023 году",
+			want: DateRange{From: start(2023, time.January, 1), To: end(2023, time.December, 31)},
+		},
+		{
+			name: "in 2022",
+			expr: "in 2022",
+			want: DateRange{From: start(2022, time.January, 1), To: end(2022, time.December, 31)},
+		},
+
+		// периоды с границами
+		{
+			name: "с 1 по 15 марта",
+			expr: "с 1 по 15 марта",
+			want: DateRange{From: start(2024, time.March, 1), To: end(2024, time.March, 15)},
+		},
+		{
+			name: "с марта по май 2023",
+			expr: "с марта по май 2023",
+			want: DateRange{From: start(2023, time.March, 1), To: end(2023, time.May, 31)},
+		},
+		{
+			name: "с понедельника по среду",
+			expr: "с понедельника по среду",
+			want: DateRange{From: start(2024, time.May, 13), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "с 1 марта до 15 апреля",
+			expr: "с 1 марта до 15 апреля",
+			want: DateRange{From: start(2024, time.March, 1), To: end(2024, time.April, 15)},
+		},
+		{
+			name: "from monday to wednesday",
+			expr: "from monday to wednesday",
+			want: DateRange{From: start(2024, time.May, 13), To: end(2024, time.May, 15)},
+		},
+		{
+			name: "between march 1 and march 15",
+			expr: "between march 1 and march 15",
+			want: DateRange{From: start(2024, time.March, 1), To: end(2024, time.March, 15)},
+		},
+		{
+			name: "с 10 мая",
+			expr: "с 10 мая",
+			want: DateRange{From: start(2024, time.May, 10)},
+		},
+		{
+			name: "since last week",
+			expr: "since last week",
+			want: DateRange{From: start(2024, time.May, 6)},
+		},
+		{
+			name: "до 15 марта",
+			expr: "до 15 марта",
+			want: DateRange{To: end(2024, time.March, 14)},
+		},
+		{
+			name: "before 2024",
+			expr: "before 2024",
+			want: DateRange{To: end(2023, time.December, 31)},
+		},
+		{
+			name: "по 15 марта",
+			expr: "по 15 марта",
+			want: DateRange{To: end(2024, time.March, 15)},
+		},
+
+		// ошибки
+		{name: "empty", expr: "  ", err: ErrInvalidDateExpression},
+		{name: "unknown word", expr: "завтра", err: ErrInvalidDateExpression},
+		{name: "unit without period", expr: "на неделе", err: ErrInvalidDateExpression},
+		{name: "invalid day", expr: "31 февраля", err: ErrInvalidDateExpression},
+		{name: "day out of range", expr: "32 марта", err: ErrInvalidDateExpression},
+		{name: "invalid numeric date", expr: "15.13.2023", err: ErrInvalidDateExpression},
+		{name: "between without end", expr: "between march", err: ErrInvalidDateExpression},
+		{name: "invalid range end", expr: "с 1 марта по завтра", err: ErrInvalidDateExpression},
+		{name: "start after end", expr: "с 15 мая по 1 мая", err: ErrInvalidDateExpression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateExpression(tt.expr, now)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDateExpression_Timezone(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	vladivostok := time.FixedZone("VLAT", 10*60*60)
+
+	// в Москве еще 14 мая, во Владивостоке уже 15
+	now := time.Date(2024, time.May, 14, 20, 0, 0, 0, moscow)
+
+	got, err := ParseDateExpression("сегодня", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.May, 14, 0, 0, 0, 0, moscow), got.From)
+
+	got, err = ParseDateExpression("сегодня", now.In(vladivostok))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.May, 15, 0, 0, 0, 0, vladivostok), got.From)
+}
+
+func TestShift(t *testing.T) {
+	type test struct {
+		name string
+		t    time.Time
+		unit dateUnit
+		n    int
+		want time.Time
+	}
+
+	tests := []test{
+		{
+			name: "month back from the end of march",
+			t:    time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			unit: monthUnit,
+			n:    -1,
+			want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year back from leap day",
+			t:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			unit: yearUnit,
+			n:    -1,
+			want: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weeks",
+			t:    time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC),
+			unit: weekUnit,
+			n:    -2,
+			want: time.Date(2023, time.December, 20, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, shift(tt.t, tt.unit, tt.n))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/deletebyquery"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/update"
+	"github.com/google/uuid"
 )
 
 type ElasticIndex string
@@ -21,16 +22,20 @@ func (s ElasticIndex) String() string {
 
 // Структура для сохранения и поиска в ElasticSearch
 type Data struct {
-	Model model // Note / Reminder
-	Index ElasticIndex
+	Model  model // Note / Reminder
+	Index  ElasticIndex
+	Filter SearchFilter // фильтры для поиска
 }
 
 type model interface {
 	validate() error
 	getVal() any
 	searchByIDQuery() (*search.Request, error)
-	searchByTextQuery() (*search.Request, error)
+	searchByTextQuery(filter SearchFilter) (*search.Request, error)
+	searchByTextInSpacesQuery(spaceIDs []uuid.UUID, filter SearchFilter) (*search.Request, error)
+	suggestQuery(size int) (*search.Request, error)
 	deleteByQuery() (*deletebyquery.Request, error)
+	deleteBySpaceQuery() (*deletebyquery.Request, error)
 	updateQuery() (*update.Request, error)
 	setElasticID(id string)
 }
@@ -45,17 +50,33 @@ func (d *Data) SetElasticID(id string) {
 	d.Model.setElasticID(id)
 }
 
+// DeleteByQuery возвращает запрос на удаление всех документов записи по ID из базы
 func (d *Data) DeleteByQuery() (*deletebyquery.Request, error) {
 	return d.Model.deleteByQuery()
 }
 
+// DeleteBySpaceQuery возвращает запрос на удаление всех записей пространства
+func (d *Data) DeleteBySpaceQuery() (*deletebyquery.Request, error) {
+	return d.Model.deleteBySpaceQuery()
+}
+
 func (d *Data) UpdateQuery() (*update.Request, error) {
 	return d.Model.updateQuery()
 }
 
 // SearchByTextQuery возвращает готовый запрос для поиска по тексту
 func (d *Data) SearchByTextQuery() (*search.Request, error) {
-	return d.Model.searchByTextQuery()
+	return d.Model.searchByTextQuery(d.Filter)
+}
+
+// SearchByTextInSpacesQuery возвращает готовый запрос для поиска по тексту в нескольких пространствах
+func (d *Data) SearchByTextInSpacesQuery(spaceIDs []uuid.UUID) (*search.Request, error) {
+	return d.Model.searchByTextInSpacesQuery(spaceIDs, d.Filter)
+}
+
+// SuggestQuery возвращает готовый запрос для подсказок при вводе
+func (d *Data) SuggestQuery(size int) (*search.Request, error) {
+	return d.Model.suggestQuery(size)
 }
 
 func (d *Data) ValidateNote() (*Note, error) {
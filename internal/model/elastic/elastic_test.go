@@ -106,7 +106,15 @@ func (mockNote) searchByIDQuery() (*search.Request, error) {
 	return nil, nil
 }
 
-func (mockNote) searchByTextQuery() (*search.Request, error) {
+func (mockNote) searchByTextQuery(filter SearchFilter) (*search.Request, error) {
+	return nil, nil
+}
+
+func (mockNote) searchByTextInSpacesQuery(spaceIDs []uuid.UUID, filter SearchFilter) (*search.Request, error) {
+	return nil, nil
+}
+
+func (mockNote) suggestQuery(size int) (*search.Request, error) {
 	return nil, nil
 }
 
@@ -114,6 +122,10 @@ func (mockNote) deleteByQuery() (*deletebyquery.Request, error) {
 	return nil, nil
 }
 
+func (mockNote) deleteBySpaceQuery() (*deletebyquery.Request, error) {
+	return nil, nil
+}
+
 func (mockNote) updateQuery() (*update.Request, error) {
 	return nil, nil
 }
@@ -0,0 +1,173 @@
+package elastic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// NoteIndexVersion - текущая версия маппинга индекса заметок.
+// Увеличивается при любом изменении маппинга или анализаторов: приложение создаст индекс новой версии,
+// а переключить на него алиас можно после переиндексации
+const NoteIndexVersion = 1
+
+// MappingVersionMeta - ключ в _meta маппинга, под которым хранится версия индекса
+const MappingVersionMeta = "version"
+
+// TextExactField - поле с текстом заметки без стемминга и стоп-слов. По нему ищется вхождение подстроки
+const TextExactField = "Text.exact"
+
+const (
+	// анализатор текста заметок: русская и английская морфология, стоп-слова, ё = е
+	textAnalyzer = "notes_text"
+	// анализатор без морфологии: для подсказок при вводе и поиска подстроки
+	exactAnalyzer = "notes_exact"
+)
+
+var ErrMappingVersionNotFound = errors.New("mapping version not found in _meta")
+
+// IndexDefinition - описание индекса с явным маппингом. Данные хранятся в индексе `<алиас>_v<версия>`,
+// а приложение читает и пишет только через алиас, поэтому новую версию маппинга можно выкатить без простоя:
+// создать индекс новой версии, переиндексировать в него данные и переключить алиас
+type IndexDefinition struct {
+	Alias    ElasticIndex
+	Version  int
+	Settings *types.IndexSettings
+	Mappings *types.TypeMapping
+}
+
+// Name возвращает название индекса этой версии, например `notes_v1`
+func (d IndexDefinition) Name() string {
+	return IndexName(d.Alias, d.Version)
+}
+
+// NewIndexName возвращает название нового индекса этой версии, например `notes_v1_20240515143000`.
+// Переиндексация пишет в новый индекс, а старый обслуживает запросы, пока на него указывает алиас
+func (d IndexDefinition) NewIndexName(now time.Time) string {
+	return fmt.Sprintf("%s_%s", d.Name(), now.UTC().Format("20060102150405"))
+}
+
+// Owns проверяет, что индекс создан по этому описанию: при старте приложения (`notes_v1`) или переиндексацией (`notes_v1_<время>`)
+func (d IndexDefinition) Owns(index string) bool {
+	return index == d.Name() || strings.HasPrefix(index, d.Name()+"_")
+}
+
+// IndexName возвращает название индекса версии version для алиаса alias
+func IndexName(alias ElasticIndex, version int) string {
+	return fmt.Sprintf("%s_v%d", alias, version)
+}
+
+// Indices возвращает индексы, которые должны существовать при старте приложения
+func Indices() []IndexDefinition {
+	return []IndexDefinition{NoteIndexDefinition()}
+}
+
+// NoteIndexDefinition возвращает описание индекса заметок текущей версии
+func NoteIndexDefinition() IndexDefinition {
+	return IndexDefinition{
+		Alias:   NoteIndex,
+		Version: NoteIndexVersion,
+		Settings: &types.IndexSettings{
+			Analysis: analysisSettings(),
+		},
+		Mappings: &types.TypeMapping{
+			Meta_: types.Metadata{
+				MappingVersionMeta: json.RawMessage(strconv.Itoa(NoteIndexVersion)),
+			},
+			Properties: noteProperties(),
+		},
+	}
+}
+
+// MappingVersion возвращает версию маппинга, записанную в _meta при создании индекса
+func MappingVersion(mapping types.TypeMapping) (int, error) {
+	raw, ok := mapping.Meta_[MappingVersionMeta]
+	if !ok {
+		return 0, ErrMappingVersionNotFound
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("error parsing mapping version %s: %w", raw, err)
+	}
+
+	return version, nil
+}
+
+// analysisSettings возвращает анализаторы индекса заметок.
+// Стеммеры для обоих языков применяются подряд: русский не трогает латиницу, английский - кириллицу
+func analysisSettings() *types.IndexSettingsAnalysis {
+	return &types.IndexSettingsAnalysis{
+		CharFilter: map[string]types.CharFilter{
+			"yo": &types.MappingCharFilter{
+				Mappings: []string{"ё => е", "Ё => Е"},
+			},
+		},
+		Filter: map[string]types.TokenFilter{
+			"russian_stop": &types.StopTokenFilter{
+				Stopwords: []string{"_russian_"},
+			},
+			"english_stop": &types.StopTokenFilter{
+				Stopwords: []string{"_english_"},
+			},
+			"russian_stemmer": &types.StemmerTokenFilter{
+				Language: valueToPointer("russian"),
+			},
+			"english_stemmer": &types.StemmerTokenFilter{
+				Language: valueToPointer("english"),
+			},
+		},
+		Analyzer: map[string]types.Analyzer{
+			textAnalyzer: &types.CustomAnalyzer{
+				Tokenizer:  "standard",
+				CharFilter: []string{"yo"},
+				Filter: []string{
+					"lowercase",
+					"russian_stop",
+					"english_stop",
+					"russian_stemmer",
+					"english_stemmer",
+				},
+			},
+			exactAnalyzer: &types.CustomAnalyzer{
+				Tokenizer:  "standard",
+				CharFilter: []string{"yo"},
+				Filter:     []string{"lowercase"},
+			},
+		},
+	}
+}
+
+// noteProperties возвращает поля индекса заметок. Идентификаторы и тип - keyword, чтобы по ним работали
+// точные фильтры, сортировка и агрегации; Created хранится в секундах unix, как в elastic.Note
+func noteProperties() map[string]types.Property {
+	return map[string]types.Property{
+		"ID":        &types.KeywordProperty{},
+		"ElasticID": &types.KeywordProperty{},
+		"TgID":      &types.LongNumberProperty{},
+		"SpaceID":   &types.KeywordProperty{},
+		"Type":      &types.KeywordProperty{},
+		"Deleted":   &types.BooleanProperty{},
+		"Created": &types.DateProperty{
+			Format: valueToPointer("epoch_second"),
+		},
+		"File": &types.KeywordProperty{},
+		"Text": &types.TextProperty{
+			Analyzer: valueToPointer(textAnalyzer),
+			CopyTo:   []string{SuggestField},
+			Fields: map[string]types.Property{
+				"exact": &types.TextProperty{
+					Analyzer: valueToPointer(exactAnalyzer),
+				},
+			},
+		},
+		SuggestField: &types.SearchAsYouTypeProperty{
+			Analyzer: valueToPointer(exactAnalyzer),
+		},
+	}
+}
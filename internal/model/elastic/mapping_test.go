@@ -0,0 +1,140 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteIndexDefinition(t *testing.T) {
+	def := NoteIndexDefinition()
+
+	assert.Equal(t, NoteIndex, def.Alias)
+	assert.Equal(t, "notes_v1", def.Name())
+
+	version, err := MappingVersion(*def.Mappings)
+	require.NoError(t, err)
+	assert.Equal(t, NoteIndexVersion, version)
+}
+
+func TestIndexDefinition_Owns(t *testing.T) {
+	def := NoteIndexDefinition()
+
+	newIndex := def.NewIndexName(time.Date(2024, 5, 15, 17, 30, 0, 0, time.FixedZone("MSK", 3*60*60)))
+	assert.Equal(t, "notes_v1_20240515143000", newIndex)
+
+	tests := []struct {
+		index string
+		want  bool
+	}{
+		{index: "notes_v1", want: true},
+		{index: newIndex, want: true},
+		{index: "notes", want: false},
+		{index: "notes_v10", want: false},
+		{index: "notes_v2_20240515143000", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.index, func(t *testing.T) {
+			assert.Equal(t, tt.want, def.Owns(tt.index))
+		})
+	}
+}
+
+func TestNoteIndexMapping(t *testing.T) {
+	mapping, err := json.Marshal(NoteIndexDefinition().Mappings)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"_meta":{"version":1},
+		"properties":{
+			"ID":{"type":"keyword"},
+			"ElasticID":{"type":"keyword"},
+			"TgID":{"type":"long"},
+			"SpaceID":{"type":"keyword"},
+			"Type":{"type":"keyword"},
+			"Deleted":{"type":"boolean"},
+			"Created":{"type":"date","format":"epoch_second"},
+			"File":{"type":"keyword"},
+			"Text":{
+				"type":"text",
+				"analyzer":"notes_text",
+				"copy_to":["TextSuggest"],
+				"fields":{"exact":{"type":"text","analyzer":"notes_exact"}}
+			},
+			"TextSuggest":{"type":"search_as_you_type","analyzer":"notes_exact"}
+		}
+	}`, string(mapping))
+}
+
+func TestNoteIndexSettings(t *testing.T) {
+	settings, err := json.Marshal(NoteIndexDefinition().Settings)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"analysis":{
+			"char_filter":{"yo":{"type":"mapping","mappings":["ё => е","Ё => Е"]}},
+			"filter":{
+				"russian_stop":{"type":"stop","stopwords":["_russian_"]},
+				"english_stop":{"type":"stop","stopwords":["_english_"]},
+				"russian_stemmer":{"type":"stemmer","language":"russian"},
+				"english_stemmer":{"type":"stemmer","language":"english"}
+			},
+			"analyzer":{
+				"notes_text":{
+					"type":"custom",
+					"tokenizer":"standard",
+					"char_filter":["yo"],
+					"filter":["lowercase","russian_stop","english_stop","russian_stemmer","english_stemmer"]
+				},
+				"notes_exact":{
+					"type":"custom",
+					"tokenizer":"standard",
+					"char_filter":["yo"],
+					"filter":["lowercase"]
+				}
+			}
+		}
+	}`, string(settings))
+}
+
+func TestMappingVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		meta    types.Metadata
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "version set",
+			meta: types.Metadata{MappingVersionMeta: json.RawMessage("3")},
+			want: 3,
+		},
+		{
+			name:    "no meta (dynamic mapping)",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			meta:    types.Metadata{MappingVersionMeta: json.RawMessage(`"v1"`)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MappingVersion(types.TypeMapping{Meta_: tt.meta})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -23,6 +23,9 @@ type Note struct {
 	Text      string
 	SpaceID   uuid.UUID
 	Type      model_package.NoteType // тип заметки
+	Deleted   bool                   // заметка перемещена в корзину
+	Created   int64                  // дата создания заметки в unix (UTC)
+	File      string                 `json:"File,omitempty"` // название файла в Minio (если есть). Пустое не сохраняем, чтобы работал фильтр по наличию файла
 }
 
 var (
@@ -30,6 +33,8 @@ var (
 	ErrFieldElasticIDNotFilled = errors.New("field `elastic_id` not filled")
 	ErrTgIDNotFilled           = errors.New("field `TgID` not filled")
 	ErrFieldTextNotFilled      = errors.New("field `text` not filled")
+	ErrFieldSpaceIDNotFilled   = errors.New("field `space_id` not filled")
+	ErrSpacesNotFilled         = errors.New("spaces to search in not filled")
 )
 
 // ValidateNote проверяет поля структуры elastic.Data на правильность и возвращает заметку
@@ -58,64 +63,142 @@ func (n Note) getVal() interface{} {
 	return n
 }
 
-func (n Note) searchByTextQuery() (*search.Request, error) {
-	must1 := []types.Query{
-		{
-			Bool: &types.BoolQuery{
-				Should: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"Text": {
-								Query:     n.Text,
-								Operator:  &operator.Or,
-								Fuzziness: "auto",
-							},
-						},
-					},
-					{
-						Wildcard: map[string]types.WildcardQuery{
-							"Text": {
-								Value:   valueToPointer(fmt.Sprintf("*%s*", n.Text)),
-								Boost:   valueToPointer(float32(1.0)),
-								Rewrite: valueToPointer("constant_score"),
-							},
+// searchByTextQuery возвращает запрос на поиск по тексту в пространстве заметки.
+// Текст разбирается как поисковый запрос (см. ParseSearchQuery).
+// Фильтры (тип, дата, автор, файл) попадают в filter и не влияют на релевантность
+func (n Note) searchByTextQuery(filter SearchFilter) (*search.Request, error) {
+	query, err := ParseSearchQuery(n.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	must1 := append(query.must(), types.Query{
+		Bool: &types.BoolQuery{
+			Must: []types.Query{
+				{
+					Match: map[string]types.MatchQuery{
+						"SpaceID": {
+							Query: n.SpaceID.String(),
 						},
 					},
 				},
 			},
 		},
-		{
+	})
+
+	req := &search.Request{
+		Query: &types.Query{
+			Bool: &types.BoolQuery{
+				Must:    must1,
+				Filter:  query.ApplyTo(filter.WithType(n.Type)).queries(),
+				MustNot: append(notDeletedQuery(), query.mustNot()...),
+			},
+		},
+	}
+
+	return req, nil
+}
+
+// searchByTextInSpacesQuery возвращает запрос на поиск по тексту сразу в нескольких пространствах.
+// Заметки из других пространств отсекаются фильтром terms, а по найденным заметкам считается,
+// сколько из них в каждом пространстве
+func (n Note) searchByTextInSpacesQuery(spaceIDs []uuid.UUID, filter SearchFilter) (*search.Request, error) {
+	if len(spaceIDs) == 0 {
+		return nil, ErrSpacesNotFilled
+	}
+
+	query, err := ParseSearchQuery(n.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(spaceIDs))
+	for _, id := range spaceIDs {
+		ids = append(ids, id.String())
+	}
+
+	spacesFilter := types.Query{
+		Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{
+				"SpaceID": ids,
+			},
+		},
+	}
+
+	req := &search.Request{
+		Query: &types.Query{
 			Bool: &types.BoolQuery{
-				Must: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"SpaceID": {
-								Query: n.SpaceID.String(),
-							},
+				Must:    query.must(),
+				Filter:  append([]types.Query{spacesFilter}, query.ApplyTo(filter.WithType(n.Type)).queries()...),
+				MustNot: append(notDeletedQuery(), query.mustNot()...),
+			},
+		},
+		Aggregations: map[string]types.Aggregations{
+			SpacesAggregation: {
+				Terms: &types.TermsAggregation{
+					Field: valueToPointer("SpaceID"),
+					Size:  valueToPointer(len(ids)),
+				},
+			},
+		},
+	}
+
+	return req, nil
+}
+
+// textQuery - поиск по тексту заметки: нечеткое совпадение слов или вхождение подстроки
+func textQuery(text string) types.Query {
+	return types.Query{
+		Bool: &types.BoolQuery{
+			Should: []types.Query{
+				{
+					Match: map[string]types.MatchQuery{
+						"Text": {
+							Query:     text,
+							Operator:  &operator.Or,
+							Fuzziness: "auto",
+						},
+					},
+				},
+				{
+					Wildcard: map[string]types.WildcardQuery{
+						TextExactField: {
+							Value:   valueToPointer(fmt.Sprintf("*%s*", text)),
+							Boost:   valueToPointer(float32(1.0)),
+							Rewrite: valueToPointer("constant_score"),
 						},
 					},
 				},
 			},
 		},
+	}
+}
+
+// notDeletedQuery - заметки из корзины не должны попадать в результаты поиска
+func notDeletedQuery() []types.Query {
+	return []types.Query{
 		{
-			Bool: &types.BoolQuery{
-				Must: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"Type": {
-								Query: string(n.Type),
-							},
-						},
-					},
+			Term: map[string]types.TermQuery{
+				"Deleted": {
+					Value: true,
 				},
 			},
 		},
 	}
+}
+
+// searchByIDQuery возвращает запрос на поиск документов заметки по ID из базы
+func (n Note) searchByIDQuery() (*search.Request, error) {
+	if n.ID == uuid.Nil {
+		return nil, ErrFieldIDNotFilled
+	}
 
 	req := &search.Request{
 		Query: &types.Query{
-			Bool: &types.BoolQuery{
-				Must: must1,
+			Term: map[string]types.TermQuery{
+				"ID": {
+					Value: n.ID.String(),
+				},
 			},
 		},
 	}
@@ -123,12 +206,18 @@ func (n Note) searchByTextQuery() (*search.Request, error) {
 	return req, nil
 }
 
-func (n Note) searchByIDQuery() (*search.Request, error) {
-	req := &search.Request{
+// deleteByQuery возвращает запрос на удаление всех документов заметки по ID из базы.
+// Документов может быть несколько, если заметка по ошибке проиндексирована дважды
+func (n Note) deleteByQuery() (*deletebyquery.Request, error) {
+	if n.ID == uuid.Nil {
+		return nil, ErrFieldIDNotFilled
+	}
+
+	req := &deletebyquery.Request{
 		Query: &types.Query{
-			Match: map[string]types.MatchQuery{
+			Term: map[string]types.TermQuery{
 				"ID": {
-					Query: n.ID.String(),
+					Value: n.ID.String(),
 				},
 			},
 		},
@@ -137,12 +226,17 @@ func (n Note) searchByIDQuery() (*search.Request, error) {
 	return req, nil
 }
 
-func (n Note) deleteByQuery() (*deletebyquery.Request, error) {
+// deleteBySpaceQuery возвращает запрос на удаление всех заметок пространства
+func (n Note) deleteBySpaceQuery() (*deletebyquery.Request, error) {
+	if n.SpaceID == uuid.Nil {
+		return nil, ErrFieldSpaceIDNotFilled
+	}
+
 	req := &deletebyquery.Request{
 		Query: &types.Query{
-			Match: map[string]types.MatchQuery{
-				"TgID": {
-					Query: fmt.Sprintf("%d", n.TgID),
+			Term: map[string]types.TermQuery{
+				"SpaceID": {
+					Value: n.SpaceID.String(),
 				},
 			},
 		},
@@ -170,6 +264,6 @@ func (n *Note) setElasticID(id string) {
 	n.ElasticID = id
 }
 
-func valueToPointer[T string | float32 | float64](val T) *T {
+func valueToPointer[T string | int | float32 | float64](val T) *T {
 	return &val
 }
@@ -4,13 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 	model_package "webserver/internal/model"
 
-	"github.com/elastic/go-elasticsearch/v8/typedapi/core/deletebyquery"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/update"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -104,79 +103,113 @@ func TestNoteValidate(t *testing.T) {
 }
 
 func TestSearchByTextQuery(t *testing.T) {
-	n := Note{
-		ID:        uuid.New(),
-		ElasticID: "123456",
-		TgID:      12345,
-		Text:      "text note",
-		SpaceID:   uuid.New(),
+	type test struct {
+		name   string
+		note   Note
+		filter SearchFilter
+		want   string // ожидаемый фильтр запроса в JSON
 	}
 
-	must1 := []types.Query{
+	spaceID := uuid.New()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	withFile, withoutFile := true, false
+
+	tests := []test{
 		{
-			Bool: &types.BoolQuery{
-				Should: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"Text": {
-								Query:     n.Text,
-								Operator:  &operator.Or,
-								Fuzziness: "auto",
-							},
-						},
-					},
-					{
-						Wildcard: map[string]types.WildcardQuery{
-							"Text": {
-								Value:   valueToPointer(fmt.Sprintf("*%s*", n.Text)),
-								Boost:   valueToPointer(float32(1.0)),
-								Rewrite: valueToPointer("constant_score"),
-							},
-						},
-					},
-				},
-			},
+			name: "without filters",
+			note: Note{Text: "text note", SpaceID: spaceID},
+			want: `null`,
 		},
 		{
-			Bool: &types.BoolQuery{
-				Must: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"SpaceID": {
-								Query: n.SpaceID.String(),
-							},
-						},
-					},
-				},
-			},
+			name: "type from note",
+			note: Note{Text: "text note", SpaceID: spaceID, Type: model_package.TextNoteType},
+			want: `[{"terms":{"Type":["text"]}}]`,
 		},
 		{
-			Bool: &types.BoolQuery{
-				Must: []types.Query{
-					{
-						Match: map[string]types.MatchQuery{
-							"Type": {
-								Query: string(n.Type),
-							},
-						},
-					},
-				},
-			},
+			name:   "several types",
+			note:   Note{Text: "text note", SpaceID: spaceID, Type: model_package.TextNoteType},
+			filter: SearchFilter{Types: []model_package.NoteType{model_package.TextNoteType, model_package.PhotoNoteType}},
+			want:   `[{"terms":{"Type":["text","photo"]}}]`,
 		},
-	}
-
-	result := &search.Request{
-		Query: &types.Query{
-			Bool: &types.BoolQuery{
-				Must: must1,
+		{
+			name:   "date range",
+			note:   Note{Text: "text note", SpaceID: spaceID},
+			filter: SearchFilter{CreatedFrom: &from, CreatedTo: &to},
+			want:   `[{"range":{"Created":{"gte":1704067200,"lte":1706745600}}}]`,
+		},
+		{
+			name:   "only start date",
+			note:   Note{Text: "text note", SpaceID: spaceID},
+			filter: SearchFilter{CreatedFrom: &from},
+			want:   `[{"range":{"Created":{"gte":1704067200}}}]`,
+		},
+		{
+			name:   "author",
+			note:   Note{Text: "text note", SpaceID: spaceID},
+			filter: SearchFilter{AuthorID: 42},
+			want:   `[{"term":{"TgID":{"value":42}}}]`,
+		},
+		{
+			name:   "has file",
+			note:   Note{Text: "text note", SpaceID: spaceID},
+			filter: SearchFilter{HasFile: &withFile},
+			want:   `[{"exists":{"field":"File"}}]`,
+		},
+		{
+			name:   "without file",
+			note:   Note{Text: "text note", SpaceID: spaceID},
+			filter: SearchFilter{HasFile: &withoutFile},
+			want:   `[{"bool":{"must_not":[{"exists":{"field":"File"}}]}}]`,
+		},
+		{
+			name: "all filters",
+			note: Note{Text: "text note", SpaceID: spaceID, Type: model_package.TextNoteType},
+			filter: SearchFilter{
+				Types:       []model_package.NoteType{model_package.PhotoNoteType},
+				CreatedFrom: &from,
+				CreatedTo:   &to,
+				AuthorID:    42,
+				HasFile:     &withoutFile,
 			},
+			want: `[
+				{"terms":{"Type":["text","photo"]}},
+				{"range":{"Created":{"gte":1704067200,"lte":1706745600}}},
+				{"term":{"TgID":{"value":42}}},
+				{"bool":{"must_not":[{"exists":{"field":"File"}}]}}
+			]`,
 		},
 	}
 
-	actual, err := n.searchByTextQuery()
-	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.note.searchByTextQuery(tt.filter)
+			require.NoError(t, err)
 
-	assert.Equal(t, result, actual)
+			// текст и пространство влияют на релевантность, поэтому лежат в must
+			expectedMust := fmt.Sprintf(`[
+				{"bool":{"should":[
+					{"match":{"Text":{"fuzziness":"auto","operator":"or","query":%q}}},
+					{"wildcard":{"Text.exact":{"boost":1,"rewrite":"constant_score","value":%q}}}
+				]}},
+				{"bool":{"must":[{"match":{"SpaceID":{"query":%q}}}]}}
+			]`, tt.note.Text, "*"+tt.note.Text+"*", spaceID.String())
+
+			must, err := json.Marshal(actual.Query.Bool.Must)
+			require.NoError(t, err)
+			assert.JSONEq(t, expectedMust, string(must))
+
+			// фильтры не влияют на релевантность, поэтому лежат в filter
+			filter, err := json.Marshal(actual.Query.Bool.Filter)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(filter))
+
+			mustNot, err := json.Marshal(actual.Query.Bool.MustNot)
+			require.NoError(t, err)
+			assert.JSONEq(t, `[{"term":{"Deleted":{"value":true}}}]`, string(mustNot))
+		})
+	}
 }
 
 func TestGetVal(t *testing.T) {
@@ -202,9 +235,9 @@ func TestSearchByIDQuery(t *testing.T) {
 
 	result := &search.Request{
 		Query: &types.Query{
-			Match: map[string]types.MatchQuery{
+			Term: map[string]types.TermQuery{
 				"ID": {
-					Query: n.ID.String(),
+					Value: n.ID.String(),
 				},
 			},
 		},
@@ -214,6 +247,9 @@ func TestSearchByIDQuery(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, result, actual)
+
+	_, err = Note{}.searchByIDQuery()
+	assert.ErrorIs(t, err, ErrFieldIDNotFilled)
 }
 
 func TestDeleteByQuery(t *testing.T) {
@@ -225,20 +261,34 @@ func TestDeleteByQuery(t *testing.T) {
 		SpaceID:   uuid.New(),
 	}
 
-	result := &deletebyquery.Request{
-		Query: &types.Query{
-			Match: map[string]types.MatchQuery{
-				"TgID": {
-					Query: fmt.Sprintf("%d", n.TgID),
-				},
-			},
-		},
+	actual, err := n.deleteByQuery()
+	require.NoError(t, err)
+
+	// удаляются только документы этой заметки, а не все заметки автора
+	query, err := json.Marshal(actual.Query)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, fmt.Sprintf(`{"term":{"ID":{"value":"%s"}}}`, n.ID), string(query))
+
+	_, err = Note{TgID: 12345}.deleteByQuery()
+	assert.ErrorIs(t, err, ErrFieldIDNotFilled)
+}
+
+func TestDeleteBySpaceQuery(t *testing.T) {
+	n := Note{
+		SpaceID: uuid.New(),
 	}
 
-	actual, err := n.deleteByQuery()
+	actual, err := n.deleteBySpaceQuery()
 	require.NoError(t, err)
 
-	assert.Equal(t, result, actual)
+	query, err := json.Marshal(actual.Query)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, fmt.Sprintf(`{"term":{"SpaceID":{"value":"%s"}}}`, n.SpaceID), string(query))
+
+	_, err = Note{ID: uuid.New()}.deleteBySpaceQuery()
+	assert.ErrorIs(t, err, ErrFieldSpaceIDNotFilled)
 }
 
 func TestUpdateByQuery(t *testing.T) {
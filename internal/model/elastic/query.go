@@ -0,0 +1,424 @@
+package elastic
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	model_package "webserver/internal/model"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// Язык поисковых запросов:
+//
+//	купить молоко          - обычные слова: ищутся с опечатками и по подстроке, как и раньше
+//	"купить молоко"        - фраза: слова должны идти подряд
+//	-хлеб, -"белый хлеб"   - исключение: заметки с этим словом / фразой не попадут в результат
+//	молоко OR кефир        - хотя бы одно из слов / фраз
+//	type:photo             - тип заметки (можно указать несколько раз)
+//	from:12345678          - telegram id автора
+//	after:2024-01-01       - созданные в этот день или позже
+//	before:2024-02-01      - созданные раньше этого дня
+//
+// Условия, разделенные пробелом, должны выполняться одновременно.
+
+const (
+	orOperator     = "OR"
+	typeOperator   = "type"
+	fromOperator   = "from"
+	beforeOperator = "before"
+	afterOperator  = "after"
+
+	queryDateLayout = "2006-01-02"
+)
+
+// queryTerm - слово или фраза из запроса
+type queryTerm struct {
+	text   string
+	phrase bool
+}
+
+// query возвращает запрос для поиска слова / фразы в тексте заметки
+func (t queryTerm) query() types.Query {
+	if t.phrase {
+		return types.Query{
+			MatchPhrase: map[string]types.MatchPhraseQuery{
+				"Text": {
+					Query: t.text,
+				},
+			},
+		}
+	}
+
+	return textQuery(t.text)
+}
+
+// SearchQuery - разобранный поисковый запрос
+type SearchQuery struct {
+	words    []string      // обычные слова
+	phrases  []string      // фразы в кавычках
+	anyOf    [][]queryTerm // группы, связанные через OR: должно совпасть хотя бы одно из условий группы
+	excluded []queryTerm   // исключения
+	filter   SearchFilter  // операторы type:, from:, before:, after:
+}
+
+// token - элемент запроса
+type token struct {
+	pos      int // позиция в запросе (в символах, начиная с 1)
+	term     queryTerm
+	or       bool
+	excluded bool
+	operator bool
+}
+
+// ParseSearchQuery разбирает поисковый запрос. При синтаксической ошибке возвращает
+// *model.SearchSyntaxError с позицией проблемного места
+func ParseSearchQuery(query string) (SearchQuery, error) {
+	var res SearchQuery
+
+	tokens, err := tokenize(query, &res.filter)
+	if err != nil {
+		return SearchQuery{}, err
+	}
+
+	var (
+		group []queryTerm // текущая группа условий, связанных через OR
+		orPos int         // позиция последнего OR, после которого еще не было условия
+		prev  *token
+	)
+
+	flush := func() {
+		switch {
+		case len(group) == 1 && group[0].phrase:
+			res.phrases = append(res.phrases, group[0].text)
+		case len(group) == 1:
+			res.words = append(res.words, group[0].text)
+		case len(group) > 1:
+			res.anyOf = append(res.anyOf, group)
+		}
+
+		group = nil
+	}
+
+	for i := range tokens {
+		tok := tokens[i]
+
+		switch {
+		case tok.or:
+			if prev == nil || prev.or || prev.excluded || prev.operator {
+				return SearchQuery{}, syntaxError(tok.pos, "OR must be placed between two words or phrases")
+			}
+
+			orPos = tok.pos
+		case tok.excluded, tok.operator:
+			if orPos != 0 {
+				return SearchQuery{}, syntaxError(tok.pos, "exclusions and operators cannot be combined with OR")
+			}
+
+			if tok.excluded {
+				res.excluded = append(res.excluded, tok.term)
+			}
+		default:
+			if orPos == 0 {
+				flush()
+			}
+
+			group = append(group, tok.term)
+			orPos = 0
+		}
+
+		prev = &tokens[i]
+	}
+
+	if orPos != 0 {
+		return SearchQuery{}, syntaxError(orPos, "OR must be placed between two words or phrases")
+	}
+
+	flush()
+
+	return res, nil
+}
+
+// tokenize разбивает запрос на слова, фразы, OR и операторы. Значения операторов сразу записываются в фильтр
+func tokenize(query string, filter *SearchFilter) ([]token, error) {
+	runes := []rune(query)
+
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		tok := token{pos: i + 1}
+
+		if runes[i] == '-' {
+			tok.excluded = true
+			i++
+
+			if i == len(runes) || unicode.IsSpace(runes[i]) {
+				return nil, syntaxError(tok.pos, "nothing to exclude after `-`")
+			}
+		}
+
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, syntaxError(i+1, "unclosed quote")
+			}
+
+			phrase := strings.TrimSpace(string(runes[i+1 : end]))
+			if len(phrase) == 0 {
+				return nil, syntaxError(i+1, "empty phrase")
+			}
+
+			tok.term = queryTerm{text: phrase, phrase: true}
+			tokens = append(tokens, tok)
+			i = end + 1
+
+			continue
+		}
+
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' {
+			i++
+		}
+
+		word := string(runes[start:i])
+
+		if word == orOperator && !tok.excluded {
+			tok.or = true
+			tokens = append(tokens, tok)
+
+			continue
+		}
+
+		name, value, ok := strings.Cut(word, ":")
+		if ok && isOperator(name) {
+			if tok.excluded {
+				return nil, syntaxError(tok.pos, "operators cannot be excluded")
+			}
+
+			valuePos := start + len([]rune(name)) + 2
+			if err := applyOperator(filter, name, value, valuePos); err != nil {
+				return nil, err
+			}
+
+			tok.operator = true
+			tokens = append(tokens, tok)
+
+			continue
+		}
+
+		tok.term = queryTerm{text: word}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, nil
+}
+
+func isOperator(name string) bool {
+	switch name {
+	case typeOperator, fromOperator, beforeOperator, afterOperator:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyOperator записывает значение оператора в фильтр
+func applyOperator(filter *SearchFilter, name, value string, pos int) error {
+	if len(value) == 0 {
+		return syntaxError(pos, "empty value of `"+name+":`")
+	}
+
+	switch name {
+	case typeOperator:
+		noteType := model_package.NoteType(value)
+
+		switch noteType {
+		case model_package.TextNoteType, model_package.PhotoNoteType:
+		default:
+			return syntaxError(pos, "unknown note type `"+value+"`")
+		}
+
+		filter.Types = append(filter.Types, noteType)
+	case fromOperator:
+		authorID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || authorID <= 0 {
+			return syntaxError(pos, "author must be a telegram id")
+		}
+
+		filter.AuthorID = authorID
+	case beforeOperator, afterOperator:
+		date, err := time.ParseInLocation(queryDateLayout, value, time.UTC)
+		if err != nil {
+			return syntaxError(pos, "date must be in format YYYY-MM-DD")
+		}
+
+		if name == afterOperator {
+			filter.CreatedFrom = &date
+		} else {
+			// before - строго раньше указанного дня
+			to := date.Add(-time.Second)
+			filter.CreatedTo = &to
+		}
+	}
+
+	return nil
+}
+
+func syntaxError(pos int, msg string) error {
+	return &model_package.SearchSyntaxError{Position: pos, Message: msg}
+}
+
+// must возвращает условия, которые влияют на релевантность заметки
+func (q SearchQuery) must() []types.Query {
+	var res []types.Query
+
+	if len(q.words) > 0 {
+		res = append(res, textQuery(strings.Join(q.words, " ")))
+	}
+
+	for _, phrase := range q.phrases {
+		res = append(res, queryTerm{text: phrase, phrase: true}.query())
+	}
+
+	for _, group := range q.anyOf {
+		should := make([]types.Query, 0, len(group))
+		for _, term := range group {
+			should = append(should, term.query())
+		}
+
+		res = append(res, types.Query{
+			Bool: &types.BoolQuery{
+				Should:             should,
+				MinimumShouldMatch: 1,
+			},
+		})
+	}
+
+	return res
+}
+
+// mustNot возвращает исключения
+func (q SearchQuery) mustNot() []types.Query {
+	res := make([]types.Query, 0, len(q.excluded))
+
+	for _, term := range q.excluded {
+		if term.phrase {
+			res = append(res, term.query())
+			continue
+		}
+
+		// исключаем только точные совпадения слова, без опечаток и подстрок
+		res = append(res, types.Query{
+			Match: map[string]types.MatchQuery{
+				"Text": {
+					Query: term.text,
+				},
+			},
+		})
+	}
+
+	return res
+}
+
+// ApplyTo дополняет фильтр из запроса операторами из текста.
+// Типы из текста заменяют типы из запроса, остальные операторы заменяют соответствующие поля фильтра.
+// Даты из before: и after: считаются в часовом поясе filter.Location
+func (q SearchQuery) ApplyTo(filter SearchFilter) SearchFilter {
+	if len(q.filter.Types) > 0 {
+		filter.Types = q.filter.Types
+	}
+
+	if q.filter.AuthorID != 0 {
+		filter.AuthorID = q.filter.AuthorID
+	}
+
+	if q.filter.CreatedFrom != nil {
+		from := inLocation(*q.filter.CreatedFrom, filter.Location)
+		filter.CreatedFrom = &from
+	}
+
+	if q.filter.CreatedTo != nil {
+		to := inLocation(*q.filter.CreatedTo, filter.Location)
+		filter.CreatedTo = &to
+	}
+
+	return filter
+}
+
+// HasDates сообщает, есть ли в запросе операторы before: или after:, даты которых зависят от часового пояса
+func (q SearchQuery) HasDates() bool {
+	return q.filter.CreatedFrom != nil || q.filter.CreatedTo != nil
+}
+
+// inLocation переносит время, разобранное в UTC, в часовой пояс loc с тем же временем на часах
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// QueryTerm - слово или фраза из запроса. Нужна, чтобы выполнить запрос не в эластике (см. fulltext)
+type QueryTerm struct {
+	Text   string
+	Phrase bool
+}
+
+// Groups возвращает условия, которые влияют на релевантность, в виде групп: в каждой группе должно совпасть
+// хотя бы одно условие, совпасть должны все группы. Обычные слова собраны в одно условие, как и в эластике
+func (q SearchQuery) Groups() [][]QueryTerm {
+	res := make([][]QueryTerm, 0, len(q.phrases)+len(q.anyOf)+1)
+
+	if len(q.words) > 0 {
+		res = append(res, []QueryTerm{{Text: strings.Join(q.words, " ")}})
+	}
+
+	for _, phrase := range q.phrases {
+		res = append(res, []QueryTerm{{Text: phrase, Phrase: true}})
+	}
+
+	for _, group := range q.anyOf {
+		terms := make([]QueryTerm, 0, len(group))
+		for _, term := range group {
+			terms = append(terms, QueryTerm{Text: term.text, Phrase: term.phrase})
+		}
+
+		res = append(res, terms)
+	}
+
+	return res
+}
+
+// Exclusions возвращает исключения
+func (q SearchQuery) Exclusions() []QueryTerm {
+	res := make([]QueryTerm, 0, len(q.excluded))
+	for _, term := range q.excluded {
+		res = append(res, QueryTerm{Text: term.text, Phrase: term.phrase})
+	}
+
+	return res
+}
+
+// Plain возвращает все слова и фразы, которые влияют на релевантность, через пробел - для нечеткого поиска
+func (q SearchQuery) Plain() string {
+	var res []string
+
+	for _, group := range q.Groups() {
+		for _, term := range group {
+			res = append(res, term.Text)
+		}
+	}
+
+	return strings.Join(res, " ")
+}
@@ -0,0 +1,256 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	model_package "webserver/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	type test struct {
+		name string
+		text string
+		want SearchQuery
+		err  error
+	}
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+
+	tests := []test{
+		{
+			name: "plain words",
+			text: "купить молоко",
+			want: SearchQuery{words: []string{"купить", "молоко"}},
+		},
+		{
+			name: "phrase, exclusion and operator",
+			text: `"купить молоко" -хлеб type:photo`,
+			want: SearchQuery{
+				phrases:  []string{"купить молоко"},
+				excluded: []queryTerm{{text: "хлеб"}},
+				filter:   SearchFilter{Types: []model_package.NoteType{model_package.PhotoNoteType}},
+			},
+		},
+		{
+			name: "excluded phrase",
+			text: `молоко -"белый хлеб"`,
+			want: SearchQuery{
+				words:    []string{"молоко"},
+				excluded: []queryTerm{{text: "белый хлеб", phrase: true}},
+			},
+		},
+		{
+			name: "or",
+			text: `молоко OR кефир OR "ряженка 4%" хлеб`,
+			want: SearchQuery{
+				words: []string{"хлеб"},
+				anyOf: [][]queryTerm{{
+					{text: "молоко"},
+					{text: "кефир"},
+					{text: "ряженка 4%", phrase: true},
+				}},
+			},
+		},
+		{
+			name: "lowercase or is a word",
+			text: "молоко or кефир",
+			want: SearchQuery{words: []string{"молоко", "or", "кефир"}},
+		},
+		{
+			name: "all operators",
+			text: "type:text type:photo from:12345 after:2024-01-01 before:2024-02-01",
+			want: SearchQuery{
+				filter: SearchFilter{
+					Types:       []model_package.NoteType{model_package.TextNoteType, model_package.PhotoNoteType},
+					AuthorID:    12345,
+					CreatedFrom: &after,
+					CreatedTo:   &before,
+				},
+			},
+		},
+		{
+			name: "unknown operator is a word",
+			text: "время:10",
+			want: SearchQuery{words: []string{"время:10"}},
+		},
+		{
+			name: "unclosed quote",
+			text: `молоко "купить хлеб`,
+			err:  &model_package.SearchSyntaxError{Position: 8, Message: "unclosed quote"},
+		},
+		{
+			name: "empty phrase",
+			text: `молоко ""`,
+			err:  &model_package.SearchSyntaxError{Position: 8, Message: "empty phrase"},
+		},
+		{
+			name: "nothing to exclude",
+			text: "молоко - хлеб",
+			err:  &model_package.SearchSyntaxError{Position: 8, Message: "nothing to exclude after `-`"},
+		},
+		{
+			name: "or at the start",
+			text: "OR молоко",
+			err:  &model_package.SearchSyntaxError{Position: 1, Message: "OR must be placed between two words or phrases"},
+		},
+		{
+			name: "or at the end",
+			text: "молоко OR",
+			err:  &model_package.SearchSyntaxError{Position: 8, Message: "OR must be placed between two words or phrases"},
+		},
+		{
+			name: "double or",
+			text: "молоко OR OR кефир",
+			err:  &model_package.SearchSyntaxError{Position: 11, Message: "OR must be placed between two words or phrases"},
+		},
+		{
+			name: "exclusion in or",
+			text: "молоко OR -кефир",
+			err:  &model_package.SearchSyntaxError{Position: 11, Message: "exclusions and operators cannot be combined with OR"},
+		},
+		{
+			name: "excluded operator",
+			text: "молоко -type:photo",
+			err:  &model_package.SearchSyntaxError{Position: 8, Message: "operators cannot be excluded"},
+		},
+		{
+			name: "unknown type",
+			text: "молоко type:video",
+			err:  &model_package.SearchSyntaxError{Position: 13, Message: "unknown note type `video`"},
+		},
+		{
+			name: "empty operator value",
+			text: "молоко from:",
+			err:  &model_package.SearchSyntaxError{Position: 13, Message: "empty value of `from:`"},
+		},
+		{
+			name: "invalid author",
+			text: "from:@user",
+			err:  &model_package.SearchSyntaxError{Position: 6, Message: "author must be a telegram id"},
+		},
+		{
+			name: "invalid date",
+			text: "after:01.01.2024",
+			err:  &model_package.SearchSyntaxError{Position: 7, Message: "date must be in format YYYY-MM-DD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchQuery(tt.text)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.err, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchQueryCompile(t *testing.T) {
+	query, err := ParseSearchQuery(`"купить молоко" -хлеб сыр OR творог type:photo`)
+	require.NoError(t, err)
+
+	must, err := json.Marshal(query.must())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[
+		{"match_phrase":{"Text":{"query":"купить молоко"}}},
+		{"bool":{"minimum_should_match":1,"should":[
+			{"bool":{"should":[
+				{"match":{"Text":{"fuzziness":"auto","operator":"or","query":"сыр"}}},
+				{"wildcard":{"Text.exact":{"boost":1,"rewrite":"constant_score","value":"*сыр*"}}}
+			]}},
+			{"bool":{"should":[
+				{"match":{"Text":{"fuzziness":"auto","operator":"or","query":"творог"}}},
+				{"wildcard":{"Text.exact":{"boost":1,"rewrite":"constant_score","value":"*творог*"}}}
+			]}}
+		]}}
+	]`, string(must))
+
+	mustNot, err := json.Marshal(query.mustNot())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[{"match":{"Text":{"query":"хлеб"}}}]`, string(mustNot))
+
+	// тип из текста запроса заменяет тип по умолчанию
+	filter, err := json.Marshal(query.ApplyTo(SearchFilter{}.WithType(model_package.TextNoteType)).queries())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[{"terms":{"Type":["photo"]}}]`, string(filter))
+}
+
+func TestSearchQueryApplyTo_Location(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	query, err := ParseSearchQuery("молоко after:2024-05-15 before:2024-05-16")
+	require.NoError(t, err)
+	require.True(t, query.HasDates())
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		from     time.Time
+		to       time.Time
+	}{
+		{
+			name: "utc by default",
+			from: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, 5, 15, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:     "user's timezone",
+			location: moscow,
+			from:     time.Date(2024, 5, 15, 0, 0, 0, 0, moscow),
+			to:       time.Date(2024, 5, 15, 23, 59, 59, 0, moscow),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := query.ApplyTo(SearchFilter{Location: tt.location})
+
+			require.NotNil(t, filter.CreatedFrom)
+			require.NotNil(t, filter.CreatedTo)
+			assert.True(t, tt.from.Equal(*filter.CreatedFrom), "from: %s", filter.CreatedFrom)
+			assert.True(t, tt.to.Equal(*filter.CreatedTo), "to: %s", filter.CreatedTo)
+		})
+	}
+
+	plain, err := ParseSearchQuery("молоко type:photo")
+	require.NoError(t, err)
+	assert.False(t, plain.HasDates())
+}
+
+func TestSearchQueryTerms(t *testing.T) {
+	query, err := ParseSearchQuery(`купить молоко "белый хлеб" -"черный хлеб" -сыр кефир OR ряженка type:photo`)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]QueryTerm{
+		{{Text: "купить молоко"}},
+		{{Text: "белый хлеб", Phrase: true}},
+		{{Text: "кефир"}, {Text: "ряженка"}},
+	}, query.Groups())
+
+	assert.Equal(t, []QueryTerm{
+		{Text: "черный хлеб", Phrase: true},
+		{Text: "сыр"},
+	}, query.Exclusions())
+
+	assert.Equal(t, "купить молоко белый хлеб кефир ряженка", query.Plain())
+
+	// только исключения и операторы: условий на релевантность нет
+	query, err = ParseSearchQuery(`-сыр from:123`)
+	require.NoError(t, err)
+
+	assert.Empty(t, query.Groups())
+	assert.Empty(t, query.Plain())
+}
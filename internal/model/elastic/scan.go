@@ -0,0 +1,30 @@
+package elastic
+
+import (
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// ScanKeepAlive - сколько эластик держит снимок индекса (point in time) между запросами страниц при обходе индекса
+const ScanKeepAlive = "1m"
+
+// ScanNotesQuery возвращает запрос на очередную страницу при обходе всех документов индекса заметок в порядке ID.
+// Запрос идет по снимку индекса pitID, поэтому эластик сам добавляет к сортировке _shard_doc:
+// страницы не теряют и не повторяют документы, даже если у нескольких документов одинаковый ID
+func ScanNotesQuery(pitID string, size int, after []types.FieldValue) *search.Request {
+	return &search.Request{
+		Pit: &types.PointInTimeReference{
+			Id:        pitID,
+			KeepAlive: ScanKeepAlive,
+		},
+		Size: &size,
+		Sort: []types.SortCombinations{
+			map[string]string{"ID": "asc"},
+		},
+		SearchAfter: after,
+		Source_: &types.SourceFilter{
+			Includes: []string{"ID", "Text", "SpaceID"},
+		},
+		TrackTotalHits: false,
+	}
+}
@@ -0,0 +1,29 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanNotesQuery(t *testing.T) {
+	req, err := json.Marshal(ScanNotesQuery("pit-id", 500, nil))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"pit":{"id":"pit-id","keep_alive":"1m"},
+		"size":500,
+		"sort":[{"ID":"asc"}],
+		"_source":{"includes":["ID","Text","SpaceID"]},
+		"track_total_hits":false
+	}`, string(req))
+
+	// следующая страница запрашивается по сортировке последнего документа (ID и _shard_doc)
+	req, err = json.Marshal(ScanNotesQuery("pit-id", 500, []types.FieldValue{"00000000-0000-0000-0000-000000000001", 42}))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(req), `"search_after":["00000000-0000-0000-0000-000000000001",42]`)
+}
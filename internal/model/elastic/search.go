@@ -0,0 +1,182 @@
+package elastic
+
+import (
+	"time"
+	model_package "webserver/internal/model"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/uuid"
+)
+
+// теги, которыми подсвечиваются совпадения в тексте
+const (
+	HighlightPreTag  = "<em>"
+	HighlightPostTag = "</em>"
+)
+
+// название агрегации, в которой считается количество найденных записей по пространствам
+const SpacesAggregation = "spaces"
+
+const (
+	highlightFragmentSize      = 150 // длина одного фрагмента в символах
+	highlightNumberOfFragments = 3   // сколько фрагментов возвращать на заметку
+)
+
+// Page - параметры страницы результатов поиска
+type Page struct {
+	From        int
+	Size        int
+	SearchAfter []types.FieldValue // значения сортировки последнего результата предыдущей страницы
+}
+
+// SearchFilter - фильтры поиска. Попадают в filter запроса, поэтому не влияют на релевантность
+type SearchFilter struct {
+	Types       []model_package.NoteType // типы заметок
+	CreatedFrom *time.Time               // заметки, созданные не раньше
+	CreatedTo   *time.Time               // заметки, созданные не позже
+	AuthorID    int64                    // telegram id автора
+	HasFile     *bool                    // есть ли у заметки файл
+	Location    *time.Location           // часовой пояс дат из операторов before: и after: (по умолчанию UTC)
+}
+
+// WithType добавляет к фильтру тип заметки, если он указан и его еще нет в фильтре
+func (f SearchFilter) WithType(noteType model_package.NoteType) SearchFilter {
+	if len(noteType) == 0 {
+		return f
+	}
+
+	for _, t := range f.Types {
+		if t == noteType {
+			return f
+		}
+	}
+
+	f.Types = append([]model_package.NoteType{noteType}, f.Types...)
+
+	return f
+}
+
+// queries возвращает условия фильтра для bool запроса
+func (f SearchFilter) queries() []types.Query {
+	var res []types.Query
+
+	if len(f.Types) > 0 {
+		noteTypes := make([]string, 0, len(f.Types))
+		for _, t := range f.Types {
+			noteTypes = append(noteTypes, string(t))
+		}
+
+		res = append(res, types.Query{
+			Terms: &types.TermsQuery{
+				TermsQuery: map[string]types.TermsQueryField{
+					"Type": noteTypes,
+				},
+			},
+		})
+	}
+
+	if f.CreatedFrom != nil || f.CreatedTo != nil {
+		created := types.NumberRangeQuery{}
+
+		if f.CreatedFrom != nil {
+			from := types.Float64(f.CreatedFrom.In(time.UTC).Unix())
+			created.Gte = &from
+		}
+
+		if f.CreatedTo != nil {
+			to := types.Float64(f.CreatedTo.In(time.UTC).Unix())
+			created.Lte = &to
+		}
+
+		res = append(res, types.Query{
+			Range: map[string]types.RangeQuery{
+				"Created": created,
+			},
+		})
+	}
+
+	if f.AuthorID != 0 {
+		res = append(res, types.Query{
+			Term: map[string]types.TermQuery{
+				"TgID": {
+					Value: f.AuthorID,
+				},
+			},
+		})
+	}
+
+	if f.HasFile != nil {
+		exists := types.Query{
+			Exists: &types.ExistsQuery{
+				Field: "File",
+			},
+		}
+
+		if *f.HasFile {
+			res = append(res, exists)
+		} else {
+			res = append(res, types.Query{
+				Bool: &types.BoolQuery{
+					MustNot: []types.Query{exists},
+				},
+			})
+		}
+	}
+
+	return res
+}
+
+// SearchHit - найденная запись
+type SearchHit struct {
+	ID         uuid.UUID // id из базы
+	Score      float64
+	Highlights []string
+	Sort       []types.FieldValue // значения сортировки, используются как курсор для следующей страницы
+}
+
+// SpaceCount - сколько записей нашлось в пространстве
+type SpaceCount struct {
+	SpaceID uuid.UUID
+	Count   int64
+}
+
+// SearchResult - страница результатов поиска, отсортированная по релевантности
+type SearchResult struct {
+	Total  int64 // сколько всего записей подходит под запрос
+	Hits   []SearchHit
+	Spaces []SpaceCount // количество записей по пространствам (только при поиске в нескольких пространствах)
+}
+
+// WithPage добавляет к запросу на поиск пагинацию, сортировку по релевантности и подсветку совпадений в тексте.
+// Для стабильного порядка при равной релевантности записи дополнительно сортируются по ID
+func WithPage(req *search.Request, page Page) *search.Request {
+	req.Size = &page.Size
+
+	if len(page.SearchAfter) > 0 {
+		req.SearchAfter = page.SearchAfter
+	} else {
+		req.From = &page.From
+	}
+
+	req.Sort = []types.SortCombinations{
+		map[string]string{"_score": "desc"},
+		map[string]string{"ID": "asc"},
+	}
+
+	// нужно точное количество результатов, а не оценка "больше 10000"
+	req.TrackTotalHits = true
+
+	req.Highlight = &types.Highlight{
+		Fields: map[string]types.HighlightField{
+			"Text": {
+				FragmentSize:      valueToPointer(highlightFragmentSize),
+				NumberOfFragments: valueToPointer(highlightNumberOfFragments),
+			},
+		},
+		PreTags:  []string{HighlightPreTag},
+		PostTags: []string{HighlightPostTag},
+	}
+
+	return req
+}
@@ -0,0 +1,90 @@
+package elastic
+
+import (
+	"testing"
+	model_package "webserver/internal/model"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPage(t *testing.T) {
+	type test struct {
+		name            string
+		page            Page
+		wantFrom        *int
+		wantSearchAfter []types.FieldValue
+	}
+
+	tests := []test{
+		{
+			name:     "from / size",
+			page:     Page{From: 20, Size: 10},
+			wantFrom: valueToPointer(20),
+		},
+		{
+			name:            "search after",
+			page:            Page{Size: 10, SearchAfter: []types.FieldValue{1.5, "abc"}},
+			wantSearchAfter: []types.FieldValue{1.5, "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := WithPage(&search.Request{}, tt.page)
+
+			require.NotNil(t, req.Size)
+			assert.Equal(t, tt.page.Size, *req.Size)
+			assert.Equal(t, tt.wantFrom, req.From)
+			assert.Equal(t, tt.wantSearchAfter, req.SearchAfter)
+			assert.Equal(t, true, req.TrackTotalHits)
+
+			assert.Equal(t, []types.SortCombinations{
+				map[string]string{"_score": "desc"},
+				map[string]string{"ID": "asc"},
+			}, req.Sort)
+
+			require.NotNil(t, req.Highlight)
+			assert.Contains(t, req.Highlight.Fields, "Text")
+			assert.Equal(t, []string{HighlightPreTag}, req.Highlight.PreTags)
+			assert.Equal(t, []string{HighlightPostTag}, req.Highlight.PostTags)
+		})
+	}
+}
+
+func TestSearchByTextInSpacesQuery(t *testing.T) {
+	n := Note{
+		Text: "dentist",
+		Type: model_package.TextNoteType,
+	}
+
+	spaceIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	req, err := n.searchByTextInSpacesQuery(spaceIDs, SearchFilter{})
+	require.NoError(t, err)
+
+	require.NotNil(t, req.Query)
+	require.NotNil(t, req.Query.Bool)
+
+	assert.Equal(t, []types.Query{textQuery(n.Text)}, req.Query.Bool.Must)
+	assert.Equal(t, notDeletedQuery(), req.Query.Bool.MustNot)
+
+	// заметки ищутся только в переданных пространствах и только среди заметок указанного типа
+	require.Len(t, req.Query.Bool.Filter, 2)
+	assert.Equal(t, SearchFilter{Types: []model_package.NoteType{n.Type}}.queries(), req.Query.Bool.Filter[1:])
+	require.NotNil(t, req.Query.Bool.Filter[0].Terms)
+	assert.Equal(t, map[string]types.TermsQueryField{
+		"SpaceID": []string{spaceIDs[0].String(), spaceIDs[1].String()},
+	}, req.Query.Bool.Filter[0].Terms.TermsQuery)
+
+	require.Contains(t, req.Aggregations, SpacesAggregation)
+	require.NotNil(t, req.Aggregations[SpacesAggregation].Terms)
+	assert.Equal(t, "SpaceID", *req.Aggregations[SpacesAggregation].Terms.Field)
+	assert.Equal(t, len(spaceIDs), *req.Aggregations[SpacesAggregation].Terms.Size)
+
+	_, err = n.searchByTextInSpacesQuery(nil, SearchFilter{})
+	assert.ErrorIs(t, err, ErrSpacesNotFilled)
+}
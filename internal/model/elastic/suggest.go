@@ -0,0 +1,92 @@
+package elastic
+
+import (
+	"fmt"
+	"time"
+	model_package "webserver/internal/model"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/textquerytype"
+	"github.com/google/uuid"
+)
+
+// SuggestField - поле для подсказок при вводе (search_as_you_type, см. маппинг в mapping.go).
+// Заполняется эластиком из Text через copy_to, поэтому сохранять его в документе не нужно
+const SuggestField = "TextSuggest"
+
+// SuggestTimeout - сколько эластик ищет подсказки. Если не успел, возвращает то, что успел найти
+const SuggestTimeout = 200 * time.Millisecond
+
+const suggestSnippetSize = 100 // длина фрагмента текста в подсказке
+
+// Suggestion - подсказка при вводе
+type Suggestion struct {
+	ID      uuid.UUID // id из базы
+	Type    model_package.NoteType
+	Snippet string // фрагмент текста с подсвеченным совпадением
+}
+
+// suggestQuery возвращает запрос на подсказки по началу текста в пространстве заметки.
+// Из документа достаются только id и тип, текст подсказки берется из подсветки
+func (n Note) suggestQuery(size int) (*search.Request, error) {
+	if len(n.Text) == 0 {
+		return nil, ErrFieldTextNotFilled
+	}
+
+	req := &search.Request{
+		Query: &types.Query{
+			Bool: &types.BoolQuery{
+				Must: []types.Query{
+					{
+						MultiMatch: &types.MultiMatchQuery{
+							Query: n.Text,
+							Type:  &textquerytype.Boolprefix,
+							Fields: []string{
+								SuggestField,
+								SuggestField + "._2gram",
+								SuggestField + "._3gram",
+							},
+						},
+					},
+				},
+				Filter: []types.Query{
+					{
+						Term: map[string]types.TermQuery{
+							"SpaceID": {
+								Value: n.SpaceID.String(),
+							},
+						},
+					},
+				},
+				MustNot: notDeletedQuery(),
+			},
+		},
+		Size: &size,
+		Source_: &types.SourceFilter{
+			Includes: []string{"ID", "Type"},
+		},
+		Highlight: &types.Highlight{
+			Fields: map[string]types.HighlightField{
+				"Text": {
+					// подсвечиваем в исходном поле тот же префикс, что искали в поле для подсказок
+					HighlightQuery: &types.Query{
+						MatchBoolPrefix: map[string]types.MatchBoolPrefixQuery{
+							"Text": {
+								Query: n.Text,
+							},
+						},
+					},
+					FragmentSize:      valueToPointer(suggestSnippetSize),
+					NumberOfFragments: valueToPointer(1),
+					NoMatchSize:       valueToPointer(suggestSnippetSize), // если подсветить не получилось, вернется начало текста
+				},
+			},
+			PreTags:  []string{HighlightPreTag},
+			PostTags: []string{HighlightPostTag},
+		},
+		Timeout: valueToPointer(fmt.Sprintf("%dms", SuggestTimeout.Milliseconds())),
+	}
+
+	return req, nil
+}
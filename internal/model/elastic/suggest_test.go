@@ -0,0 +1,55 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestQuery(t *testing.T) {
+	spaceID := uuid.MustParse("ed3a5b3a-b81e-4cad-acea-178e230a9b93")
+
+	n := Note{
+		SpaceID: spaceID,
+		Text:    "купить мол",
+	}
+
+	req, err := n.suggestQuery(5)
+	require.NoError(t, err)
+
+	require.NotNil(t, req.Size)
+	assert.Equal(t, 5, *req.Size)
+
+	require.NotNil(t, req.Timeout)
+	assert.Equal(t, "200ms", *req.Timeout)
+
+	query, err := json.Marshal(req.Query)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"bool":{
+		"must":[{"multi_match":{
+			"fields":["TextSuggest","TextSuggest._2gram","TextSuggest._3gram"],
+			"query":"купить мол",
+			"type":"bool_prefix"
+		}}],
+		"filter":[{"term":{"SpaceID":{"value":"ed3a5b3a-b81e-4cad-acea-178e230a9b93"}}}],
+		"must_not":[{"term":{"Deleted":{"value":true}}}]
+	}}`, string(query))
+
+	source, err := json.Marshal(req.Source_)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"includes":["ID","Type"]}`, string(source))
+
+	require.NotNil(t, req.Highlight)
+	require.Contains(t, req.Highlight.Fields, "Text")
+	assert.NotNil(t, req.Highlight.Fields["Text"].HighlightQuery)
+	assert.NotNil(t, req.Highlight.Fields["Text"].NoMatchSize)
+}
+
+func TestSuggestQuery_EmptyText(t *testing.T) {
+	_, err := Note{SpaceID: uuid.New()}.suggestQuery(5)
+	assert.ErrorIs(t, err, ErrFieldTextNotFilled)
+}
@@ -121,9 +121,33 @@ type NoteTypeResponse struct {
 	Count int      `json:"count"`
 }
 
-// запрос на поиск заметок по тексту в пространстве
-type SearchNoteByTextRequest struct {
-	SpaceID uuid.UUID `json:"space_id"`
-	Text    string    `json:"text"`
-	Type    NoteType  `json:"type"` // тип заметок, для которого осуществлять поиск
+// заметка, перемещенная в корзину пространства.
+// Хранится в корзине до истечения срока хранения, после чего удаляется окончательно
+type TrashNote struct {
+	GetNote
+	Deleted   time.Time `json:"deleted"`    // дата перемещения заметки в корзину
+	ExpiresAt time.Time `json:"expires_at"` // дата, после которой заметку нельзя будет восстановить
+}
+
+//	{
+//	  "user_id": 12345678,
+//	  "target_space_id": "ed3a5b3a-b81e-4cad-acea-178e230a9b93"
+//	}
+//
+// запрос на перенос / копирование заметки в другое пространство
+type TransferNoteRequest struct {
+	UserID        int64     `json:"user_id"`         // кто переносит / копирует заметку
+	TargetSpaceID uuid.UUID `json:"target_space_id"` // куда перенести / скопировать заметку
+}
+
+func (s *TransferNoteRequest) Validate() error {
+	if s.UserID == 0 {
+		return ErrFieldUserNotFilled
+	}
+
+	if s.TargetSpaceID == uuid.Nil {
+		return ErrInvalidSpaceID
+	}
+
+	return nil
 }
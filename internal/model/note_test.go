@@ -313,3 +313,39 @@ func TestGetNoteValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestTransferNoteRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model TransferNoteRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name:  "positive case",
+			model: TransferNoteRequest{UserID: 1, TargetSpaceID: uuid.New()},
+		},
+		{
+			name:  "user not filled",
+			model: TransferNoteRequest{TargetSpaceID: uuid.New()},
+			err:   ErrFieldUserNotFilled,
+		},
+		{
+			name:  "target space not filled",
+			model: TransferNoteRequest{UserID: 1},
+			err:   ErrInvalidSpaceID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
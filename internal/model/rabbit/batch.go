@@ -0,0 +1,104 @@
+package rabbit
+
+import (
+	"errors"
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ошибка о том, что в операции пакетного запроса заполнено не ровно одно действие
+var ErrInvalidBatchItem = errors.New("batch item must contain exactly one operation")
+
+// пакетный запрос на изменение заметок пространства.
+// db-worker применяет все операции в одной транзакции: либо все, либо ни одной
+type BatchNotesRequest struct {
+	ID        uuid.UUID       `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	SpaceID   uuid.UUID       `json:"space_id"`
+	Items     []BatchNoteItem `json:"items"`     // операции в порядке выполнения
+	Operation Operation       `json:"operation"` // какое действие сделать: создать, удалить, редактировать
+	Created   int64           `json:"created"`   // дата обращения в Unix в UTC
+}
+
+// операция пакетного запроса. Заполняется ровно одно поле
+type BatchNoteItem struct {
+	Create *CreateNoteRequest `json:"create,omitempty"`
+	Update *UpdateNoteRequest `json:"update,omitempty"`
+	Delete *DeleteNoteRequest `json:"delete,omitempty"`
+}
+
+func (s *BatchNotesRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *BatchNotesRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if len(s.Items) == 0 {
+		return model.ErrEmptyBatch
+	}
+
+	if len(s.Items) > model.MaxBatchSize {
+		return model.ErrBatchTooLarge
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != BatchOp {
+		return ErrInvalidOperation
+	}
+
+	for _, item := range s.Items {
+		if err := item.validate(s.SpaceID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *BatchNoteItem) validate(spaceID uuid.UUID) error {
+	var (
+		req         Model
+		itemSpaceID uuid.UUID
+		count       int
+	)
+
+	if s.Create != nil {
+		req, itemSpaceID = s.Create, s.Create.SpaceID
+		count++
+	}
+
+	if s.Update != nil {
+		req, itemSpaceID = s.Update, s.Update.SpaceID
+		count++
+	}
+
+	if s.Delete != nil {
+		req, itemSpaceID = s.Delete, s.Delete.SpaceID
+		count++
+	}
+
+	if count != 1 {
+		return ErrInvalidBatchItem
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	// все операции пакета должны относиться к одному пространству
+	if itemSpaceID != spaceID {
+		return model.ErrInvalidSpaceID
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package rabbit
+
+import (
+	"testing"
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model BatchNotesRequest
+		err   error
+	}
+
+	spaceID := uuid.New()
+
+	create := &CreateNoteRequest{
+		ID:        uuid.New(),
+		UserID:    1,
+		SpaceID:   spaceID,
+		Text:      "new note",
+		Type:      model.TextNoteType,
+		Created:   123,
+		Operation: CreateOp,
+	}
+
+	del := &DeleteNoteRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		NoteID:    uuid.New(),
+		Created:   123,
+		Operation: DeleteOp,
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{Create: create}, {Delete: del}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: BatchNotesRequest{
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{Create: create}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "space ID not filled",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				Items:     []BatchNoteItem{{Create: create}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "no items",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrEmptyBatch,
+		},
+		{
+			name: "too many items",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     make([]BatchNoteItem, model.MaxBatchSize+1),
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrBatchTooLarge,
+		},
+		{
+			name: "created not filled",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{Create: create}},
+				Operation: BatchOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{Create: create}},
+				Created:   123,
+				Operation: CreateOp,
+			},
+			err: ErrInvalidOperation,
+		},
+		{
+			name: "empty item",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: ErrInvalidBatchItem,
+		},
+		{
+			name: "item with several operations",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Items:     []BatchNoteItem{{Create: create, Delete: del}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: ErrInvalidBatchItem,
+		},
+		{
+			name: "invalid item",
+			model: BatchNotesRequest{
+				ID:      uuid.New(),
+				SpaceID: spaceID,
+				Items: []BatchNoteItem{{Delete: &DeleteNoteRequest{
+					ID:        uuid.New(),
+					SpaceID:   spaceID,
+					Created:   123,
+					Operation: DeleteOp,
+				}}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrIDNotFilled,
+		},
+		{
+			name: "item from another space",
+			model: BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Items:     []BatchNoteItem{{Create: create}},
+				Created:   123,
+				Operation: BatchOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package rabbit
+
+import (
+	"errors"
+	"fmt"
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// EventType - тип события об изменении данных. Используется как routing key в exchange событий
+type EventType string
+
+var (
+	SpaceUpdatedEvent       EventType = "space.updated"       // пространство переименовано или изменено
+	SpaceDeletedEvent       EventType = "space.deleted"       // пространство удалено
+	ParticipantAddedEvent   EventType = "participant.added"   // в пространство добавлен участник
+	ParticipantRemovedEvent EventType = "participant.removed" // участник удален из пространства
+	UserCreatedEvent        EventType = "user.created"        // зарегистрирован пользователь
+	UserUpdatedEvent        EventType = "user.updated"        // изменены данные пользователя (часовой пояс, имя и т.п.)
+)
+
+// ErrUnknownEvent - событие неизвестного типа: db-worker новее вебсервера
+var ErrUnknownEvent = errors.New("unknown event type")
+
+// ChangeEvent - событие, которое db-worker публикует после изменения данных в базе.
+// По нему вебсервер удаляет устаревшие записи из кэша
+type ChangeEvent struct {
+	Type    EventType `json:"type"`
+	SpaceID uuid.UUID `json:"space_id,omitempty"` // пространство, которое изменилось
+	UserID  int64     `json:"user_id,omitempty"`  // телеграм айди пользователя, который изменился или стал (перестал быть) участником
+}
+
+func (e ChangeEvent) Validate() error {
+	switch e.Type {
+	case SpaceUpdatedEvent, SpaceDeletedEvent:
+		if e.SpaceID == uuid.Nil {
+			return model.ErrInvalidSpaceID
+		}
+	case ParticipantAddedEvent, ParticipantRemovedEvent:
+		if e.SpaceID == uuid.Nil {
+			return model.ErrInvalidSpaceID
+		}
+
+		if e.UserID == 0 {
+			return model.ErrFieldUserNotFilled
+		}
+	case UserCreatedEvent, UserUpdatedEvent:
+		if e.UserID == 0 {
+			return model.ErrFieldUserNotFilled
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownEvent, e.Type)
+	}
+
+	return nil
+}
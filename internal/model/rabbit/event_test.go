@@ -0,0 +1,63 @@
+package rabbit
+
+import (
+	"testing"
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeEventValidate(t *testing.T) {
+	type test struct {
+		name  string
+		event ChangeEvent
+		err   error
+	}
+
+	tests := []test{
+		{
+			name:  "space updated",
+			event: ChangeEvent{Type: SpaceUpdatedEvent, SpaceID: uuid.New()},
+		},
+		{
+			name:  "space updated: space id not filled",
+			event: ChangeEvent{Type: SpaceUpdatedEvent},
+			err:   model.ErrInvalidSpaceID,
+		},
+		{
+			name:  "participant added",
+			event: ChangeEvent{Type: ParticipantAddedEvent, SpaceID: uuid.New(), UserID: 1},
+		},
+		{
+			name:  "participant removed: user not filled",
+			event: ChangeEvent{Type: ParticipantRemovedEvent, SpaceID: uuid.New()},
+			err:   model.ErrFieldUserNotFilled,
+		},
+		{
+			name:  "user updated",
+			event: ChangeEvent{Type: UserUpdatedEvent, UserID: 1},
+		},
+		{
+			name:  "user created: user not filled",
+			event: ChangeEvent{Type: UserCreatedEvent},
+			err:   model.ErrFieldUserNotFilled,
+		},
+		{
+			name:  "unknown event",
+			event: ChangeEvent{Type: "note.created"},
+			err:   ErrUnknownEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -123,6 +123,7 @@ func (s *UpdateNoteRequest) Validate() error {
 	return nil
 }
 
+// запрос на удаление заметки. Заметка не удаляется сразу, а перемещается в корзину пространства
 type DeleteNoteRequest struct {
 	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
 	SpaceID   uuid.UUID `json:"space_id"`
@@ -159,6 +160,50 @@ func (s *DeleteNoteRequest) Validate() error {
 	return nil
 }
 
+// запрос на перенос заметки в другое пространство. Тип заметки и файл сохраняются
+type MoveNoteRequest struct {
+	ID            uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	UserID        int64     `json:"user_id"`    // кто переносит заметку
+	NoteID        uuid.UUID `json:"note_id"`
+	SpaceID       uuid.UUID `json:"space_id"`        // текущее пространство заметки
+	TargetSpaceID uuid.UUID `json:"target_space_id"` // куда перенести заметку
+	Operation     Operation `json:"operation"`       // какое действие сделать: создать, удалить, редактировать
+	Created       int64     `json:"created"`         // дата обращения в Unix в UTC
+}
+
+func (s *MoveNoteRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *MoveNoteRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.UserID == 0 {
+		return model.ErrFieldUserNotFilled
+	}
+
+	if s.NoteID == uuid.Nil {
+		return model.ErrIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil || s.TargetSpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != MoveOp {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
+
+// запрос на удаление всех заметок пространства. Заметки перемещаются в корзину пространства
 type DeleteAllNotesRequest struct {
 	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
 	SpaceID   uuid.UUID `json:"space_id"`
@@ -375,3 +375,122 @@ func TestDeleteAllNotesRequestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveNoteRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model MoveNoteRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     MoveOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: MoveNoteRequest{
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     MoveOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "user not filled",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     MoveOp,
+			},
+			err: model.ErrFieldUserNotFilled,
+		},
+		{
+			name: "note ID not filled",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     MoveOp,
+			},
+			err: model.ErrIDNotFilled,
+		},
+		{
+			name: "space ID not filled",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     MoveOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "target space ID not filled",
+			model: MoveNoteRequest{
+				ID:        uuid.New(),
+				UserID:    1,
+				NoteID:    uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: MoveOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "created not filled",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Operation:     MoveOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       123,
+				Operation:     CreateOp,
+			},
+			err: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
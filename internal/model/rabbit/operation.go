@@ -10,6 +10,12 @@ var (
 	DeleteOp         Operation = "delete"
 	DeleteAllOp      Operation = "delete_all"
 	AddParticipantOp Operation = "add_participant"
+	RestoreOp        Operation = "restore"     // восстановить заметку из корзины
+	RestoreAllOp     Operation = "restore_all" // восстановить все заметки из корзины
+	PurgeOp          Operation = "purge"       // окончательно удалить заметку из корзины
+	PurgeAllOp       Operation = "purge_all"   // очистить корзину
+	BatchOp          Operation = "batch"       // пакетное изменение заметок
+	MoveOp           Operation = "move"        // перенести заметку в другое пространство
 )
 
 var (
@@ -0,0 +1,145 @@
+package rabbit
+
+import (
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// запрос на восстановление заметки из корзины
+type RestoreNoteRequest struct {
+	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	SpaceID   uuid.UUID `json:"space_id"`
+	NoteID    uuid.UUID `json:"note_id"`
+	Operation Operation `json:"operation"` // какое действие сделать: создать, удалить, редактировать
+	Created   int64     `json:"created"`   // дата обращения в Unix в UTC
+}
+
+func (s *RestoreNoteRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *RestoreNoteRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if s.NoteID == uuid.Nil {
+		return model.ErrIDNotFilled
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != RestoreOp {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
+
+// запрос на восстановление всех заметок из корзины пространства
+type RestoreAllNotesRequest struct {
+	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	SpaceID   uuid.UUID `json:"space_id"`
+	Operation Operation `json:"operation"` // какое действие сделать: создать, удалить, редактировать
+	Created   int64     `json:"created"`   // дата обращения в Unix в UTC
+}
+
+func (s *RestoreAllNotesRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *RestoreAllNotesRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != RestoreAllOp {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
+
+// запрос на окончательное удаление заметки из корзины
+type PurgeNoteRequest struct {
+	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	SpaceID   uuid.UUID `json:"space_id"`
+	NoteID    uuid.UUID `json:"note_id"`
+	Operation Operation `json:"operation"` // какое действие сделать: создать, удалить, редактировать
+	Created   int64     `json:"created"`   // дата обращения в Unix в UTC
+}
+
+func (s *PurgeNoteRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *PurgeNoteRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if s.NoteID == uuid.Nil {
+		return model.ErrIDNotFilled
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != PurgeOp {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
+
+// запрос на очистку корзины пространства
+type PurgeAllNotesRequest struct {
+	ID        uuid.UUID `json:"request_id"` // айди запроса, генерируется в процессе обработки
+	SpaceID   uuid.UUID `json:"space_id"`
+	Operation Operation `json:"operation"` // какое действие сделать: создать, удалить, редактировать
+	Created   int64     `json:"created"`   // дата обращения в Unix в UTC
+}
+
+func (s *PurgeAllNotesRequest) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s *PurgeAllNotesRequest) Validate() error {
+	if s.ID == uuid.Nil {
+		return model.ErrFieldIDNotFilled
+	}
+
+	if s.SpaceID == uuid.Nil {
+		return model.ErrInvalidSpaceID
+	}
+
+	if s.Created == 0 {
+		return model.ErrFieldCreatedNotFilled
+	}
+
+	if s.Operation != PurgeAllOp {
+		return ErrInvalidOperation
+	}
+
+	return nil
+}
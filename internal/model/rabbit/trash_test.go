@@ -0,0 +1,312 @@
+package rabbit
+
+import (
+	"testing"
+	"webserver/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreNoteRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model RestoreNoteRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: RestoreOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: RestoreNoteRequest{
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: RestoreOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "SpaceID not filled",
+			model: RestoreNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: RestoreOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "NoteID not filled",
+			model: RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: RestoreOp,
+			},
+			err: model.ErrIDNotFilled,
+		},
+		{
+			name: "Created field not filled",
+			model: RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: RestoreOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: DeleteOp,
+			},
+			err: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreAllNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model RestoreAllNotesRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: RestoreAllOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: RestoreAllNotesRequest{
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: RestoreAllOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "SpaceID not filled",
+			model: RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				Created:   123,
+				Operation: RestoreAllOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "Created field not filled",
+			model: RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: RestoreAllOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: RestoreOp,
+			},
+			err: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeNoteRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model PurgeNoteRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: PurgeOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: PurgeNoteRequest{
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: PurgeOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "SpaceID not filled",
+			model: PurgeNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: PurgeOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "NoteID not filled",
+			model: PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: PurgeOp,
+			},
+			err: model.ErrIDNotFilled,
+		},
+		{
+			name: "Created field not filled",
+			model: PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: PurgeOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   123,
+				Operation: DeleteOp,
+			},
+			err: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeAllNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model PurgeAllNotesRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: PurgeAllOp,
+			},
+		},
+		{
+			name: "ID not filled",
+			model: PurgeAllNotesRequest{
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: PurgeAllOp,
+			},
+			err: model.ErrFieldIDNotFilled,
+		},
+		{
+			name: "SpaceID not filled",
+			model: PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				Created:   123,
+				Operation: PurgeAllOp,
+			},
+			err: model.ErrInvalidSpaceID,
+		},
+		{
+			name: "Created field not filled",
+			model: PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: PurgeAllOp,
+			},
+			err: model.ErrFieldCreatedNotFilled,
+		},
+		{
+			name: "invalid operation",
+			model: PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   123,
+				Operation: DeleteAllOp,
+			},
+			err: ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
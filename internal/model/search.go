@@ -0,0 +1,270 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// сколько результатов поиска возвращать, если размер страницы не указан
+	DefaultSearchSize = 10
+	// максимальный размер страницы результатов поиска
+	MaxSearchSize = 100
+)
+
+var (
+	// ошибка о том, что поле from заполнено неправильно
+	ErrInvalidSearchFrom = errors.New("field `from` must not be negative")
+	// ошибка о том, что поле size заполнено неправильно
+	ErrInvalidSearchSize = errors.New("field `size` must be between 0 and 100")
+	// ошибка о том, что переданы одновременно from и search_after
+	ErrSearchPagingConflict = errors.New("fields `from` and `search_after` cannot be used together")
+	// ошибка о том, что курсор search_after не удалось разобрать
+	ErrInvalidSearchCursor = errors.New("invalid `search_after` cursor")
+	// ошибка о том, что указан неизвестный тип заметок
+	ErrInvalidNoteType = errors.New("invalid note type")
+	// ошибка о том, что начало периода позже его конца
+	ErrInvalidDateRange = errors.New("field `created_from` must not be after `created_to`")
+	// ошибка о том, что период указан одновременно словами и датами
+	ErrDateConflict = errors.New("field `date` cannot be used together with `created_from` and `created_to`")
+)
+
+// синтаксическая ошибка в поисковом запросе
+type SearchSyntaxError struct {
+	Position int    // позиция проблемного места в запросе (в символах, начиная с 1)
+	Message  string // что не так
+}
+
+func (e *SearchSyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Position, e.Message)
+}
+
+// параметры страницы результатов поиска.
+// Постранично можно листать либо через from / size, либо через курсор search_after из предыдущего ответа
+type SearchPage struct {
+	From        int    `json:"from"`                   // сколько результатов пропустить
+	Size        int    `json:"size"`                   // сколько результатов вернуть (по умолчанию 10)
+	SearchAfter string `json:"search_after,omitempty"` // курсор на следующую страницу из предыдущего ответа
+}
+
+func (s *SearchPage) Validate() error {
+	if s.From < 0 {
+		return ErrInvalidSearchFrom
+	}
+
+	if s.Size < 0 || s.Size > MaxSearchSize {
+		return ErrInvalidSearchSize
+	}
+
+	if len(s.SearchAfter) > 0 {
+		if s.From > 0 {
+			return ErrSearchPagingConflict
+		}
+
+		if _, err := DecodeSearchCursor(s.SearchAfter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// фильтры поиска. Не влияют на релевантность заметок, а только отсекают неподходящие
+type SearchFilters struct {
+	Types       []NoteType `json:"types,omitempty"`        // искать среди заметок нескольких типов (вместе с полем type)
+	CreatedFrom *time.Time `json:"created_from,omitempty"` // заметки, созданные не раньше этой даты
+	CreatedTo   *time.Time `json:"created_to,omitempty"`   // заметки, созданные не позже этой даты
+	AuthorID    int64      `json:"author_id,omitempty"`    // telegram id автора заметок
+	HasFile     *bool      `json:"has_file,omitempty"`     // только заметки с файлом (true) или только без файла (false)
+	Date        string     `json:"date,omitempty"`         // период словами: "вчера", "на прошлой неделе", "в марте"
+
+	// Location - часовой пояс пользователя, в котором разбираются даты из операторов before: и after:.
+	// Заполняется сервисом, если не заполнен, даты разбираются в UTC
+	Location *time.Location `json:"-"`
+}
+
+func (s *SearchFilters) Validate() error {
+	for _, noteType := range s.Types {
+		if err := validateNoteType(noteType); err != nil {
+			return err
+		}
+	}
+
+	if s.CreatedFrom != nil && s.CreatedTo != nil && s.CreatedFrom.After(*s.CreatedTo) {
+		return ErrInvalidDateRange
+	}
+
+	if len(s.Date) > 0 {
+		if s.CreatedFrom != nil || s.CreatedTo != nil {
+			return ErrDateConflict
+		}
+
+		// точные границы зависят от часового пояса пользователя, здесь проверяем только, что период понятен
+		if _, err := ParseDateExpression(s.Date, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyDate переводит период, указанный словами, в границы created_from / created_to.
+// now должно быть в часовом поясе пользователя
+func (s *SearchFilters) ApplyDate(now time.Time) error {
+	if len(s.Date) == 0 {
+		return nil
+	}
+
+	period, err := ParseDateExpression(s.Date, now)
+	if err != nil {
+		return err
+	}
+
+	if !period.From.IsZero() {
+		s.CreatedFrom = &period.From
+	}
+
+	if !period.To.IsZero() {
+		s.CreatedTo = &period.To
+	}
+
+	s.Date = ""
+
+	return nil
+}
+
+// validateNoteType проверяет, что тип заметки - один из перечисленных
+func validateNoteType(noteType NoteType) error {
+	switch noteType {
+	case TextNoteType, PhotoNoteType:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
+	}
+}
+
+//	{
+//	  "space_id": "ed3a5b3a-b81e-4cad-acea-178e230a9b93",
+//	  "text": "купить молоко",
+//	  "types": ["text", "photo"],
+//	  "date": "на прошлой неделе",
+//	  "author_id": 12345678,
+//	  "user_id": 12345678,
+//	  "size": 20,
+//	  "search_after": "WzEuMiwiYWJjIl0"
+//	}
+//
+// запрос на поиск заметок по тексту в пространстве
+type SearchNoteByTextRequest struct {
+	SpaceID uuid.UUID `json:"space_id"`
+	UserID  int64     `json:"user_id,omitempty"` // telegram id пользователя: в его часовом поясе считается период из поля date
+	Text    string    `json:"text"`
+	Type    NoteType  `json:"type"` // тип заметок, для которого осуществлять поиск
+	SearchFilters
+	SearchPage
+}
+
+func (s *SearchNoteByTextRequest) Validate() error {
+	if len(s.Type) > 0 {
+		if err := validateNoteType(s.Type); err != nil {
+			return err
+		}
+	}
+
+	if err := s.SearchFilters.Validate(); err != nil {
+		return err
+	}
+
+	return s.SearchPage.Validate()
+}
+
+//	{
+//	  "text": "стоматолог",
+//	  "size": 20
+//	}
+//
+// запрос на поиск заметок по тексту во всех пространствах, в которых состоит пользователь
+type SearchAllNotesRequest struct {
+	UserID int64    `json:"-"` // заполняется из токена
+	Text   string   `json:"text"`
+	Type   NoteType `json:"type"` // тип заметок, для которого осуществлять поиск
+	SearchFilters
+	SearchPage
+}
+
+func (s *SearchAllNotesRequest) Validate() error {
+	if s.UserID == 0 {
+		return ErrFieldUserNotFilled
+	}
+
+	if len(s.Text) == 0 {
+		return ErrFieldTextNotFilled
+	}
+
+	if len(s.Type) > 0 {
+		if err := validateNoteType(s.Type); err != nil {
+			return err
+		}
+	}
+
+	if err := s.SearchFilters.Validate(); err != nil {
+		return err
+	}
+
+	return s.SearchPage.Validate()
+}
+
+// найденная заметка: сама заметка, ее релевантность и подсвеченные фрагменты текста
+type SearchNoteHit struct {
+	GetNote
+	Score      float64  `json:"score"`                // релевантность заметки запросу
+	Highlights []string `json:"highlights,omitempty"` // фрагменты текста с подсвеченными совпадениями
+}
+
+// ответ на поиск заметок. Заметки отсортированы по релевантности
+type SearchNoteResponse struct {
+	Total       int64           `json:"total"`                  // сколько всего заметок нашлось
+	Hits        []SearchNoteHit `json:"hits"`                   // заметки на текущей странице
+	SearchAfter string          `json:"search_after,omitempty"` // курсор на следующую страницу (если она есть)
+	Spaces      []SpaceHits     `json:"spaces,omitempty"`       // сколько заметок нашлось в каждом пространстве (при поиске по всем пространствам)
+}
+
+// сколько заметок нашлось в пространстве
+type SpaceHits struct {
+	SpaceID uuid.UUID `json:"space_id"`
+	Count   int64     `json:"count"`
+}
+
+// EncodeSearchCursor упаковывает значения сортировки последнего результата в курсор для search_after
+func EncodeSearchCursor(values []any) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	bytesJSON, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytesJSON), nil
+}
+
+// DecodeSearchCursor распаковывает курсор search_after в значения сортировки
+func DecodeSearchCursor(cursor string) ([]any, error) {
+	bytesJSON, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	var values []any
+	if err := json.Unmarshal(bytesJSON, &values); err != nil || len(values) == 0 {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	return values, nil
+}
@@ -0,0 +1,256 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchNoteByTextRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model SearchNoteByTextRequest
+		err   error
+	}
+
+	cursor, err := EncodeSearchCursor([]any{1.5, uuid.NewString()})
+	require.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	hasFile := true
+
+	tests := []test{
+		{
+			name: "positive case: default page",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+			},
+		},
+		{
+			name: "positive case: from / size",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				SearchPage: SearchPage{
+					From: 20,
+					Size: MaxSearchSize,
+				},
+			},
+		},
+		{
+			name: "positive case: search after",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				SearchPage: SearchPage{
+					Size:        10,
+					SearchAfter: cursor,
+				},
+			},
+		},
+		{
+			name: "positive case: filters",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				SearchFilters: SearchFilters{
+					Types:       []NoteType{TextNoteType, PhotoNoteType},
+					CreatedFrom: &from,
+					CreatedTo:   &to,
+					AuthorID:    12345,
+					HasFile:     &hasFile,
+				},
+			},
+		},
+		{
+			name: "invalid type",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				Type:    "video",
+			},
+			err: fmt.Errorf("%w: video", ErrInvalidNoteType),
+		},
+		{
+			name: "invalid type in list",
+			model: SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: SearchFilters{Types: []NoteType{TextNoteType, "video"}},
+			},
+			err: fmt.Errorf("%w: video", ErrInvalidNoteType),
+		},
+		{
+			name: "invalid date range",
+			model: SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: SearchFilters{CreatedFrom: &to, CreatedTo: &from},
+			},
+			err: ErrInvalidDateRange,
+		},
+		{
+			name: "positive case: date",
+			model: SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: SearchFilters{Date: "на прошлой неделе"},
+			},
+		},
+		{
+			name: "date with created_from",
+			model: SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: SearchFilters{Date: "вчера", CreatedFrom: &from},
+			},
+			err: ErrDateConflict,
+		},
+		{
+			name: "invalid date",
+			model: SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: SearchFilters{Date: "когда-нибудь"},
+			},
+			err: fmt.Errorf("%w: %q", ErrInvalidDateExpression, "когда-нибудь"),
+		},
+		{
+			name: "negative from",
+			model: SearchNoteByTextRequest{
+				SpaceID:    uuid.New(),
+				Text:       "test",
+				SearchPage: SearchPage{From: -1},
+			},
+			err: ErrInvalidSearchFrom,
+		},
+		{
+			name: "negative size",
+			model: SearchNoteByTextRequest{
+				SpaceID:    uuid.New(),
+				Text:       "test",
+				SearchPage: SearchPage{Size: -1},
+			},
+			err: ErrInvalidSearchSize,
+		},
+		{
+			name: "size too large",
+			model: SearchNoteByTextRequest{
+				SpaceID:    uuid.New(),
+				Text:       "test",
+				SearchPage: SearchPage{Size: MaxSearchSize + 1},
+			},
+			err: ErrInvalidSearchSize,
+		},
+		{
+			name: "from and search after together",
+			model: SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				SearchPage: SearchPage{
+					From:        10,
+					SearchAfter: cursor,
+				},
+			},
+			err: ErrSearchPagingConflict,
+		},
+		{
+			name: "invalid cursor",
+			model: SearchNoteByTextRequest{
+				SpaceID:    uuid.New(),
+				Text:       "test",
+				SearchPage: SearchPage{SearchAfter: "not a cursor!"},
+			},
+			err: ErrInvalidSearchCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchAllNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model SearchAllNotesRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: SearchAllNotesRequest{
+				UserID: 1,
+				Text:   "test",
+			},
+		},
+		{
+			name: "user not filled",
+			model: SearchAllNotesRequest{
+				Text: "test",
+			},
+			err: ErrFieldUserNotFilled,
+		},
+		{
+			name: "text not filled",
+			model: SearchAllNotesRequest{
+				UserID: 1,
+			},
+			err: ErrFieldTextNotFilled,
+		},
+		{
+			name: "invalid page",
+			model: SearchAllNotesRequest{
+				UserID:     1,
+				Text:       "test",
+				SearchPage: SearchPage{Size: MaxSearchSize + 1},
+			},
+			err: ErrInvalidSearchSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	id := uuid.NewString()
+
+	cursor, err := EncodeSearchCursor([]any{1.5, id})
+	require.NoError(t, err)
+
+	values, err := DecodeSearchCursor(cursor)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{1.5, id}, values)
+
+	// пустой курсор означает, что следующей страницы нет
+	cursor, err = EncodeSearchCursor(nil)
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	_, err = DecodeSearchCursor("W10") // []
+	assert.ErrorIs(t, err, ErrInvalidSearchCursor)
+}
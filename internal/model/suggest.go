@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// сколько подсказок возвращать, если количество не указано
+	DefaultSuggestSize = 5
+	// максимальное количество подсказок
+	MaxSuggestSize = 10
+)
+
+var (
+	// ошибка о том, что не передан текст для подсказок
+	ErrSuggestQueryNotFilled = errors.New("query parameter `q` not filled")
+	// ошибка о том, что количество подсказок указано неправильно
+	ErrInvalidSuggestSize = errors.New("parameter `size` must be between 0 and 10")
+)
+
+// запрос на подсказки при вводе текста в поиск
+type SuggestNotesRequest struct {
+	SpaceID uuid.UUID
+	Query   string // то, что пользователь успел ввести
+	Size    int    // сколько подсказок вернуть (по умолчанию 5)
+}
+
+func (s *SuggestNotesRequest) Validate() error {
+	if s.SpaceID == uuid.Nil {
+		return ErrInvalidSpaceID
+	}
+
+	if len(s.Query) == 0 {
+		return ErrSuggestQueryNotFilled
+	}
+
+	if s.Size < 0 || s.Size > MaxSuggestSize {
+		return ErrInvalidSuggestSize
+	}
+
+	return nil
+}
+
+// подсказка: заметка, подходящая под введенный текст
+type NoteSuggestion struct {
+	ID      uuid.UUID `json:"id"`
+	Type    NoteType  `json:"type"`
+	Snippet string    `json:"snippet"` // фрагмент текста заметки с подсвеченным совпадением
+}
+
+// ответ с подсказками, отсортированными по релевантности
+type SuggestNotesResponse struct {
+	Suggestions []NoteSuggestion `json:"suggestions"`
+}
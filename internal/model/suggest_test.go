@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestNotesRequestValidate(t *testing.T) {
+	type test struct {
+		name  string
+		model SuggestNotesRequest
+		err   error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			model: SuggestNotesRequest{
+				SpaceID: uuid.New(),
+				Query:   "куп",
+			},
+		},
+		{
+			name: "positive case: max size",
+			model: SuggestNotesRequest{
+				SpaceID: uuid.New(),
+				Query:   "куп",
+				Size:    MaxSuggestSize,
+			},
+		},
+		{
+			name: "space not filled",
+			model: SuggestNotesRequest{
+				Query: "куп",
+			},
+			err: ErrInvalidSpaceID,
+		},
+		{
+			name: "query not filled",
+			model: SuggestNotesRequest{
+				SpaceID: uuid.New(),
+			},
+			err: ErrSuggestQueryNotFilled,
+		},
+		{
+			name: "size too big",
+			model: SuggestNotesRequest{
+				SpaceID: uuid.New(),
+				Query:   "куп",
+				Size:    MaxSuggestSize + 1,
+			},
+			err: ErrInvalidSuggestSize,
+		},
+		{
+			name: "negative size",
+			model: SuggestNotesRequest{
+				SpaceID: uuid.New(),
+				Query:   "куп",
+				Size:    -1,
+			},
+			err: ErrInvalidSuggestSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -31,6 +31,9 @@ type spaceService interface {
 	noteSearcher
 	noteUpdater
 	participantAdder
+	trashManager
+	noteBatcher
+	noteTransferer
 }
 
 type spaceCreator interface {
@@ -61,6 +64,17 @@ type noteUpdater interface {
 	UpdateNote(ctx context.Context, update rabbit.UpdateNoteRequest) error
 }
 
+// пакетные операции над заметками
+type noteBatcher interface {
+	BatchNotes(ctx context.Context, req model.BatchNotesRequest) (model.BatchNotesResponse, error)
+}
+
+// перенос и копирование заметок между пространствами
+type noteTransferer interface {
+	MoveNote(ctx context.Context, req rabbit.MoveNoteRequest) error
+	CopyNote(ctx context.Context, note model.GetNote, req rabbit.CreateNoteRequest) error
+}
+
 type noteDeleter interface {
 	DeleteAllNotes(ctx context.Context, req rabbit.DeleteAllNotesRequest) error
 	DeleteNote(ctx context.Context, req rabbit.DeleteNoteRequest) error
@@ -74,8 +88,20 @@ type noteGetter interface {
 	GetNotesTypes(ctx context.Context, spaceID uuid.UUID) ([]model.NoteTypeResponse, error)
 }
 
+// управление корзиной пространства
+type trashManager interface {
+	GetTrash(ctx context.Context, spaceID uuid.UUID) ([]model.TrashNote, error)
+	GetTrashNoteByID(ctx context.Context, noteID uuid.UUID) (model.TrashNote, error)
+	RestoreNote(ctx context.Context, req rabbit.RestoreNoteRequest) error
+	RestoreAllNotes(ctx context.Context, req rabbit.RestoreAllNotesRequest) error
+	PurgeNote(ctx context.Context, req rabbit.PurgeNoteRequest) error
+	PurgeAllNotes(ctx context.Context, req rabbit.PurgeAllNotesRequest) error
+}
+
 type noteSearcher interface {
-	SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error)
+	SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error)
+	SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest) (model.SearchNoteResponse, error)
+	SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error)
 }
 
 type userService interface {
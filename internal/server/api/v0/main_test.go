@@ -71,13 +71,25 @@ func runTestServer(t *testing.T, h *Handler) (*echo.Echo, error) {
 	spaces.PATCH("/notes/update", h.UpdateNote, h.WrapNetHTTP)
 	spaces.DELETE("/:space_id/notes/:note_id/delete", h.DeleteNote, h.WrapNetHTTP)
 	spaces.DELETE("/:space_id/notes/delete_all", h.DeleteAllNotes, h.WrapNetHTTP) // удалить все заметки
+	spaces.POST("/:space_id/notes/batch", h.BatchNotes, h.WrapNetHTTP)
+	spaces.POST("/:space_id/notes/:note_id/move", h.MoveNote, h.WrapNetHTTP)
+	spaces.POST("/:space_id/notes/:note_id/copy", h.CopyNote, h.WrapNetHTTP)
 
 	// типы заметок
 	spaces.GET("/:space_id/notes/types", h.GetNoteTypes, h.WrapNetHTTP)   // получить, какие есть типы заметок
 	spaces.GET("/:space_id/notes/:type", h.GetNotesByType, h.WrapNetHTTP) // получить все заметки одного типа
 
+	// корзина
+	spaces.GET("/:space_id/trash", h.GetTrash, h.WrapNetHTTP)                      // получить заметки из корзины
+	spaces.POST("/:space_id/trash/restore_all", h.RestoreAllNotes, h.WrapNetHTTP)  // восстановить все заметки из корзины
+	spaces.POST("/:space_id/trash/:note_id/restore", h.RestoreNote, h.WrapNetHTTP) // восстановить заметку из корзины
+	spaces.DELETE("/:space_id/trash/purge_all", h.PurgeAllNotes, h.WrapNetHTTP)    // очистить корзину
+	spaces.DELETE("/:space_id/trash/:note_id/purge", h.PurgeNote, h.WrapNetHTTP)   // окончательно удалить заметку
+
 	// поиск
-	spaces.POST("/notes/search/text", h.SearchNoteByText, h.WrapNetHTTP) // по тексту
+	spaces.POST("/notes/search/text", h.SearchNoteByText, h.WrapNetHTTP)      // по тексту
+	spaces.POST("/notes/search/all", h.SearchAllNotes, h.Auth, h.WrapNetHTTP) // по тексту во всех пространствах пользователя
+	spaces.GET("/:space_id/notes/suggest", h.SuggestNotes, h.WrapNetHTTP)     // подсказки при вводе
 
 	return e, nil
 }
@@ -107,13 +119,25 @@ func runTestServerWithMiddleware(t *testing.T, h *Handler) (*echo.Echo, error) {
 	spaces.PATCH("/notes/update", h.UpdateNote, h.ValidateNoteRequest)
 	spaces.DELETE("/:space_id/notes/:note_id/delete", h.DeleteNote)
 	spaces.DELETE("/:space_id/notes/delete_all", h.DeleteAllNotes) // удалить все заметки
+	spaces.POST("/:space_id/notes/batch", h.BatchNotes, h.ValidateNoteRequest)
+	spaces.POST("/:space_id/notes/:note_id/move", h.MoveNote)
+	spaces.POST("/:space_id/notes/:note_id/copy", h.CopyNote)
 
 	// типы заметок
 	spaces.GET("/:space_id/notes/types", h.GetNoteTypes)   // получить, какие есть типы заметок
 	spaces.GET("/:space_id/notes/:type", h.GetNotesByType) // получить все заметки одного типа
 
+	// корзина
+	spaces.GET("/:space_id/trash", h.GetTrash)                      // получить заметки из корзины
+	spaces.POST("/:space_id/trash/restore_all", h.RestoreAllNotes)  // восстановить все заметки из корзины
+	spaces.POST("/:space_id/trash/:note_id/restore", h.RestoreNote) // восстановить заметку из корзины
+	spaces.DELETE("/:space_id/trash/purge_all", h.PurgeAllNotes)    // очистить корзину
+	spaces.DELETE("/:space_id/trash/:note_id/purge", h.PurgeNote)   // окончательно удалить заметку
+
 	// поиск
-	spaces.POST("/notes/search/text", h.SearchNoteByText) // по тексту
+	spaces.POST("/notes/search/text", h.SearchNoteByText)      // по тексту
+	spaces.POST("/notes/search/all", h.SearchAllNotes, h.Auth) // по тексту во всех пространствах пользователя
+	spaces.GET("/:space_id/notes/suggest", h.SuggestNotes)     // подсказки при вводе
 
 	return e, nil
 }
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"time"
 	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
 	"webserver/internal/model/rabbit"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -17,7 +18,7 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// ValidateNoteRequest производит валидацию запросов на создание и обновление заметки.
+// ValidateNoteRequest производит валидацию запросов на создание и обновление заметки, а также пакетных запросов.
 // Проверяет: что пользователь существует, что пространство существует, что пользователь состоит в пространстве.
 func (h *Handler) ValidateNoteRequest(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -46,6 +47,20 @@ func (h *Handler) ValidateNoteRequest(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 			userID = note.UserID
 			spaceID = note.SpaceID
+		case "/api/v0/spaces/:space_id/notes/batch":
+			var batch model.BatchNotesRequest
+			if err := json.Unmarshal(body, &batch); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+
+			batchSpaceID, err := getSpaceIDFromPath(c)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid space id parameter: %+v", err)})
+			}
+
+			// пользователь и пространство проверяются один раз на весь пакет
+			userID = batch.UserID
+			spaceID = batchSpaceID
 		default:
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported operation"})
 		}
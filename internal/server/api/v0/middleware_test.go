@@ -367,6 +367,131 @@ func TestValidateNoteRequest_UpdateNote(t *testing.T) {
 	}
 }
 
+// тест для проверки middleware на пакетном запросе: пользователь и пространство проверяются один раз
+func TestValidateNoteRequest_BatchNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name         string
+		spaceID      string
+		req          model.BatchNotesRequest
+		expectedCode int
+		expectedErr  error
+		setupMocks   func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+
+	req := model.BatchNotesRequest{
+		UserID: 1,
+		Items: []model.BatchNoteItem{
+			{Operation: model.BatchCreate, Text: "note 1", Type: model.TextNoteType},
+			{Operation: model.BatchCreate, Text: "note 2", Type: model.TextNoteType},
+		},
+	}
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			req:          req,
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(m *fields) {
+				t.Helper()
+
+				m.userSrv.EXPECT().CheckUser(gomock.Any(), int64(1)).Return(true, nil)
+				m.spaceSrv.EXPECT().IsSpaceExists(gomock.Any(), spaceID).Return(true, nil)
+				m.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(true, nil)
+				m.spaceSrv.EXPECT().BatchNotes(gomock.Any(), gomock.Any()).Return(model.BatchNotesResponse{Accepted: 2}, nil)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			req:          req,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  fmt.Errorf("invalid space id parameter: invalid UUID length: 3"),
+			setupMocks:   func(m *fields) {},
+		},
+		{
+			name:         "db err: unknown user",
+			spaceID:      spaceID.String(),
+			req:          req,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.ErrUnknownUser,
+			setupMocks: func(m *fields) {
+				t.Helper()
+
+				m.userSrv.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:         "db err: space belongs another user",
+			spaceID:      spaceID.String(),
+			req:          req,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.ErrSpaceNotBelongsUser,
+			setupMocks: func(m *fields) {
+				t.Helper()
+
+				m.userSrv.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(true, nil)
+				m.spaceSrv.EXPECT().IsSpaceExists(gomock.Any(), gomock.Any()).Return(true, nil)
+				m.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+		},
+	}
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServerWithMiddleware(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			bodyJSON, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v0/spaces/%s/notes/batch", tt.spaceID)
+
+			resp := testRequest(t, ts, http.MethodPost, url, "", bytes.NewReader(bodyJSON))
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedErr != nil {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestWrapNetHTTP(t *testing.T) {
 	type fields struct {
 		spaceSrv *mocks.MockspaceService
@@ -52,6 +52,21 @@ func (mr *MockspaceServiceMockRecorder) AddParticipant(ctx, req interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockspaceService)(nil).AddParticipant), ctx, req)
 }
 
+// BatchNotes mocks base method.
+func (m *MockspaceService) BatchNotes(ctx context.Context, req model.BatchNotesRequest) (model.BatchNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", ctx, req)
+	ret0, _ := ret[0].(model.BatchNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MockspaceServiceMockRecorder) BatchNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MockspaceService)(nil).BatchNotes), ctx, req)
+}
+
 // CheckInvitation mocks base method.
 func (m *MockspaceService) CheckInvitation(ctx context.Context, from, to int64, spaceID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +82,20 @@ func (mr *MockspaceServiceMockRecorder) CheckInvitation(ctx, from, to, spaceID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInvitation", reflect.TypeOf((*MockspaceService)(nil).CheckInvitation), ctx, from, to, spaceID)
 }
 
+// CopyNote mocks base method.
+func (m *MockspaceService) CopyNote(ctx context.Context, note model.GetNote, req rabbit.CreateNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyNote", ctx, note, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyNote indicates an expected call of CopyNote.
+func (mr *MockspaceServiceMockRecorder) CopyNote(ctx, note, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyNote", reflect.TypeOf((*MockspaceService)(nil).CopyNote), ctx, note, req)
+}
+
 // CreateNote mocks base method.
 func (m *MockspaceService) CreateNote(ctx context.Context, note rabbit.CreateNoteRequest) error {
 	m.ctrl.T.Helper()
@@ -213,6 +242,36 @@ func (mr *MockspaceServiceMockRecorder) GetSpaceByID(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByID", reflect.TypeOf((*MockspaceService)(nil).GetSpaceByID), ctx, id)
 }
 
+// GetTrash mocks base method.
+func (m *MockspaceService) GetTrash(ctx context.Context, spaceID uuid.UUID) ([]model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, spaceID)
+	ret0, _ := ret[0].([]model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockspaceServiceMockRecorder) GetTrash(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockspaceService)(nil).GetTrash), ctx, spaceID)
+}
+
+// GetTrashNoteByID mocks base method.
+func (m *MockspaceService) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID) (model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashNoteByID", ctx, noteID)
+	ret0, _ := ret[0].(model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashNoteByID indicates an expected call of GetTrashNoteByID.
+func (mr *MockspaceServiceMockRecorder) GetTrashNoteByID(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashNoteByID", reflect.TypeOf((*MockspaceService)(nil).GetTrashNoteByID), ctx, noteID)
+}
+
 // IsSpaceExists mocks base method.
 func (m *MockspaceService) IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -258,11 +317,96 @@ func (mr *MockspaceServiceMockRecorder) IsUserInSpace(ctx, userID, spaceID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserInSpace", reflect.TypeOf((*MockspaceService)(nil).IsUserInSpace), ctx, userID, spaceID)
 }
 
+// MoveNote mocks base method.
+func (m *MockspaceService) MoveNote(ctx context.Context, req rabbit.MoveNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockspaceServiceMockRecorder) MoveNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockspaceService)(nil).MoveNote), ctx, req)
+}
+
+// PurgeAllNotes mocks base method.
+func (m *MockspaceService) PurgeAllNotes(ctx context.Context, req rabbit.PurgeAllNotesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MockspaceServiceMockRecorder) PurgeAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*MockspaceService)(nil).PurgeAllNotes), ctx, req)
+}
+
+// PurgeNote mocks base method.
+func (m *MockspaceService) PurgeNote(ctx context.Context, req rabbit.PurgeNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MockspaceServiceMockRecorder) PurgeNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MockspaceService)(nil).PurgeNote), ctx, req)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *MockspaceService) RestoreAllNotes(ctx context.Context, req rabbit.RestoreAllNotesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MockspaceServiceMockRecorder) RestoreAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*MockspaceService)(nil).RestoreAllNotes), ctx, req)
+}
+
+// RestoreNote mocks base method.
+func (m *MockspaceService) RestoreNote(ctx context.Context, req rabbit.RestoreNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MockspaceServiceMockRecorder) RestoreNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MockspaceService)(nil).RestoreNote), ctx, req)
+}
+
+// SearchAllNotes mocks base method.
+func (m *MockspaceService) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest) (model.SearchNoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", ctx, req)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MockspaceServiceMockRecorder) SearchAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*MockspaceService)(nil).SearchAllNotes), ctx, req)
+}
+
 // SearchNoteByText mocks base method.
-func (m *MockspaceService) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
+func (m *MockspaceService) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchNoteByText", ctx, req)
-	ret0, _ := ret[0].([]model.GetNote)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -273,6 +417,21 @@ func (mr *MockspaceServiceMockRecorder) SearchNoteByText(ctx, req interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*MockspaceService)(nil).SearchNoteByText), ctx, req)
 }
 
+// SuggestNotes mocks base method.
+func (m *MockspaceService) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", ctx, req)
+	ret0, _ := ret[0].(model.SuggestNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MockspaceServiceMockRecorder) SuggestNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*MockspaceService)(nil).SuggestNotes), ctx, req)
+}
+
 // UpdateNote mocks base method.
 func (m *MockspaceService) UpdateNote(ctx context.Context, update rabbit.UpdateNoteRequest) error {
 	m.ctrl.T.Helper()
@@ -556,6 +715,95 @@ func (mr *MocknoteUpdaterMockRecorder) UpdateNote(ctx, update interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MocknoteUpdater)(nil).UpdateNote), ctx, update)
 }
 
+// MocknoteBatcher is a mock of noteBatcher interface.
+type MocknoteBatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MocknoteBatcherMockRecorder
+}
+
+// MocknoteBatcherMockRecorder is the mock recorder for MocknoteBatcher.
+type MocknoteBatcherMockRecorder struct {
+	mock *MocknoteBatcher
+}
+
+// NewMocknoteBatcher creates a new mock instance.
+func NewMocknoteBatcher(ctrl *gomock.Controller) *MocknoteBatcher {
+	mock := &MocknoteBatcher{ctrl: ctrl}
+	mock.recorder = &MocknoteBatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknoteBatcher) EXPECT() *MocknoteBatcherMockRecorder {
+	return m.recorder
+}
+
+// BatchNotes mocks base method.
+func (m *MocknoteBatcher) BatchNotes(ctx context.Context, req model.BatchNotesRequest) (model.BatchNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", ctx, req)
+	ret0, _ := ret[0].(model.BatchNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MocknoteBatcherMockRecorder) BatchNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MocknoteBatcher)(nil).BatchNotes), ctx, req)
+}
+
+// MocknoteTransferer is a mock of noteTransferer interface.
+type MocknoteTransferer struct {
+	ctrl     *gomock.Controller
+	recorder *MocknoteTransfererMockRecorder
+}
+
+// MocknoteTransfererMockRecorder is the mock recorder for MocknoteTransferer.
+type MocknoteTransfererMockRecorder struct {
+	mock *MocknoteTransferer
+}
+
+// NewMocknoteTransferer creates a new mock instance.
+func NewMocknoteTransferer(ctrl *gomock.Controller) *MocknoteTransferer {
+	mock := &MocknoteTransferer{ctrl: ctrl}
+	mock.recorder = &MocknoteTransfererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknoteTransferer) EXPECT() *MocknoteTransfererMockRecorder {
+	return m.recorder
+}
+
+// CopyNote mocks base method.
+func (m *MocknoteTransferer) CopyNote(ctx context.Context, note model.GetNote, req rabbit.CreateNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyNote", ctx, note, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyNote indicates an expected call of CopyNote.
+func (mr *MocknoteTransfererMockRecorder) CopyNote(ctx, note, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyNote", reflect.TypeOf((*MocknoteTransferer)(nil).CopyNote), ctx, note, req)
+}
+
+// MoveNote mocks base method.
+func (m *MocknoteTransferer) MoveNote(ctx context.Context, req rabbit.MoveNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MocknoteTransfererMockRecorder) MoveNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MocknoteTransferer)(nil).MoveNote), ctx, req)
+}
+
 // MocknoteDeleter is a mock of noteDeleter interface.
 type MocknoteDeleter struct {
 	ctrl     *gomock.Controller
@@ -705,6 +953,115 @@ func (mr *MocknoteGetterMockRecorder) GetNotesTypes(ctx, spaceID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesTypes", reflect.TypeOf((*MocknoteGetter)(nil).GetNotesTypes), ctx, spaceID)
 }
 
+// MocktrashManager is a mock of trashManager interface.
+type MocktrashManager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrashManagerMockRecorder
+}
+
+// MocktrashManagerMockRecorder is the mock recorder for MocktrashManager.
+type MocktrashManagerMockRecorder struct {
+	mock *MocktrashManager
+}
+
+// NewMocktrashManager creates a new mock instance.
+func NewMocktrashManager(ctrl *gomock.Controller) *MocktrashManager {
+	mock := &MocktrashManager{ctrl: ctrl}
+	mock.recorder = &MocktrashManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrashManager) EXPECT() *MocktrashManagerMockRecorder {
+	return m.recorder
+}
+
+// GetTrash mocks base method.
+func (m *MocktrashManager) GetTrash(ctx context.Context, spaceID uuid.UUID) ([]model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, spaceID)
+	ret0, _ := ret[0].([]model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MocktrashManagerMockRecorder) GetTrash(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MocktrashManager)(nil).GetTrash), ctx, spaceID)
+}
+
+// GetTrashNoteByID mocks base method.
+func (m *MocktrashManager) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID) (model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashNoteByID", ctx, noteID)
+	ret0, _ := ret[0].(model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashNoteByID indicates an expected call of GetTrashNoteByID.
+func (mr *MocktrashManagerMockRecorder) GetTrashNoteByID(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashNoteByID", reflect.TypeOf((*MocktrashManager)(nil).GetTrashNoteByID), ctx, noteID)
+}
+
+// PurgeAllNotes mocks base method.
+func (m *MocktrashManager) PurgeAllNotes(ctx context.Context, req rabbit.PurgeAllNotesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MocktrashManagerMockRecorder) PurgeAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*MocktrashManager)(nil).PurgeAllNotes), ctx, req)
+}
+
+// PurgeNote mocks base method.
+func (m *MocktrashManager) PurgeNote(ctx context.Context, req rabbit.PurgeNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MocktrashManagerMockRecorder) PurgeNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MocktrashManager)(nil).PurgeNote), ctx, req)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *MocktrashManager) RestoreAllNotes(ctx context.Context, req rabbit.RestoreAllNotesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MocktrashManagerMockRecorder) RestoreAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*MocktrashManager)(nil).RestoreAllNotes), ctx, req)
+}
+
+// RestoreNote mocks base method.
+func (m *MocktrashManager) RestoreNote(ctx context.Context, req rabbit.RestoreNoteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MocktrashManagerMockRecorder) RestoreNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MocktrashManager)(nil).RestoreNote), ctx, req)
+}
+
 // MocknoteSearcher is a mock of noteSearcher interface.
 type MocknoteSearcher struct {
 	ctrl     *gomock.Controller
@@ -728,11 +1085,26 @@ func (m *MocknoteSearcher) EXPECT() *MocknoteSearcherMockRecorder {
 	return m.recorder
 }
 
+// SearchAllNotes mocks base method.
+func (m *MocknoteSearcher) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest) (model.SearchNoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", ctx, req)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MocknoteSearcherMockRecorder) SearchAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*MocknoteSearcher)(nil).SearchAllNotes), ctx, req)
+}
+
 // SearchNoteByText mocks base method.
-func (m *MocknoteSearcher) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
+func (m *MocknoteSearcher) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchNoteByText", ctx, req)
-	ret0, _ := ret[0].([]model.GetNote)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -743,6 +1115,21 @@ func (mr *MocknoteSearcherMockRecorder) SearchNoteByText(ctx, req interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*MocknoteSearcher)(nil).SearchNoteByText), ctx, req)
 }
 
+// SuggestNotes mocks base method.
+func (m *MocknoteSearcher) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", ctx, req)
+	ret0, _ := ret[0].(model.SuggestNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MocknoteSearcherMockRecorder) SuggestNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*MocknoteSearcher)(nil).SuggestNotes), ctx, req)
+}
+
 // MockuserService is a mock of userService interface.
 type MockuserService struct {
 	ctrl     *gomock.Controller
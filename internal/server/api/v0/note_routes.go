@@ -273,9 +273,10 @@ func (h *Handler) GetNotesByType(c echo.Context) error {
 }
 
 //	@Summary		Получить все заметки по тексту
-//	@Description	Получить все заметки с текстом среди указанного типа (по умолчанию: текстовые)
+//	@Description	Получить заметки с текстом среди указанного типа (по умолчанию: текстовые), отсортированные по релевантности.
+//	@Description	Листать результаты можно через from / size или через курсор search_after из предыдущего ответа
 //	@Param          type   body      model.SearchNoteByTextRequest  true  "запрос на поиск по тексту"
-//	@Success		200 {object}    []model.GetNote   массив с типами заметок и их количеством
+//	@Success		200 {object}    model.SearchNoteResponse   найденные заметки с релевантностью и подсвеченным текстом
 //	@Failure		404	{object}	nil "Нет заметок"
 //	@Failure		400	{object}	map[string]string "Невалидный запрос"
 //	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
@@ -290,13 +291,9 @@ func (h *Handler) SearchNoteByText(c echo.Context) error {
 		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
 	}
 
-	if len(req.Type) > 0 {
-		// валидируем запрос: тип должен быть одним из перечисленных
-		switch req.Type {
-		case model.TextNoteType, model.PhotoNoteType:
-		default:
-			return api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid note type: %s", req.Type), nil)
-		}
+	// валидируем запрос: типы заметок должны быть одними из перечисленных, фильтры и страница - корректными
+	if err := req.Validate(); err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
 	}
 
 	notes, err := h.space.SearchNoteByText(c.Request().Context(), req)
@@ -305,12 +302,110 @@ func (h *Handler) SearchNoteByText(c echo.Context) error {
 			return c.NoContent(http.StatusNotFound)
 		}
 
+		// ошибка в поисковом запросе пользователя
+		var syntaxErr *model.SearchSyntaxError
+		if errors.As(err, &syntaxErr) {
+			return api_errors.NewHTTPError(http.StatusBadRequest, syntaxErr.Error(), err)
+		}
+
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return c.JSON(http.StatusOK, notes)
+}
+
+//	@Summary		Поиск заметок по тексту во всех пространствах пользователя
+//	@Description	Найти заметки с текстом во всех пространствах, в которых состоит пользователь из токена, одним запросом.
+//	@Description	У каждой заметки указано пространство, в поле spaces - сколько заметок нашлось в каждом пространстве
+//	@Param          request   body      model.SearchAllNotesRequest  true  "запрос на поиск по тексту"
+//	@Success		200 {object}    model.SearchNoteResponse   найденные заметки с релевантностью и подсвеченным текстом
+//	@Failure		404	{object}	nil "Нет заметок"
+//	@Failure		400	{object}	map[string]string "Невалидный запрос"
+//	@Failure		401	{object}	map[string]string "Невалидный токен"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/spaces/notes/search/all [post]
+//
+// ручка для поиска заметок по тексту во всех пространствах пользователя
+func (h *Handler) SearchAllNotes(c echo.Context) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	var req model.SearchAllNotesRequest
+
+	err = json.NewDecoder(c.Request().Body).Decode(&req)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	req.UserID = userID
+
+	// валидируем запрос: типы заметок должны быть одними из перечисленных, фильтры и страница - корректными
+	if err := req.Validate(); err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	notes, err := h.space.SearchAllNotes(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrNoNotesFoundByText) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		// ошибка в поисковом запросе пользователя
+		var syntaxErr *model.SearchSyntaxError
+		if errors.As(err, &syntaxErr) {
+			return api_errors.NewHTTPError(http.StatusBadRequest, syntaxErr.Error(), err)
+		}
+
 		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
 	}
 
 	return c.JSON(http.StatusOK, notes)
 }
 
+//	@Summary		Подсказки при вводе текста в поиск
+//	@Description	Получить несколько заметок, в которых есть слова, начинающиеся с введенного текста. Отвечает быстро и не ищет с опечатками:
+//	@Description	для полного поиска используется /spaces/notes/search/text
+//	@Param          space_id   path      string  true  "ID пространства"
+//	@Param          q   query      string  true  "введенный текст"
+//	@Param          size   query      int  false  "сколько подсказок вернуть (по умолчанию 5, не больше 10)"
+//	@Success		200 {object}    model.SuggestNotesResponse   подсказки, отсортированные по релевантности
+//	@Failure		400	{object}	map[string]string "Невалидный запрос"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/notes/suggest [get]
+//
+// ручка для подсказок при вводе
+func (h *Handler) SuggestNotes(c echo.Context) error {
+	spaceID, err := getSpaceIDFromPath(c)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid space id parameter: %+v", err), err)
+	}
+
+	req := model.SuggestNotesRequest{
+		SpaceID: spaceID,
+		Query:   c.QueryParam("q"),
+	}
+
+	if sizeParam := c.QueryParam("size"); len(sizeParam) > 0 {
+		req.Size, err = strconv.Atoi(sizeParam)
+		if err != nil {
+			return api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid size parameter: %+v", err), err)
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	suggestions, err := h.space.SuggestNotes(c.Request().Context(), req)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
+
 //	@Summary		Удалить заметку по айди
 //	@Param          space_id   path      string  true  "айди пространства"
 //	@Param          note_id   path      string  true  "айди заметки"
@@ -417,6 +512,49 @@ func (h *Handler) DeleteAllNotes(c echo.Context) error {
 	return sendRequestID(c, req.ID)
 }
 
+//	@Summary		Пакетные операции над заметками
+//	@Description	Создать, обновить и удалить несколько заметок пространства одним запросом (не больше 100 операций).
+//	@Description	Если atomic = true, пакет принимается целиком либо отклоняется целиком
+//	@Param          space_id   path      string  true  "айди пространства"
+//	@Param			request	body	model.BatchNotesRequest	true	"пакет операций: айди пользователя, флаг atomic и список операций create / update / delete"
+//	@Success		202 {object}    model.BatchNotesResponse "Результат по каждой операции и айди запросов"
+//	@Failure		400	{object}	model.BatchNotesResponse "Невалидный запрос / атомарный пакет отклонен"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/notes/batch [post]
+//
+// ручка для пакетных операций над заметками
+func (h *Handler) BatchNotes(c echo.Context) error {
+	spaceID, err := getSpaceIDFromPath(c)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid space id parameter: %+v", err), err)
+	}
+
+	var req model.BatchNotesRequest
+
+	err = json.NewDecoder(c.Request().Body).Decode(&req)
+	if err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	req.SpaceID = spaceID
+
+	if err := req.Validate(); err != nil {
+		return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	resp, err := h.space.BatchNotes(c.Request().Context(), req)
+	if err != nil {
+		// атомарный пакет отклонен: отдаем результат по каждой операции
+		if errors.Is(err, model.ErrBatchRejected) {
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return c.JSON(http.StatusAccepted, resp)
+}
+
 func getSpaceIDFromPath(c echo.Context) (uuid.UUID, error) {
 	spaceIDStr := c.Param("space_id")
 
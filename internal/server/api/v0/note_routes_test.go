@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 	api_errors "webserver/internal/errors"
@@ -16,6 +18,7 @@ import (
 
 	"bou.ke/monkey"
 	"github.com/ex-rate/logger"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -974,7 +977,7 @@ func TestSearchNotesByText(t *testing.T) {
 		req              model.SearchNoteByTextRequest
 		dbErr            error // ошибка, которую возвращает база
 		expectedCode     int
-		expectedResponse []model.GetNote
+		expectedResponse model.SearchNoteResponse
 		expectedErr      *api_errors.HTTPError
 		setupMocks       func(mocks *fields)
 	}
@@ -987,14 +990,22 @@ func TestSearchNotesByText(t *testing.T) {
 
 	handlerLogger := logger.WithService("handler")
 
-	fullNote := []model.GetNote{
-		{
-			ID:      uuid.New(),
-			UserID:  1234,
-			Text:    "positive test",
-			SpaceID: uuid.New(),
-			Type:    model.TextNoteType,
+	fullNote := model.SearchNoteResponse{
+		Total: 1,
+		Hits: []model.SearchNoteHit{
+			{
+				GetNote: model.GetNote{
+					ID:      uuid.New(),
+					UserID:  1234,
+					Text:    "positive test",
+					SpaceID: uuid.New(),
+					Type:    model.TextNoteType,
+				},
+				Score:      2.5,
+				Highlights: []string{"<em>positive</em> <em>test</em>"},
+			},
 		},
+		SearchAfter: "WzIuNSwiYWJjIl0",
 	}
 
 	tests := []test{
@@ -1039,6 +1050,59 @@ func TestSearchNotesByText(t *testing.T) {
 			expectedErr: api_errors.NewHTTPError(http.StatusBadRequest, "invalid note type: video", nil),
 			setupMocks:  func(mocks *fields) {},
 		},
+		{
+			name:         "positive test: search after",
+			expectedCode: http.StatusOK,
+			req: model.SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "positive test",
+				SearchPage: model.SearchPage{
+					Size:        1,
+					SearchAfter: fullNote.SearchAfter,
+				},
+			},
+			expectedResponse: fullNote,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).Return(fullNote, nil)
+			},
+		},
+		{
+			name:         "invalid size",
+			expectedCode: http.StatusBadRequest,
+			req: model.SearchNoteByTextRequest{
+				SpaceID:    uuid.New(),
+				Text:       "positive test",
+				SearchPage: model.SearchPage{Size: model.MaxSearchSize + 1},
+			},
+			expectedErr: api_errors.NewHTTPError(http.StatusBadRequest, model.ErrInvalidSearchSize.Error(), nil),
+			setupMocks:  func(mocks *fields) {},
+		},
+		{
+			name:         "from and search after together",
+			expectedCode: http.StatusBadRequest,
+			req: model.SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "positive test",
+				SearchPage: model.SearchPage{
+					From:        10,
+					SearchAfter: fullNote.SearchAfter,
+				},
+			},
+			expectedErr: api_errors.NewHTTPError(http.StatusBadRequest, model.ErrSearchPagingConflict.Error(), nil),
+			setupMocks:  func(mocks *fields) {},
+		},
+		{
+			name:         "unrecognized date",
+			expectedCode: http.StatusBadRequest,
+			req: model.SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "positive test",
+				SearchFilters: model.SearchFilters{Date: "когда-нибудь"},
+			},
+			expectedErr: api_errors.NewHTTPError(http.StatusBadRequest, `unrecognized date expression: "когда-нибудь"`, nil),
+			setupMocks:  func(mocks *fields) {},
+		},
 		{
 			name:         "notes not found",
 			spaceID:      uuid.NewString(),
@@ -1051,7 +1115,21 @@ func TestSearchNotesByText(t *testing.T) {
 			},
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.spaceSrv.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).Return(nil, api_errors.ErrNoNotesFoundByText)
+				mocks.spaceSrv.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).Return(model.SearchNoteResponse{}, api_errors.ErrNoNotesFoundByText)
+			},
+		},
+		{
+			name:         "query syntax error",
+			expectedCode: http.StatusBadRequest,
+			req: model.SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    `молоко "купить хлеб`,
+			},
+			expectedErr: api_errors.NewHTTPError(http.StatusBadRequest, "syntax error at position 8: unclosed quote", nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				syntaxErr := &model.SearchSyntaxError{Position: 8, Message: "unclosed quote"}
+				mocks.spaceSrv.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).Return(model.SearchNoteResponse{}, fmt.Errorf("error while creating query for search note: %w", syntaxErr))
 			},
 		},
 	}
@@ -1089,7 +1167,7 @@ func TestSearchNotesByText(t *testing.T) {
 			assert.Equal(t, tt.expectedCode, resp.StatusCode)
 
 			if tt.expectedCode == http.StatusOK { // успешный кейс
-				var result []model.GetNote
+				var result model.SearchNoteResponse
 
 				dec := json.NewDecoder(resp.Body)
 				err = dec.Decode(&result)
@@ -1106,6 +1184,169 @@ func TestSearchNotesByText(t *testing.T) {
 	}
 }
 
+func TestSearchAllNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name             string
+		req              model.SearchAllNotesRequest
+		expectedCode     int
+		expectedResponse model.SearchNoteResponse
+		expectedErr      *api_errors.HTTPError
+		setupMocks       func(mocks *fields)
+	}
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	userID := float64(1234)
+	expired := float64(time.Now().Add(24 * time.Hour).Unix())
+
+	spaceID := uuid.New()
+
+	found := model.SearchNoteResponse{
+		Total: 1,
+		Hits: []model.SearchNoteHit{
+			{
+				GetNote: model.GetNote{
+					ID:      uuid.New(),
+					UserID:  1234,
+					Text:    "dentist on monday",
+					SpaceID: spaceID,
+					Type:    model.TextNoteType,
+				},
+				Score:      1.2,
+				Highlights: []string{"<em>dentist</em> on monday"},
+			},
+		},
+		Spaces: []model.SpaceHits{{SpaceID: spaceID, Count: 1}},
+	}
+
+	// успешная авторизация пользователя из токена
+	auth := func(mocks *fields) {
+		mocks.authSrv.EXPECT().CheckToken(gomock.Any()).Return(&jwt.Token{}, nil)
+		mocks.authSrv.EXPECT().GetPayload(gomock.Any()).Return(map[string]interface{}{
+			"user_id": userID,
+			"expired": expired,
+		}, true)
+		mocks.userSrv.EXPECT().CheckUser(gomock.Any(), int64(userID)).Return(true, nil)
+	}
+
+	tests := []test{
+		{
+			name:             "positive case",
+			req:              model.SearchAllNotesRequest{Text: "dentist"},
+			expectedCode:     http.StatusOK,
+			expectedResponse: found,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				auth(mocks)
+				mocks.spaceSrv.EXPECT().SearchAllNotes(gomock.Any(), model.SearchAllNotesRequest{
+					UserID: int64(userID),
+					Text:   "dentist",
+				}).Return(found, nil)
+			},
+		},
+		{
+			name:         "invalid note type",
+			req:          model.SearchAllNotesRequest{Text: "dentist", Type: "video"},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid note type: video", nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				auth(mocks)
+			},
+		},
+		{
+			name:         "text not filled",
+			req:          model.SearchAllNotesRequest{},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrFieldTextNotFilled.Error(), nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				auth(mocks)
+			},
+		},
+		{
+			name:         "notes not found",
+			req:          model.SearchAllNotesRequest{Text: "dentist"},
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				auth(mocks)
+				mocks.spaceSrv.EXPECT().SearchAllNotes(gomock.Any(), gomock.Any()).Return(model.SearchNoteResponse{}, api_errors.ErrNoNotesFoundByText)
+			},
+		},
+		{
+			name:         "internal error",
+			req:          model.SearchAllNotesRequest{Text: "dentist"},
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  api_errors.NewHTTPError(http.StatusInternalServerError, "elastic error", nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				auth(mocks)
+				mocks.spaceSrv.EXPECT().SearchAllNotes(gomock.Any(), gomock.Any()).Return(model.SearchNoteResponse{}, errors.New("elastic error"))
+			},
+		},
+	}
+
+	url := "/api/v0/spaces/notes/search/all"
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			bodyJSON, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			token := generateToken(t, userID, expired)
+
+			resp := testRequest(t, ts, http.MethodPost, url, token, bytes.NewReader(bodyJSON))
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusOK {
+				var result model.SearchNoteResponse
+
+				err = json.NewDecoder(resp.Body).Decode(&result)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.expectedResponse, result)
+			} else if tt.expectedErr != nil {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestDeleteNote(t *testing.T) {
 	type fields struct {
 		spaceSrv *mocks.MockspaceService
@@ -1441,3 +1682,316 @@ func TestDeleteAllNotes(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name         string
+		spaceID      string
+		req          model.BatchNotesRequest
+		expectedCode int
+		expectedErr  *api_errors.HTTPError
+		expectedResp *model.BatchNotesResponse
+		setupMocks   func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	noteID := uuid.New()
+
+	items := []model.BatchNoteItem{
+		{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+		{Operation: model.BatchDelete, NoteID: noteID},
+	}
+
+	acceptedResp := model.BatchNotesResponse{
+		Accepted: 2,
+		Results: []model.BatchNoteResult{
+			{Index: 0, Operation: model.BatchCreate, RequestID: uuid.New(), Status: model.BatchItemAccepted},
+			{Index: 1, Operation: model.BatchDelete, NoteID: noteID, RequestID: uuid.New(), Status: model.BatchItemAccepted},
+		},
+	}
+
+	rejectedResp := model.BatchNotesResponse{
+		Rejected: 2,
+		Results: []model.BatchNoteResult{
+			{Index: 0, Operation: model.BatchCreate, Status: model.BatchItemSkipped},
+			{Index: 1, Operation: model.BatchDelete, NoteID: noteID, Status: model.BatchItemRejected, Error: api_errors.ErrNoteNotFound.Error()},
+		},
+	}
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			req:          model.BatchNotesRequest{UserID: 1, Items: items},
+			expectedCode: http.StatusAccepted,
+			expectedResp: &acceptedResp,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().BatchNotes(gomock.Any(), model.BatchNotesRequest{
+					UserID:  1,
+					SpaceID: spaceID,
+					Items:   items,
+				}).Return(acceptedResp, nil)
+			},
+		},
+		{
+			name:         "atomic batch rejected",
+			spaceID:      spaceID.String(),
+			req:          model.BatchNotesRequest{UserID: 1, Atomic: true, Items: items},
+			expectedCode: http.StatusBadRequest,
+			expectedResp: &rejectedResp,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().BatchNotes(gomock.Any(), gomock.Any()).Return(rejectedResp, model.ErrBatchRejected)
+			},
+		},
+		{
+			name:         "empty batch",
+			spaceID:      spaceID.String(),
+			req:          model.BatchNotesRequest{UserID: 1},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrEmptyBatch.Error(), model.ErrEmptyBatch),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "batch too large",
+			spaceID:      spaceID.String(),
+			req:          model.BatchNotesRequest{UserID: 1, Items: make([]model.BatchNoteItem, model.MaxBatchSize+1)},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrBatchTooLarge.Error(), model.ErrBatchTooLarge),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			req:          model.BatchNotesRequest{UserID: 1, Items: items},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "internal error",
+			spaceID:      spaceID.String(),
+			req:          model.BatchNotesRequest{UserID: 1, Atomic: true, Items: items},
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  api_errors.NewHTTPError(http.StatusInternalServerError, "channel closed", nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().BatchNotes(gomock.Any(), gomock.Any()).Return(model.BatchNotesResponse{}, fmt.Errorf("channel closed"))
+			},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/notes/batch"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			bodyJSON, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			resp := testRequest(t, ts, http.MethodPost, fmt.Sprintf(urlFmt, tt.spaceID), "", bytes.NewReader(bodyJSON))
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedResp != nil {
+				var actual model.BatchNotesResponse
+				err := json.NewDecoder(resp.Body).Decode(&actual)
+				require.NoError(t, err)
+
+				assert.Equal(t, *tt.expectedResp, actual)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSuggestNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name             string
+		spaceID          string
+		query            url.Values
+		expectedCode     int
+		expectedResponse model.SuggestNotesResponse
+		expectedErr      *api_errors.HTTPError
+		setupMocks       func(mocks *fields)
+	}
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	spaceID := uuid.New()
+
+	suggestions := model.SuggestNotesResponse{
+		Suggestions: []model.NoteSuggestion{
+			{ID: uuid.New(), Type: model.TextNoteType, Snippet: "<em>купить</em> молоко"},
+			{ID: uuid.New(), Type: model.PhotoNoteType, Snippet: "<em>купил</em> билеты"},
+		},
+	}
+
+	tests := []test{
+		{
+			name:             "positive test",
+			spaceID:          spaceID.String(),
+			query:            url.Values{"q": {"куп"}},
+			expectedCode:     http.StatusOK,
+			expectedResponse: suggestions,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().SuggestNotes(gomock.Any(), model.SuggestNotesRequest{SpaceID: spaceID, Query: "куп"}).Return(suggestions, nil)
+			},
+		},
+		{
+			name:             "positive test: with size",
+			spaceID:          spaceID.String(),
+			query:            url.Values{"q": {"куп"}, "size": {"2"}},
+			expectedCode:     http.StatusOK,
+			expectedResponse: suggestions,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().SuggestNotes(gomock.Any(), model.SuggestNotesRequest{SpaceID: spaceID, Query: "куп", Size: 2}).Return(suggestions, nil)
+			},
+		},
+		{
+			name:             "positive test: no suggestions",
+			spaceID:          spaceID.String(),
+			query:            url.Values{"q": {"абырвалг"}},
+			expectedCode:     http.StatusOK,
+			expectedResponse: model.SuggestNotesResponse{Suggestions: []model.NoteSuggestion{}},
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().SuggestNotes(gomock.Any(), gomock.Any()).Return(model.SuggestNotesResponse{Suggestions: []model.NoteSuggestion{}}, nil)
+			},
+		},
+		{
+			name:         "invalid space id",
+			spaceID:      "123",
+			query:        url.Values{"q": {"куп"}},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "query not filled",
+			spaceID:      spaceID.String(),
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrSuggestQueryNotFilled.Error(), nil),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid size",
+			spaceID:      spaceID.String(),
+			query:        url.Values{"q": {"куп"}, "size": {"много"}},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, `invalid size parameter: strconv.Atoi: parsing "много": invalid syntax`, nil),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "size too big",
+			spaceID:      spaceID.String(),
+			query:        url.Values{"q": {"куп"}, "size": {"100"}},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrInvalidSuggestSize.Error(), nil),
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "internal error",
+			spaceID:      spaceID.String(),
+			query:        url.Values{"q": {"куп"}},
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  api_errors.NewHTTPError(http.StatusInternalServerError, "elastic error", nil),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().SuggestNotes(gomock.Any(), gomock.Any()).Return(model.SuggestNotesResponse{}, errors.New("elastic error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			target := fmt.Sprintf("/api/v0/spaces/%s/notes/suggest?%s", tt.spaceID, tt.query.Encode())
+
+			resp := testRequest(t, ts, http.MethodGet, target, "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusOK {
+				var result model.SuggestNotesResponse
+
+				err = json.NewDecoder(resp.Body).Decode(&result)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.expectedResponse, result)
+			} else if tt.expectedErr != nil {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//	@Summary		Перенести заметку в другое пространство
+//	@Description	Перенести заметку в другое пространство. Пользователь должен состоять в обоих пространствах. Тип заметки и файл сохраняются
+//	@Param          space_id   path      string  true  "айди пространства, где сейчас лежит заметка"
+//	@Param          note_id   path      string  true  "айди заметки"
+//	@Param			request	body	model.TransferNoteRequest	true	"айди пользователя и айди пространства, куда перенести заметку"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Невалидный запрос / пользователь не состоит в пространстве / в пространстве нет такой заметки"
+//	@Failure		404	{object}	map[string]string "Заметка не найдена"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/notes/{note_id}/move [post]
+//
+// ручка для переноса заметки в другое пространство
+func (h *Handler) MoveNote(c echo.Context) error {
+	note, transfer, err := h.checkTransferNote(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.MoveNoteRequest{
+		ID:            uuid.New(),
+		UserID:        transfer.UserID,
+		NoteID:        note.ID,
+		SpaceID:       note.SpaceID,
+		TargetSpaceID: transfer.TargetSpaceID,
+		Created:       time.Now().In(time.UTC).Unix(),
+		Operation:     rabbit.MoveOp,
+	}
+
+	if err := h.space.MoveNote(c.Request().Context(), req); err != nil {
+		// внутренняя ошибка / ошибка валидации
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+//	@Summary		Скопировать заметку в другое пространство
+//	@Description	Создать в другом пространстве копию заметки с тем же текстом, типом и файлом. Пользователь должен состоять в обоих пространствах
+//	@Param          space_id   path      string  true  "айди пространства, где лежит заметка"
+//	@Param          note_id   path      string  true  "айди заметки"
+//	@Param			request	body	model.TransferNoteRequest	true	"айди пользователя и айди пространства, куда скопировать заметку"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Невалидный запрос / пользователь не состоит в пространстве / в пространстве нет такой заметки"
+//	@Failure		404	{object}	map[string]string "Заметка не найдена"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/notes/{note_id}/copy [post]
+//
+// ручка для копирования заметки в другое пространство
+func (h *Handler) CopyNote(c echo.Context) error {
+	note, transfer, err := h.checkTransferNote(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.CreateNoteRequest{
+		ID:        uuid.New(),
+		UserID:    transfer.UserID,
+		SpaceID:   transfer.TargetSpaceID,
+		Created:   time.Now().In(time.UTC).Unix(),
+		Operation: rabbit.CreateOp,
+	}
+
+	if err := h.space.CopyNote(c.Request().Context(), note, req); err != nil {
+		// ошибки запроса: у исходной заметки не заполнены поля, без которых нельзя создать копию
+		errs := []error{model.ErrFieldTextNotFilled, model.ErrFieldTypeNotFilled}
+
+		if errorsIn(err, errs) {
+			return api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+		}
+
+		// внутренняя ошибка
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+// checkTransferNote разбирает запрос на перенос / копирование заметки и проверяет,
+// что пользователь состоит в обоих пространствах, а заметка лежит в исходном пространстве
+func (h *Handler) checkTransferNote(c echo.Context) (model.GetNote, model.TransferNoteRequest, error) {
+	spaceID, err := getSpaceIDFromPath(c)
+	if err != nil {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid space id parameter: %+v", err), err)
+	}
+
+	noteID, err := getNoteIDFromPath(c)
+	if err != nil {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid note id parameter: %+v", err), err)
+	}
+
+	var req model.TransferNoteRequest
+
+	err = json.NewDecoder(c.Request().Body).Decode(&req)
+	if err != nil {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+	}
+
+	if req.TargetSpaceID == spaceID {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSameSpace.Error(), nil)
+	}
+
+	// проверяем, что пользователь состоит и в исходном, и в целевом пространстве
+	for _, id := range []uuid.UUID{spaceID, req.TargetSpaceID} {
+		ok, err := h.space.IsUserInSpace(c.Request().Context(), req.UserID, id)
+		if err != nil {
+			return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+		}
+
+		if !ok {
+			return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrUserNotBelongsSpace.Error(), nil)
+		}
+	}
+
+	// проверяем, что в пространстве есть заметка с таким айди
+	note, err := h.space.GetNoteByID(c.Request().Context(), noteID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrNoteNotFound) {
+			return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusNotFound, err.Error(), err)
+		}
+
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	// заметка не из этого пространства
+	if note.SpaceID != spaceID {
+		return model.GetNote{}, model.TransferNoteRequest{}, api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil)
+	}
+
+	return note, req, nil
+}
@@ -0,0 +1,421 @@
+package v0
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/server/api/v0/mocks"
+
+	"bou.ke/monkey"
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveNote(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name            string
+		spaceID, noteID string
+		req             model.TransferNoteRequest
+		expectedCode    int
+		expectedErr     *api_errors.HTTPError
+		setupMocks      func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	targetSpaceID := uuid.New()
+	noteID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	note := model.GetNote{
+		ID:      noteID,
+		UserID:  1,
+		Text:    "note",
+		SpaceID: spaceID,
+		Type:    model.PhotoNoteType,
+		File:    sql.NullString{String: "photo.jpg", Valid: true},
+	}
+
+	req := model.TransferNoteRequest{UserID: 1, TargetSpaceID: targetSpaceID}
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), targetSpaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(note, nil)
+				mocks.spaceSrv.EXPECT().MoveNote(gomock.Any(), rabbit.MoveNoteRequest{
+					ID:            requestID,
+					UserID:        1,
+					NoteID:        noteID,
+					SpaceID:       spaceID,
+					TargetSpaceID: targetSpaceID,
+					Created:       wayback.In(time.UTC).Unix(),
+					Operation:     rabbit.MoveOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid note ID",
+			spaceID:      spaceID.String(),
+			noteID:       "abc",
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid note id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "target space not filled",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          model.TransferNoteRequest{UserID: 1},
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrInvalidSpaceID.Error(), model.ErrInvalidSpaceID),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "same space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          model.TransferNoteRequest{UserID: 1, TargetSpaceID: spaceID},
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSameSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "user not in source space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrUserNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(false, nil)
+			},
+		},
+		{
+			name:         "user not in target space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrUserNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), targetSpaceID).Return(false, nil)
+			},
+		},
+		{
+			name:         "note not found",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusNotFound, api_errors.ErrNoteNotFound.Error(), api_errors.ErrNoteNotFound),
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(model.GetNote{}, api_errors.ErrNoteNotFound)
+			},
+		},
+		{
+			name:         "note does not belong space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(model.GetNote{ID: noteID, SpaceID: uuid.Nil}, nil)
+			},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/notes/%s/move"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			bodyJSON, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			resp := testRequest(t, ts, http.MethodPost, fmt.Sprintf(urlFmt, tt.spaceID, tt.noteID), "", bytes.NewReader(bodyJSON))
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCopyNote(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name            string
+		spaceID, noteID string
+		req             model.TransferNoteRequest
+		expectedCode    int
+		expectedErr     *api_errors.HTTPError
+		setupMocks      func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	targetSpaceID := uuid.New()
+	noteID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	note := model.GetNote{
+		ID:      noteID,
+		UserID:  1,
+		Text:    "note",
+		SpaceID: spaceID,
+		Type:    model.PhotoNoteType,
+		File:    sql.NullString{String: "photo.jpg", Valid: true},
+	}
+
+	req := model.TransferNoteRequest{UserID: 1, TargetSpaceID: targetSpaceID}
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), targetSpaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(note, nil)
+				mocks.spaceSrv.EXPECT().CopyNote(gomock.Any(), note, rabbit.CreateNoteRequest{
+					ID:        requestID,
+					UserID:    1,
+					SpaceID:   targetSpaceID,
+					Created:   wayback.In(time.UTC).Unix(),
+					Operation: rabbit.CreateOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid note ID",
+			spaceID:      spaceID.String(),
+			noteID:       "abc",
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid note id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "target space not filled",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          model.TransferNoteRequest{UserID: 1},
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, model.ErrInvalidSpaceID.Error(), model.ErrInvalidSpaceID),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "same space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          model.TransferNoteRequest{UserID: 1, TargetSpaceID: spaceID},
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSameSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "user not in source space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrUserNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(false, nil)
+			},
+		},
+		{
+			name:         "user not in target space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrUserNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), spaceID).Return(true, nil)
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), int64(1), targetSpaceID).Return(false, nil)
+			},
+		},
+		{
+			name:         "note not found",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusNotFound, api_errors.ErrNoteNotFound.Error(), api_errors.ErrNoteNotFound),
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(model.GetNote{}, api_errors.ErrNoteNotFound)
+			},
+		},
+		{
+			name:         "note does not belong space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			req:          req,
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().IsUserInSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+				mocks.spaceSrv.EXPECT().GetNoteByID(gomock.Any(), noteID).Return(model.GetNote{ID: noteID, SpaceID: uuid.Nil}, nil)
+			},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/notes/%s/copy"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			bodyJSON, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+
+			resp := testRequest(t, ts, http.MethodPost, fmt.Sprintf(urlFmt, tt.spaceID, tt.noteID), "", bytes.NewReader(bodyJSON))
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
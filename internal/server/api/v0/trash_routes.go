@@ -0,0 +1,223 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model/rabbit"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//	@Summary		Получить заметки из корзины
+//	@Description	Получить заметки, перемещенные в корзину пространства, срок хранения которых еще не истек
+//	@Param          space_id   path      string  true  "ID пространства"
+//	@Success		200 {object}    []model.TrashNote   массив заметок из корзины
+//	@Failure		400	{object}	map[string]string "Невалидный запрос / пространства не существует"
+//	@Failure		404	{object}	nil "Корзина пуста"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/trash [get]
+//
+// ручка для получения заметок из корзины
+func (h *Handler) GetTrash(c echo.Context) error {
+	spaceID, err := h.checkTrashSpace(c)
+	if err != nil {
+		return err
+	}
+
+	notes, err := h.space.GetTrash(c.Request().Context(), spaceID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrNoNotesInTrash) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return c.JSON(http.StatusOK, notes)
+}
+
+//	@Summary		Восстановить заметку из корзины
+//	@Param          space_id   path      string  true  "айди пространства"
+//	@Param          note_id   path      string  true  "айди заметки"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Пространства не существует / в пространстве нет такой заметки"
+//	@Failure		404	{object}	map[string]string "Заметка не найдена в корзине"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/trash/{note_id}/restore [post]
+//
+// ручка для восстановления заметки из корзины
+func (h *Handler) RestoreNote(c echo.Context) error {
+	spaceID, noteID, err := h.checkTrashNote(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.RestoreNoteRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		NoteID:    noteID,
+		Created:   time.Now().In(time.UTC).Unix(),
+		Operation: rabbit.RestoreOp,
+	}
+
+	if err := h.space.RestoreNote(c.Request().Context(), req); err != nil {
+		// внутренняя ошибка / ошибка валидации
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+//	@Summary		Восстановить все заметки из корзины
+//	@Param          space_id   path      string  true  "айди пространства"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Пространства не существует"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/trash/restore_all [post]
+//
+// ручка для восстановления всех заметок из корзины
+func (h *Handler) RestoreAllNotes(c echo.Context) error {
+	spaceID, err := h.checkTrashSpace(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.RestoreAllNotesRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		Created:   time.Now().In(time.UTC).Unix(),
+		Operation: rabbit.RestoreAllOp,
+	}
+
+	if err := h.space.RestoreAllNotes(c.Request().Context(), req); err != nil {
+		// внутренняя ошибка / ошибка валидации
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+//	@Summary		Окончательно удалить заметку из корзины
+//	@Param          space_id   path      string  true  "айди пространства"
+//	@Param          note_id   path      string  true  "айди заметки"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Пространства не существует / в пространстве нет такой заметки"
+//	@Failure		404	{object}	map[string]string "Заметка не найдена в корзине"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/trash/{note_id}/purge [delete]
+//
+// ручка для окончательного удаления заметки из корзины
+func (h *Handler) PurgeNote(c echo.Context) error {
+	spaceID, noteID, err := h.checkTrashNote(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.PurgeNoteRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		NoteID:    noteID,
+		Created:   time.Now().In(time.UTC).Unix(),
+		Operation: rabbit.PurgeOp,
+	}
+
+	if err := h.space.PurgeNote(c.Request().Context(), req); err != nil {
+		// внутренняя ошибка / ошибка валидации
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+//	@Summary		Очистить корзину
+//	@Param          space_id   path      string  true  "айди пространства"
+//	@Success		202 {object}    map[string]string "Айди запроса"
+//	@Failure		400	{object}	map[string]string "Пространства не существует"
+//	@Failure		500	{object}	map[string]string "Внутренняя ошибка"
+//	@Router			/api/v0/spaces/{space_id}/trash/purge_all [delete]
+//
+// ручка для очистки корзины
+func (h *Handler) PurgeAllNotes(c echo.Context) error {
+	spaceID, err := h.checkTrashSpace(c)
+	if err != nil {
+		return err
+	}
+
+	req := rabbit.PurgeAllNotesRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		Created:   time.Now().In(time.UTC).Unix(),
+		Operation: rabbit.PurgeAllOp,
+	}
+
+	if err := h.space.PurgeAllNotes(c.Request().Context(), req); err != nil {
+		// внутренняя ошибка / ошибка валидации
+		return api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return sendRequestID(c, req.ID)
+}
+
+// checkTrashSpace достает айди пространства из запроса и проверяет, что пространство существует
+func (h *Handler) checkTrashSpace(c echo.Context) (uuid.UUID, error) {
+	spaceID, err := getSpaceIDFromPath(c)
+	if err != nil {
+		return uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid space id parameter: %+v", err), err)
+	}
+
+	_, err = h.space.GetSpaceByID(c.Request().Context(), spaceID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrSpaceNotExists) {
+			return uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+		}
+
+		return uuid.Nil, api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	return spaceID, nil
+}
+
+// checkTrashNote достает айди пространства и заметки из запроса и проверяет,
+// что пространство существует, а заметка лежит в корзине этого пространства
+func (h *Handler) checkTrashNote(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	spaceID, err := getSpaceIDFromPath(c)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid space id parameter: %+v", err), err)
+	}
+
+	noteID, err := getNoteIDFromPath(c)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid note id parameter: %+v", err), err)
+	}
+
+	// проверяем, что пространство существует
+	_, err = h.space.GetSpaceByID(c.Request().Context(), spaceID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrSpaceNotExists) {
+			return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, err.Error(), err)
+		}
+
+		return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	// проверяем, что заметка лежит в корзине
+	note, err := h.space.GetTrashNoteByID(c.Request().Context(), noteID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrNoteNotFoundInTrash) {
+			return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusNotFound, err.Error(), err)
+		}
+
+		return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusInternalServerError, err.Error(), err)
+	}
+
+	// заметка не из этого пространства
+	if note.SpaceID != spaceID {
+		return uuid.Nil, uuid.Nil, api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil)
+	}
+
+	return spaceID, noteID, nil
+}
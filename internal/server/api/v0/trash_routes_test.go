@@ -0,0 +1,656 @@
+package v0
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/server/api/v0/mocks"
+
+	"bou.ke/monkey"
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrash(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name         string
+		spaceID      string
+		expectedCode int
+		expectedErr  *api_errors.HTTPError
+		expectedBody []model.TrashNote
+		setupMocks   func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	deleted := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	notes := []model.TrashNote{
+		{
+			GetNote: model.GetNote{
+				ID:      uuid.New(),
+				UserID:  1,
+				Text:    "deleted note",
+				SpaceID: spaceID,
+				Created: deleted.Add(-time.Hour),
+				Type:    model.TextNoteType,
+			},
+			Deleted:   deleted,
+			ExpiresAt: deleted.Add(30 * 24 * time.Hour),
+		},
+	}
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			expectedCode: http.StatusOK,
+			expectedBody: notes,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrash(gomock.Any(), spaceID).Return(notes, nil)
+			},
+		},
+		{
+			name:         "trash is empty",
+			spaceID:      spaceID.String(),
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrash(gomock.Any(), spaceID).Return(nil, api_errors.ErrNoNotesInTrash)
+			},
+		},
+		{
+			name:         "space does not exist",
+			spaceID:      spaceID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSpaceNotExists.Error(), api_errors.ErrSpaceNotExists),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/trash"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			resp := testRequest(t, ts, http.MethodGet, fmt.Sprintf(urlFmt, tt.spaceID), "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedErr != nil {
+				checkResult(t, resp, tt.expectedErr)
+			}
+
+			if tt.expectedBody != nil {
+				var actual []model.TrashNote
+				err := json.NewDecoder(resp.Body).Decode(&actual)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, actual)
+			}
+		})
+	}
+}
+
+func TestRestoreNote(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name            string
+		spaceID, noteID string
+		expectedCode    int
+		expectedErr     *api_errors.HTTPError
+		setupMocks      func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	noteID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{
+					GetNote: model.GetNote{ID: noteID, SpaceID: spaceID},
+				}, nil)
+				mocks.spaceSrv.EXPECT().RestoreNote(gomock.Any(), rabbit.RestoreNoteRequest{
+					ID:        requestID,
+					SpaceID:   spaceID,
+					NoteID:    noteID,
+					Created:   wayback.In(time.UTC).Unix(),
+					Operation: rabbit.RestoreOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid note ID",
+			spaceID:      spaceID.String(),
+			noteID:       "abc",
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid note id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "space does not exist",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSpaceNotExists.Error(), api_errors.ErrSpaceNotExists),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name:         "note not found in trash",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusNotFound, api_errors.ErrNoteNotFoundInTrash.Error(), api_errors.ErrNoteNotFoundInTrash),
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{}, api_errors.ErrNoteNotFoundInTrash)
+			},
+		},
+		{
+			name:         "note does not belong space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{
+					GetNote: model.GetNote{ID: noteID, SpaceID: uuid.Nil},
+				}, nil)
+			},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/trash/%s/restore"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			resp := testRequest(t, ts, http.MethodPost, fmt.Sprintf(urlFmt, tt.spaceID, tt.noteID), "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRestoreAllNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name         string
+		spaceID      string
+		expectedCode int
+		expectedErr  *api_errors.HTTPError
+		setupMocks   func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().RestoreAllNotes(gomock.Any(), rabbit.RestoreAllNotesRequest{
+					ID:        requestID,
+					SpaceID:   spaceID,
+					Created:   wayback.In(time.UTC).Unix(),
+					Operation: rabbit.RestoreAllOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "space does not exist",
+			spaceID:      spaceID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSpaceNotExists.Error(), api_errors.ErrSpaceNotExists),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/trash/restore_all"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			resp := testRequest(t, ts, http.MethodPost, fmt.Sprintf(urlFmt, tt.spaceID), "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPurgeNote(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name            string
+		spaceID, noteID string
+		expectedCode    int
+		expectedErr     *api_errors.HTTPError
+		setupMocks      func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	noteID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{
+					GetNote: model.GetNote{ID: noteID, SpaceID: spaceID},
+				}, nil)
+				mocks.spaceSrv.EXPECT().PurgeNote(gomock.Any(), rabbit.PurgeNoteRequest{
+					ID:        requestID,
+					SpaceID:   spaceID,
+					NoteID:    noteID,
+					Created:   wayback.In(time.UTC).Unix(),
+					Operation: rabbit.PurgeOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "invalid note ID",
+			spaceID:      spaceID.String(),
+			noteID:       "abc",
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid note id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+		{
+			name:         "space does not exist",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSpaceNotExists.Error(), api_errors.ErrSpaceNotExists),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name:         "note not found in trash",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusNotFound, api_errors.ErrNoteNotFoundInTrash.Error(), api_errors.ErrNoteNotFoundInTrash),
+			expectedCode: http.StatusNotFound,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{}, api_errors.ErrNoteNotFoundInTrash)
+			},
+		},
+		{
+			name:         "note does not belong space",
+			spaceID:      spaceID.String(),
+			noteID:       noteID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrNoteNotBelongsSpace.Error(), nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().GetTrashNoteByID(gomock.Any(), noteID).Return(model.TrashNote{
+					GetNote: model.GetNote{ID: noteID, SpaceID: uuid.Nil},
+				}, nil)
+			},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/trash/%s/purge"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			resp := testRequest(t, ts, http.MethodDelete, fmt.Sprintf(urlFmt, tt.spaceID, tt.noteID), "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPurgeAllNotes(t *testing.T) {
+	type fields struct {
+		spaceSrv *mocks.MockspaceService
+		userSrv  *mocks.MockuserService
+		authSrv  *mocks.MockauthService
+	}
+
+	type test struct {
+		name         string
+		spaceID      string
+		expectedCode int
+		expectedErr  *api_errors.HTTPError
+		setupMocks   func(mocks *fields)
+	}
+
+	spaceID := uuid.New()
+	requestID := uuid.New()
+
+	wayback := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time { return wayback })
+	defer timePatch.Unpatch()
+
+	uuidPatch := monkey.Patch(uuid.New, func() uuid.UUID { return requestID })
+	defer uuidPatch.Unpatch()
+
+	tests := []test{
+		{
+			name:         "positive case",
+			spaceID:      spaceID.String(),
+			expectedCode: http.StatusAccepted,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{ID: spaceID}, nil)
+				mocks.spaceSrv.EXPECT().PurgeAllNotes(gomock.Any(), rabbit.PurgeAllNotesRequest{
+					ID:        requestID,
+					SpaceID:   spaceID,
+					Created:   wayback.In(time.UTC).Unix(),
+					Operation: rabbit.PurgeAllOp,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "space does not exist",
+			spaceID:      spaceID.String(),
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, api_errors.ErrSpaceNotExists.Error(), api_errors.ErrSpaceNotExists),
+			expectedCode: http.StatusBadRequest,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.spaceSrv.EXPECT().GetSpaceByID(gomock.Any(), spaceID).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name:         "invalid space ID",
+			spaceID:      "abc",
+			expectedErr:  api_errors.NewHTTPError(http.StatusBadRequest, "invalid space id parameter: invalid UUID length: 3", nil),
+			expectedCode: http.StatusBadRequest,
+			setupMocks:   func(mocks *fields) {},
+		},
+	}
+
+	urlFmt := "/api/v0/spaces/%s/trash/purge_all"
+
+	logger, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	handlerLogger := logger.WithService("handler")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			spaceSrv, userSrv, authSrv := createMockServices(t, ctrl)
+
+			handler, err := New(WithSpaceService(spaceSrv), WithUserService(userSrv), WithAuthService(authSrv), WithLogger(handlerLogger))
+			require.NoError(t, err)
+
+			r, err := runTestServer(t, handler)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			tt.setupMocks(&fields{
+				spaceSrv: spaceSrv,
+				userSrv:  userSrv,
+				authSrv:  authSrv,
+			})
+
+			resp := testRequest(t, ts, http.MethodDelete, fmt.Sprintf(urlFmt, tt.spaceID), "", nil)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusAccepted {
+				checkRequestID(t, resp)
+			} else {
+				checkResult(t, resp, tt.expectedErr)
+			}
+		})
+	}
+}
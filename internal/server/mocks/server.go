@@ -62,6 +62,34 @@ func (mr *MockhandlerMockRecorder) Auth(next interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*Mockhandler)(nil).Auth), next)
 }
 
+// BatchNotes mocks base method.
+func (m *Mockhandler) BatchNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MockhandlerMockRecorder) BatchNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*Mockhandler)(nil).BatchNotes), c)
+}
+
+// CopyNote mocks base method.
+func (m *Mockhandler) CopyNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyNote indicates an expected call of CopyNote.
+func (mr *MockhandlerMockRecorder) CopyNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyNote", reflect.TypeOf((*Mockhandler)(nil).CopyNote), c)
+}
+
 // CreateNote mocks base method.
 func (m *Mockhandler) CreateNote(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -146,6 +174,20 @@ func (mr *MockhandlerMockRecorder) GetNotesByType(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByType", reflect.TypeOf((*Mockhandler)(nil).GetNotesByType), c)
 }
 
+// GetTrash mocks base method.
+func (m *Mockhandler) GetTrash(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockhandlerMockRecorder) GetTrash(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*Mockhandler)(nil).GetTrash), c)
+}
+
 // Health mocks base method.
 func (m *Mockhandler) Health(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -160,6 +202,20 @@ func (mr *MockhandlerMockRecorder) Health(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*Mockhandler)(nil).Health), c)
 }
 
+// MoveNote mocks base method.
+func (m *Mockhandler) MoveNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockhandlerMockRecorder) MoveNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*Mockhandler)(nil).MoveNote), c)
+}
+
 // NotesBySpaceID mocks base method.
 func (m *Mockhandler) NotesBySpaceID(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -174,6 +230,76 @@ func (mr *MockhandlerMockRecorder) NotesBySpaceID(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotesBySpaceID", reflect.TypeOf((*Mockhandler)(nil).NotesBySpaceID), c)
 }
 
+// PurgeAllNotes mocks base method.
+func (m *Mockhandler) PurgeAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MockhandlerMockRecorder) PurgeAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*Mockhandler)(nil).PurgeAllNotes), c)
+}
+
+// PurgeNote mocks base method.
+func (m *Mockhandler) PurgeNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MockhandlerMockRecorder) PurgeNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*Mockhandler)(nil).PurgeNote), c)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *Mockhandler) RestoreAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MockhandlerMockRecorder) RestoreAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*Mockhandler)(nil).RestoreAllNotes), c)
+}
+
+// RestoreNote mocks base method.
+func (m *Mockhandler) RestoreNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MockhandlerMockRecorder) RestoreNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*Mockhandler)(nil).RestoreNote), c)
+}
+
+// SearchAllNotes mocks base method.
+func (m *Mockhandler) SearchAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MockhandlerMockRecorder) SearchAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*Mockhandler)(nil).SearchAllNotes), c)
+}
+
 // SearchNoteByText mocks base method.
 func (m *Mockhandler) SearchNoteByText(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -188,6 +314,20 @@ func (mr *MockhandlerMockRecorder) SearchNoteByText(c interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*Mockhandler)(nil).SearchNoteByText), c)
 }
 
+// SuggestNotes mocks base method.
+func (m *Mockhandler) SuggestNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MockhandlerMockRecorder) SuggestNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*Mockhandler)(nil).SuggestNotes), c)
+}
+
 // UpdateNote mocks base method.
 func (m *Mockhandler) UpdateNote(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -304,6 +444,34 @@ func (m *MocknoteHandler) EXPECT() *MocknoteHandlerMockRecorder {
 	return m.recorder
 }
 
+// BatchNotes mocks base method.
+func (m *MocknoteHandler) BatchNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MocknoteHandlerMockRecorder) BatchNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MocknoteHandler)(nil).BatchNotes), c)
+}
+
+// CopyNote mocks base method.
+func (m *MocknoteHandler) CopyNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyNote indicates an expected call of CopyNote.
+func (mr *MocknoteHandlerMockRecorder) CopyNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyNote", reflect.TypeOf((*MocknoteHandler)(nil).CopyNote), c)
+}
+
 // CreateNote mocks base method.
 func (m *MocknoteHandler) CreateNote(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -374,6 +542,20 @@ func (mr *MocknoteHandlerMockRecorder) GetNotesByType(c interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByType", reflect.TypeOf((*MocknoteHandler)(nil).GetNotesByType), c)
 }
 
+// MoveNote mocks base method.
+func (m *MocknoteHandler) MoveNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MocknoteHandlerMockRecorder) MoveNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MocknoteHandler)(nil).MoveNote), c)
+}
+
 // NotesBySpaceID mocks base method.
 func (m *MocknoteHandler) NotesBySpaceID(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -388,6 +570,20 @@ func (mr *MocknoteHandlerMockRecorder) NotesBySpaceID(c interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotesBySpaceID", reflect.TypeOf((*MocknoteHandler)(nil).NotesBySpaceID), c)
 }
 
+// SearchAllNotes mocks base method.
+func (m *MocknoteHandler) SearchAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MocknoteHandlerMockRecorder) SearchAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*MocknoteHandler)(nil).SearchAllNotes), c)
+}
+
 // SearchNoteByText mocks base method.
 func (m *MocknoteHandler) SearchNoteByText(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -402,6 +598,20 @@ func (mr *MocknoteHandlerMockRecorder) SearchNoteByText(c interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*MocknoteHandler)(nil).SearchNoteByText), c)
 }
 
+// SuggestNotes mocks base method.
+func (m *MocknoteHandler) SuggestNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MocknoteHandlerMockRecorder) SuggestNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*MocknoteHandler)(nil).SuggestNotes), c)
+}
+
 // UpdateNote mocks base method.
 func (m *MocknoteHandler) UpdateNote(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -416,6 +626,99 @@ func (mr *MocknoteHandlerMockRecorder) UpdateNote(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MocknoteHandler)(nil).UpdateNote), c)
 }
 
+// MocktrashHandler is a mock of trashHandler interface.
+type MocktrashHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrashHandlerMockRecorder
+}
+
+// MocktrashHandlerMockRecorder is the mock recorder for MocktrashHandler.
+type MocktrashHandlerMockRecorder struct {
+	mock *MocktrashHandler
+}
+
+// NewMocktrashHandler creates a new mock instance.
+func NewMocktrashHandler(ctrl *gomock.Controller) *MocktrashHandler {
+	mock := &MocktrashHandler{ctrl: ctrl}
+	mock.recorder = &MocktrashHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrashHandler) EXPECT() *MocktrashHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetTrash mocks base method.
+func (m *MocktrashHandler) GetTrash(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MocktrashHandlerMockRecorder) GetTrash(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MocktrashHandler)(nil).GetTrash), c)
+}
+
+// PurgeAllNotes mocks base method.
+func (m *MocktrashHandler) PurgeAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MocktrashHandlerMockRecorder) PurgeAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*MocktrashHandler)(nil).PurgeAllNotes), c)
+}
+
+// PurgeNote mocks base method.
+func (m *MocktrashHandler) PurgeNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MocktrashHandlerMockRecorder) PurgeNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MocktrashHandler)(nil).PurgeNote), c)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *MocktrashHandler) RestoreAllNotes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MocktrashHandlerMockRecorder) RestoreAllNotes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*MocktrashHandler)(nil).RestoreAllNotes), c)
+}
+
+// RestoreNote mocks base method.
+func (m *MocktrashHandler) RestoreNote(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MocktrashHandlerMockRecorder) RestoreNote(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MocktrashHandler)(nil).RestoreNote), c)
+}
+
 // MockhealthHandler is a mock of healthHandler interface.
 type MockhealthHandler struct {
 	ctrl     *gomock.Controller
@@ -73,6 +73,7 @@ func New(opts ...ServerOption) (*Server, error) {
 type handler interface {
 	spaceHandler
 	noteHandler
+	trashHandler
 	middlewareHandler
 	healthHandler
 }
@@ -89,8 +90,21 @@ type noteHandler interface {
 	GetNoteTypes(c echo.Context) error
 	GetNotesByType(c echo.Context) error
 	SearchNoteByText(c echo.Context) error
+	SearchAllNotes(c echo.Context) error
+	SuggestNotes(c echo.Context) error
 	DeleteNote(c echo.Context) error
 	DeleteAllNotes(c echo.Context) error
+	BatchNotes(c echo.Context) error
+	MoveNote(c echo.Context) error
+	CopyNote(c echo.Context) error
+}
+
+type trashHandler interface {
+	GetTrash(c echo.Context) error
+	RestoreNote(c echo.Context) error
+	RestoreAllNotes(c echo.Context) error
+	PurgeNote(c echo.Context) error
+	PurgeAllNotes(c echo.Context) error
 }
 
 type healthHandler interface {
@@ -152,14 +166,28 @@ func (s *Server) CreateRoutes() error {
 	spaces.POST("/notes/create", s.api.h0.CreateNote, s.api.h0.ValidateNoteRequest, s.api.h0.WrapNetHTTP)
 	spaces.PATCH("/notes/update", s.api.h0.UpdateNote, s.api.h0.ValidateNoteRequest, s.api.h0.WrapNetHTTP)
 	spaces.DELETE("/:space_id/notes/:note_id/delete", s.api.h0.DeleteNote, s.api.h0.WrapNetHTTP)
-	spaces.DELETE("/:space_id/notes/delete_all", s.api.h0.DeleteAllNotes, s.api.h0.WrapNetHTTP) // удалить все заметки
+	spaces.DELETE("/:space_id/notes/delete_all", s.api.h0.DeleteAllNotes, s.api.h0.WrapNetHTTP)                    // удалить все заметки
+	spaces.POST("/:space_id/notes/batch", s.api.h0.BatchNotes, s.api.h0.ValidateNoteRequest, s.api.h0.WrapNetHTTP) // пакетные операции над заметками
+
+	// ============================================================= перенос и копирование =============================================================
+	spaces.POST("/:space_id/notes/:note_id/move", s.api.h0.MoveNote, s.api.h0.WrapNetHTTP) // перенести заметку в другое пространство
+	spaces.POST("/:space_id/notes/:note_id/copy", s.api.h0.CopyNote, s.api.h0.WrapNetHTTP) // скопировать заметку в другое пространство
 
 	// ============================================================= типы заметок =============================================================
 	spaces.GET("/:space_id/notes/types", s.api.h0.GetNoteTypes, s.api.h0.WrapNetHTTP)   // получить, какие есть типы заметок
 	spaces.GET("/:space_id/notes/:type", s.api.h0.GetNotesByType, s.api.h0.WrapNetHTTP) // получить все заметки одного типа
 
+	// ============================================================= корзина =============================================================
+	spaces.GET("/:space_id/trash", s.api.h0.GetTrash, s.api.h0.WrapNetHTTP)                      // получить заметки из корзины
+	spaces.POST("/:space_id/trash/restore_all", s.api.h0.RestoreAllNotes, s.api.h0.WrapNetHTTP)  // восстановить все заметки из корзины
+	spaces.POST("/:space_id/trash/:note_id/restore", s.api.h0.RestoreNote, s.api.h0.WrapNetHTTP) // восстановить заметку из корзины
+	spaces.DELETE("/:space_id/trash/purge_all", s.api.h0.PurgeAllNotes, s.api.h0.WrapNetHTTP)    // очистить корзину
+	spaces.DELETE("/:space_id/trash/:note_id/purge", s.api.h0.PurgeNote, s.api.h0.WrapNetHTTP)   // окончательно удалить заметку
+
 	// ============================================================= поиск =============================================================
-	spaces.POST("/notes/search/text", s.api.h0.SearchNoteByText, s.api.h0.WrapNetHTTP) // по тексту
+	spaces.POST("/notes/search/text", s.api.h0.SearchNoteByText, s.api.h0.WrapNetHTTP)             // по тексту
+	spaces.POST("/notes/search/all", s.api.h0.SearchAllNotes, s.api.h0.Auth, s.api.h0.WrapNetHTTP) // по тексту во всех пространствах пользователя
+	spaces.GET("/:space_id/notes/suggest", s.api.h0.SuggestNotes, s.api.h0.WrapNetHTTP)            // подсказки при вводе
 
 	s.e = e
 
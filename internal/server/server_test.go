@@ -151,6 +151,21 @@ func TestCreateRoutes(t *testing.T) {
 			Path:   "/api/v0/spaces/:space_id/notes/delete_all",
 			Name:   "webserver/internal/server.handler.DeleteAllNotes-fm",
 		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/:space_id/notes/batch",
+			Name:   "webserver/internal/server.handler.BatchNotes-fm",
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/:space_id/notes/:note_id/move",
+			Name:   "webserver/internal/server.handler.MoveNote-fm",
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/:space_id/notes/:note_id/copy",
+			Name:   "webserver/internal/server.handler.CopyNote-fm",
+		},
 		{
 			Method: http.MethodGet,
 			Path:   "/api/v0/spaces/:space_id/notes/types",
@@ -166,6 +181,41 @@ func TestCreateRoutes(t *testing.T) {
 			Path:   "/api/v0/spaces/notes/search/text",
 			Name:   "webserver/internal/server.handler.SearchNoteByText-fm",
 		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/notes/search/all",
+			Name:   "webserver/internal/server.handler.SearchAllNotes-fm",
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/v0/spaces/:space_id/notes/suggest",
+			Name:   "webserver/internal/server.handler.SuggestNotes-fm",
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/v0/spaces/:space_id/trash",
+			Name:   "webserver/internal/server.handler.GetTrash-fm",
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/:space_id/trash/restore_all",
+			Name:   "webserver/internal/server.handler.RestoreAllNotes-fm",
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/v0/spaces/:space_id/trash/:note_id/restore",
+			Name:   "webserver/internal/server.handler.RestoreNote-fm",
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/v0/spaces/:space_id/trash/purge_all",
+			Name:   "webserver/internal/server.handler.PurgeAllNotes-fm",
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/v0/spaces/:space_id/trash/:note_id/purge",
+			Name:   "webserver/internal/server.handler.PurgeNote-fm",
+		},
 		{
 			Method: http.MethodPost,
 			Path:   "/api/v0/spaces/create",
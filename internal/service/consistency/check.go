@@ -0,0 +1,240 @@
+package consistency
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+
+	"github.com/google/uuid"
+)
+
+// Check сверяет все заметки из базы с документами в индексе эластика. Обе стороны читаются пачками в порядке ID
+// и сравниваются слиянием, поэтому в памяти держится только одна пачка с каждой стороны и найденные расхождения.
+// Если repair = true, документы для расхождений переиндексируются из базы, а лишние документы удаляются.
+// Заметки, измененные во время проверки, могут попасть в отчет, если воркер еще не обновил индекс
+func (s *Service) Check(ctx context.Context, repair bool) (*model.ConsistencyReport, error) {
+	c := &check{
+		report: model.NewConsistencyReport(s.now()),
+		db:     &dbCursor{repo: s.repo, limit: s.batchSize},
+	}
+
+	s.logger.Info("checking consistency of notes between db and elastic")
+
+	err := s.elastic.ScanNotes(ctx, s.batchSize, func(docs []elastic.Note) error {
+		for _, doc := range docs {
+			if err := c.compare(ctx, doc); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error comparing notes with elastic: %w", err)
+	}
+
+	// все, что осталось в базе после последнего документа, в эластик не попало
+	for {
+		note, ok, err := c.db.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		c.missing(note)
+		c.db.next()
+	}
+
+	c.report.DBNotes = c.db.read
+
+	if repair {
+		if err := s.repair(ctx, c); err != nil {
+			return nil, err
+		}
+
+		c.report.Repaired = true
+	}
+
+	c.report.Finish(s.now())
+
+	observe(c.report)
+
+	s.logger.Infof("consistency check finished: %d notes in db, %d documents in elastic, %d drifted",
+		c.report.DBNotes, c.report.IndexedNotes, c.report.TotalDrift())
+
+	return c.report, nil
+}
+
+// repair удаляет лишние документы и переиндексирует заметки с расхождениями. Сначала удаление:
+// документ заметки может лежать под случайным id, а после переиндексации он будет лежать под id заметки.
+// Проверка идет долго, поэтому перед переиндексацией заметки перечитываются из основной базы:
+// заметки, удаленные за это время, в индекс не возвращаются, а измененные индексируются в текущем виде
+func (s *Service) repair(ctx context.Context, c *check) error {
+	index := elastic.NoteIndex.String()
+
+	for batch := range slices.Chunk(c.toDelete, s.batchSize) {
+		if err := s.elastic.BulkDelete(ctx, index, batch); err != nil {
+			return fmt.Errorf("error deleting stale documents: %w", err)
+		}
+	}
+
+	var reindexed int
+
+	for batch := range slices.Chunk(c.toIndex, s.batchSize) {
+		ids := make([]uuid.UUID, len(batch))
+		for i, note := range batch {
+			ids[i] = note.ID
+		}
+
+		notes, err := s.repo.GetNotesForIndexByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("error getting drifted notes from db: %w", err)
+		}
+
+		if len(notes) == 0 {
+			continue
+		}
+
+		if err := s.elastic.BulkIndex(ctx, index, notes); err != nil {
+			return fmt.Errorf("error reindexing drifted notes: %w", err)
+		}
+
+		reindexed += len(notes)
+	}
+
+	s.logger.Infof("consistency repaired: %d documents deleted, %d notes reindexed, %d notes deleted during check",
+		len(c.toDelete), reindexed, len(c.toIndex)-reindexed)
+
+	return nil
+}
+
+// check - состояние одной проверки
+type check struct {
+	report *model.ConsistencyReport
+	db     *dbCursor
+
+	lastMatched uuid.UUID // ID последней заметки, для которой нашелся документ: следующие документы с тем же ID - дубликаты
+
+	toIndex  []elastic.Note // заметки из базы, которые нужно переиндексировать
+	toDelete []string       // id в эластике документов, которые нужно удалить
+}
+
+// compare сравнивает очередной документ из эластика с заметками из базы.
+// Заметки из базы с меньшим ID, чем у документа, в эластик не попали
+func (c *check) compare(ctx context.Context, doc elastic.Note) error {
+	c.report.IndexedNotes++
+
+	for {
+		note, ok, err := c.db.peek(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !ok || compareIDs(note.ID, doc.ID) > 0 {
+			break
+		}
+
+		c.db.next()
+
+		if note.ID != doc.ID {
+			c.missing(note)
+			continue
+		}
+
+		c.lastMatched = note.ID
+		c.matched(note, doc)
+
+		return nil
+	}
+
+	if doc.ID == c.lastMatched {
+		c.report.Add(model.DriftDuplicate, doc.SpaceID, doc.ID)
+	} else {
+		c.report.Add(model.DriftStaleInIndex, doc.SpaceID, doc.ID)
+	}
+
+	c.toDelete = append(c.toDelete, doc.ElasticID)
+
+	return nil
+}
+
+// matched сравнивает заметку с ее документом в эластике
+func (c *check) matched(note, doc elastic.Note) {
+	switch {
+	case note.SpaceID != doc.SpaceID:
+		c.report.Add(model.DriftSpaceMismatch, note.SpaceID, note.ID)
+	case note.Text != doc.Text:
+		c.report.Add(model.DriftTextMismatch, note.SpaceID, note.ID)
+	case note.Deleted != doc.Deleted:
+		c.report.Add(model.DriftDeletedMismatch, note.SpaceID, note.ID)
+	default:
+		return
+	}
+
+	// переиндексированный документ будет лежать под id заметки, старый под другим id нужно удалить
+	if doc.ElasticID != note.ID.String() {
+		c.toDelete = append(c.toDelete, doc.ElasticID)
+	}
+
+	c.toIndex = append(c.toIndex, note)
+}
+
+// missing отмечает заметку, для которой нет документа в эластике
+func (c *check) missing(note elastic.Note) {
+	c.report.Add(model.DriftMissingInIndex, note.SpaceID, note.ID)
+	c.toIndex = append(c.toIndex, note)
+}
+
+// dbCursor читает заметки из базы пачками в порядке ID
+type dbCursor struct {
+	repo  noteRepo
+	limit int
+
+	buf  []elastic.Note
+	last uuid.UUID // ID последней прочитанной из базы заметки
+	done bool
+	read int // сколько заметок прочитано
+}
+
+// peek возвращает следующую заметку, не продвигая курсор. Если заметки закончились, возвращает false
+func (c *dbCursor) peek(ctx context.Context) (elastic.Note, bool, error) {
+	if len(c.buf) == 0 && !c.done {
+		notes, err := c.repo.GetNotesForIndex(ctx, c.last, c.limit)
+		if err != nil {
+			return elastic.Note{}, false, fmt.Errorf("error getting notes from db: %w", err)
+		}
+
+		if len(notes) < c.limit {
+			c.done = true
+		}
+
+		if len(notes) > 0 {
+			c.last = notes[len(notes)-1].ID
+		}
+
+		c.buf = notes
+		c.read += len(notes)
+	}
+
+	if len(c.buf) == 0 {
+		return elastic.Note{}, false, nil
+	}
+
+	return c.buf[0], true, nil
+}
+
+// next продвигает курсор на следующую заметку
+func (c *dbCursor) next() {
+	c.buf = c.buf[1:]
+}
+
+// compareIDs сравнивает id так же, как их сортируют база (uuid) и эластик (keyword)
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
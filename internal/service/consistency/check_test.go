@@ -0,0 +1,304 @@
+package consistency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/consistency/mocks"
+
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	spaceA := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	spaceB := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+
+	id := func(n int) uuid.UUID {
+		return uuid.MustParse("00000000-0000-0000-0000-00000000000" + string(rune('0'+n)))
+	}
+
+	// в базе: 1, 2, 3, 4, 6
+	dbNotes := []elastic.Note{
+		{ID: id(1), SpaceID: spaceA, Text: "one"},
+		{ID: id(2), SpaceID: spaceA, Text: "two"},
+		{ID: id(3), SpaceID: spaceA, Text: "three"},
+		{ID: id(4), SpaceID: spaceA, Text: "four"},
+		{ID: id(6), SpaceID: spaceA, Text: "six"},
+	}
+
+	// в эластике: 1 совпадает, у 2 другой текст и случайный id, 3 в другом пространстве и с дубликатом,
+	// 4 нет, 5 нет в базе, 6 совпадает
+	docs := [][]elastic.Note{
+		{
+			{ID: id(1), ElasticID: id(1).String(), SpaceID: spaceA, Text: "one"},
+			{ID: id(2), ElasticID: "random", SpaceID: spaceA, Text: "old two"},
+		},
+		{
+			{ID: id(3), ElasticID: id(3).String(), SpaceID: spaceB, Text: "three"},
+			{ID: id(3), ElasticID: "duplicate", SpaceID: spaceB, Text: "three"},
+		},
+		{
+			{ID: id(5), ElasticID: "stale", SpaceID: spaceA, Text: "five"},
+			{ID: id(6), ElasticID: id(6).String(), SpaceID: spaceA, Text: "six"},
+		},
+	}
+
+	setupDB := func(repo *mocks.MocknoteRepo) {
+		gomock.InOrder(
+			repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(dbNotes[:2], nil),
+			repo.EXPECT().GetNotesForIndex(gomock.Any(), id(2), 2).Return(dbNotes[2:4], nil),
+			repo.EXPECT().GetNotesForIndex(gomock.Any(), id(4), 2).Return(dbNotes[4:], nil),
+		)
+	}
+
+	scan := func(pages [][]elastic.Note) func(context.Context, int, func([]elastic.Note) error) error {
+		return func(_ context.Context, _ int, fn func([]elastic.Note) error) error {
+			for _, page := range pages {
+				if err := fn(page); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	wantSpaces := []model.SpaceConsistency{
+		{
+			SpaceID: spaceA,
+			Notes: map[model.DriftKind][]uuid.UUID{
+				model.DriftTextMismatch:   {id(2)},
+				model.DriftSpaceMismatch:  {id(3)},
+				model.DriftMissingInIndex: {id(4)},
+				model.DriftStaleInIndex:   {id(5)},
+			},
+		},
+		{
+			SpaceID: spaceB,
+			Notes: map[model.DriftKind][]uuid.UUID{
+				model.DriftDuplicate: {id(3)},
+			},
+		},
+	}
+
+	wantDrift := map[model.DriftKind]int{
+		model.DriftMissingInIndex:  1,
+		model.DriftStaleInIndex:    1,
+		model.DriftDuplicate:       1,
+		model.DriftTextMismatch:    1,
+		model.DriftSpaceMismatch:   1,
+		model.DriftDeletedMismatch: 0,
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, report.DBNotes)
+		assert.Equal(t, 6, report.IndexedNotes)
+		assert.Equal(t, wantDrift, report.Drift)
+		assert.Equal(t, wantSpaces, report.Spaces)
+		assert.False(t, report.Repaired)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+
+		gomock.InOrder(
+			es.EXPECT().BulkDelete(gomock.Any(), "notes", []string{"random", "duplicate"}).Return(nil),
+			es.EXPECT().BulkDelete(gomock.Any(), "notes", []string{"stale"}).Return(nil),
+			repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{id(2), id(3)}).Return(dbNotes[1:3], nil),
+			es.EXPECT().BulkIndex(gomock.Any(), "notes", []elastic.Note{dbNotes[1], dbNotes[2]}).Return(nil),
+			repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{id(4)}).Return(dbNotes[3:4], nil),
+			es.EXPECT().BulkIndex(gomock.Any(), "notes", []elastic.Note{dbNotes[3]}).Return(nil),
+		)
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), true)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantDrift, report.Drift)
+		assert.True(t, report.Repaired)
+	})
+
+	t.Run("repair: notes changed during check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+
+		// за время проверки 2 изменили, 3 и 4 удалили: в индекс попадает только текущая версия 2
+		edited := elastic.Note{ID: id(2), SpaceID: spaceA, Text: "new two"}
+
+		gomock.InOrder(
+			es.EXPECT().BulkDelete(gomock.Any(), "notes", []string{"random", "duplicate"}).Return(nil),
+			es.EXPECT().BulkDelete(gomock.Any(), "notes", []string{"stale"}).Return(nil),
+			repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{id(2), id(3)}).Return([]elastic.Note{edited}, nil),
+			es.EXPECT().BulkIndex(gomock.Any(), "notes", []elastic.Note{edited}).Return(nil),
+			repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{id(4)}).Return(nil, nil),
+		)
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), true)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantDrift, report.Drift)
+		assert.True(t, report.Repaired)
+	})
+
+	t.Run("empty index: all notes missing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(nil))
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, report.Drift[model.DriftMissingInIndex])
+		assert.Equal(t, 5, report.TotalDrift())
+		assert.Zero(t, report.IndexedNotes)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		dbErr := errors.New("connection refused")
+
+		repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(nil, dbErr)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), true)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, report)
+	})
+
+	t.Run("repair error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		esErr := errors.New("cluster unavailable")
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+		es.EXPECT().BulkDelete(gomock.Any(), "notes", gomock.Any()).Return(esErr)
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), true)
+		assert.ErrorIs(t, err, esErr)
+		assert.Nil(t, report)
+	})
+
+	t.Run("repair: db error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+		dbErr := errors.New("connection refused")
+
+		setupDB(repo)
+		es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(scan(docs))
+		es.EXPECT().BulkDelete(gomock.Any(), "notes", gomock.Any()).Return(nil).Times(2)
+		repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{id(2), id(3)}).Return(nil, dbErr)
+
+		report, err := createTestService(t, repo, es).Check(context.Background(), true)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, report)
+	})
+}
+
+func TestCheck_DeletedMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, es := mocks.NewMocknoteRepo(ctrl), mocks.NewMockindex(ctrl)
+
+	spaceID := uuid.New()
+
+	// 1 в корзине, но в эластике не помечена удаленной; 2 восстановлена, но в эластике осталась удаленной
+	dbNotes := []elastic.Note{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), SpaceID: spaceID, Text: "trashed", Deleted: true},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), SpaceID: spaceID, Text: "restored"},
+	}
+
+	docs := []elastic.Note{
+		{ID: dbNotes[0].ID, ElasticID: dbNotes[0].ID.String(), SpaceID: spaceID, Text: "trashed"},
+		{ID: dbNotes[1].ID, ElasticID: dbNotes[1].ID.String(), SpaceID: spaceID, Text: "restored", Deleted: true},
+	}
+
+	gomock.InOrder(
+		repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(dbNotes, nil),
+		repo.EXPECT().GetNotesForIndex(gomock.Any(), dbNotes[1].ID, 2).Return(nil, nil),
+	)
+
+	es.EXPECT().ScanNotes(gomock.Any(), 2, gomock.Any()).DoAndReturn(func(_ context.Context, _ int, fn func([]elastic.Note) error) error {
+		return fn(docs)
+	})
+
+	gomock.InOrder(
+		repo.EXPECT().GetNotesForIndexByIDs(gomock.Any(), []uuid.UUID{dbNotes[0].ID, dbNotes[1].ID}).Return(dbNotes, nil),
+		es.EXPECT().BulkIndex(gomock.Any(), "notes", dbNotes).Return(nil),
+	)
+
+	report, err := createTestService(t, repo, es).Check(context.Background(), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Drift[model.DriftDeletedMismatch])
+	assert.Equal(t, 2, report.TotalDrift())
+	assert.Equal(t, []model.SpaceConsistency{{
+		SpaceID: spaceID,
+		Notes: map[model.DriftKind][]uuid.UUID{
+			model.DriftDeletedMismatch: {dbNotes[0].ID, dbNotes[1].ID},
+		},
+	}}, report.Spaces)
+}
+
+func createTestService(t *testing.T, repo noteRepo, es index) *Service {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	srv, err := New(
+		WithRepo(repo),
+		WithElastic(es),
+		WithLogger(log.WithService("consistency")),
+		WithBatchSize(2),
+	)
+	require.NoError(t, err)
+
+	srv.now = func() time.Time { return time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC) }
+
+	return srv
+}
@@ -0,0 +1,48 @@
+package consistency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Run проверяет согласованность раз в interval, пока не отменен ctx. Первая проверка - через interval после запуска,
+// чтобы не нагружать базу и эластик при каждом деплое. Ошибки проверки логируются, следующая проверка идет по расписанию.
+// Run только сообщает о расхождениях: сервер запущен в нескольких экземплярах, и исправлять индекс одновременно
+// из каждого нельзя. Расхождения исправляет команда consistency -repair
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.logger.Infof("consistency check scheduled every %s", interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("consistency check stopped")
+			return
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+func (s *Service) runOnce(ctx context.Context) {
+	report, err := s.Check(ctx, false)
+	if err != nil {
+		s.logger.Errorf("error checking consistency: %+v", err)
+		return
+	}
+
+	if report.TotalDrift() == 0 {
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		s.logger.Errorf("error marshalling consistency report: %+v", err)
+		return
+	}
+
+	s.logger.Warnf("notes in db and elastic are inconsistent: %s", data)
+}
@@ -0,0 +1,44 @@
+package consistency
+
+import (
+	"webserver/internal/model"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// метрики последней проверки отдаются сервером на /metrics вместе с метриками http
+var (
+	driftGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "webserver",
+		Subsystem: "notes_consistency",
+		Name:      "drift",
+		Help:      "Количество расхождений между заметками в базе и в эластике при последней проверке, по видам расхождений",
+	}, []string{"kind"})
+
+	checkedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "webserver",
+		Subsystem: "notes_consistency",
+		Name:      "checked_notes",
+		Help:      "Количество заметок, проверенных при последней проверке: в базе (db) и в эластике (index)",
+	}, []string{"source"})
+
+	lastCheckGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "webserver",
+		Subsystem: "notes_consistency",
+		Name:      "last_check_timestamp_seconds",
+		Help:      "Время окончания последней проверки в unix",
+	})
+)
+
+// observe обновляет метрики по отчету о проверке
+func observe(report *model.ConsistencyReport) {
+	for kind, count := range report.Drift {
+		driftGauge.WithLabelValues(string(kind)).Set(float64(count))
+	}
+
+	checkedGauge.WithLabelValues("db").Set(float64(report.DBNotes))
+	checkedGauge.WithLabelValues("index").Set(float64(report.IndexedNotes))
+
+	lastCheckGauge.Set(float64(report.FinishedAt.Unix()))
+}
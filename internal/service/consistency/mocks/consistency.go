@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	elastic "webserver/internal/model/elastic"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MocknoteRepo is a mock of noteRepo interface.
+type MocknoteRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocknoteRepoMockRecorder
+}
+
+// MocknoteRepoMockRecorder is the mock recorder for MocknoteRepo.
+type MocknoteRepoMockRecorder struct {
+	mock *MocknoteRepo
+}
+
+// NewMocknoteRepo creates a new mock instance.
+func NewMocknoteRepo(ctrl *gomock.Controller) *MocknoteRepo {
+	mock := &MocknoteRepo{ctrl: ctrl}
+	mock.recorder = &MocknoteRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknoteRepo) EXPECT() *MocknoteRepoMockRecorder {
+	return m.recorder
+}
+
+// GetNotesForIndex mocks base method.
+func (m *MocknoteRepo) GetNotesForIndex(ctx context.Context, afterID uuid.UUID, limit int) ([]elastic.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesForIndex", ctx, afterID, limit)
+	ret0, _ := ret[0].([]elastic.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotesForIndex indicates an expected call of GetNotesForIndex.
+func (mr *MocknoteRepoMockRecorder) GetNotesForIndex(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesForIndex", reflect.TypeOf((*MocknoteRepo)(nil).GetNotesForIndex), ctx, afterID, limit)
+}
+
+// GetNotesForIndexByIDs mocks base method.
+func (m *MocknoteRepo) GetNotesForIndexByIDs(ctx context.Context, ids []uuid.UUID) ([]elastic.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesForIndexByIDs", ctx, ids)
+	ret0, _ := ret[0].([]elastic.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotesForIndexByIDs indicates an expected call of GetNotesForIndexByIDs.
+func (mr *MocknoteRepoMockRecorder) GetNotesForIndexByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesForIndexByIDs", reflect.TypeOf((*MocknoteRepo)(nil).GetNotesForIndexByIDs), ctx, ids)
+}
+
+// Mockindex is a mock of index interface.
+type Mockindex struct {
+	ctrl     *gomock.Controller
+	recorder *MockindexMockRecorder
+}
+
+// MockindexMockRecorder is the mock recorder for Mockindex.
+type MockindexMockRecorder struct {
+	mock *Mockindex
+}
+
+// NewMockindex creates a new mock instance.
+func NewMockindex(ctrl *gomock.Controller) *Mockindex {
+	mock := &Mockindex{ctrl: ctrl}
+	mock.recorder = &MockindexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockindex) EXPECT() *MockindexMockRecorder {
+	return m.recorder
+}
+
+// BulkDelete mocks base method.
+func (m *Mockindex) BulkDelete(ctx context.Context, index string, elasticIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, index, elasticIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockindexMockRecorder) BulkDelete(ctx, index, elasticIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*Mockindex)(nil).BulkDelete), ctx, index, elasticIDs)
+}
+
+// BulkIndex mocks base method.
+func (m *Mockindex) BulkIndex(ctx context.Context, index string, notes []elastic.Note) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndex", ctx, index, notes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkIndex indicates an expected call of BulkIndex.
+func (mr *MockindexMockRecorder) BulkIndex(ctx, index, notes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndex", reflect.TypeOf((*Mockindex)(nil).BulkIndex), ctx, index, notes)
+}
+
+// ScanNotes mocks base method.
+func (m *Mockindex) ScanNotes(ctx context.Context, size int, fn func([]elastic.Note) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanNotes", ctx, size, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanNotes indicates an expected call of ScanNotes.
+func (mr *MockindexMockRecorder) ScanNotes(ctx, size, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanNotes", reflect.TypeOf((*Mockindex)(nil).ScanNotes), ctx, size, fn)
+}
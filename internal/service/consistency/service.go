@@ -0,0 +1,99 @@
+package consistency
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webserver/internal/model/elastic"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+)
+
+// DefaultBatchSize - сколько заметок читается из базы и из эластика за один раз
+const DefaultBatchSize = 500
+
+// Service сверяет заметки в базе с документами в индексе эластика и при необходимости исправляет индекс
+type Service struct {
+	repo    noteRepo
+	elastic index
+	logger  *logger.Logger
+
+	batchSize int
+	now       func() time.Time
+}
+
+//go:generate mockgen -source ./service.go -destination=./mocks/consistency.go -package=mocks
+type noteRepo interface {
+	// GetNotesForIndex возвращает до limit заметок с ID больше afterID, отсортированных по ID
+	GetNotesForIndex(ctx context.Context, afterID uuid.UUID, limit int) ([]elastic.Note, error)
+	// GetNotesForIndexByIDs возвращает заметки с указанными ID из основной базы. Удаленных заметок в результате нет
+	GetNotesForIndexByIDs(ctx context.Context, ids []uuid.UUID) ([]elastic.Note, error)
+}
+
+type index interface {
+	// ScanNotes обходит все документы индекса заметок в порядке ID
+	ScanNotes(ctx context.Context, size int, fn func(notes []elastic.Note) error) error
+	// BulkIndex сохраняет заметки в индекс одним запросом
+	BulkIndex(ctx context.Context, index string, notes []elastic.Note) error
+	// BulkDelete удаляет документы из индекса одним запросом
+	BulkDelete(ctx context.Context, index string, elasticIDs []string) error
+}
+
+type ConsistencyOption func(*Service)
+
+func WithRepo(repo noteRepo) ConsistencyOption {
+	return func(s *Service) {
+		s.repo = repo
+	}
+}
+
+func WithElastic(elastic index) ConsistencyOption {
+	return func(s *Service) {
+		s.elastic = elastic
+	}
+}
+
+func WithLogger(logger *logger.Logger) ConsistencyOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithBatchSize задает, сколько заметок читается за один раз. По умолчанию DefaultBatchSize
+func WithBatchSize(size int) ConsistencyOption {
+	return func(s *Service) {
+		s.batchSize = size
+	}
+}
+
+func New(opts ...ConsistencyOption) (*Service, error) {
+	srv := &Service{
+		batchSize: DefaultBatchSize,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	if srv.repo == nil {
+		return nil, errors.New("repo is nil")
+	}
+
+	if srv.elastic == nil {
+		return nil, errors.New("elastic is nil")
+	}
+
+	if srv.logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+
+	if srv.batchSize <= 0 {
+		return nil, errors.New("batch size must be positive")
+	}
+
+	srv.logger.Info("consistency service initialized")
+
+	return srv, nil
+}
@@ -0,0 +1,94 @@
+package consistency
+
+import (
+	"errors"
+	"testing"
+	"webserver/internal/service/consistency/mocks"
+
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	type test struct {
+		name      string
+		repo      noteRepo
+		elastic   index
+		logger    *logger.Logger
+		batchSize int
+		err       error
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMocknoteRepo(ctrl)
+	es := mocks.NewMockindex(ctrl)
+
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	consistencyLogger := log.WithService("consistency")
+
+	tests := []test{
+		{
+			name:      "positive case",
+			repo:      repo,
+			elastic:   es,
+			logger:    consistencyLogger,
+			batchSize: DefaultBatchSize,
+		},
+		{
+			name:      "error case: repo is nil",
+			elastic:   es,
+			logger:    consistencyLogger,
+			batchSize: DefaultBatchSize,
+			err:       errors.New("repo is nil"),
+		},
+		{
+			name:      "error case: elastic is nil",
+			repo:      repo,
+			logger:    consistencyLogger,
+			batchSize: DefaultBatchSize,
+			err:       errors.New("elastic is nil"),
+		},
+		{
+			name:      "error case: logger is nil",
+			repo:      repo,
+			elastic:   es,
+			batchSize: DefaultBatchSize,
+			err:       errors.New("logger is nil"),
+		},
+		{
+			name:    "error case: zero batch size",
+			repo:    repo,
+			elastic: es,
+			logger:  consistencyLogger,
+			err:     errors.New("batch size must be positive"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := New(
+				WithRepo(tt.repo),
+				WithElastic(tt.elastic),
+				WithLogger(tt.logger),
+				WithBatchSize(tt.batchSize),
+			)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+				assert.Nil(t, srv)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.batchSize, srv.batchSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	elastic "webserver/internal/model/elastic"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MocknoteRepo is a mock of noteRepo interface.
+type MocknoteRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocknoteRepoMockRecorder
+}
+
+// MocknoteRepoMockRecorder is the mock recorder for MocknoteRepo.
+type MocknoteRepoMockRecorder struct {
+	mock *MocknoteRepo
+}
+
+// NewMocknoteRepo creates a new mock instance.
+func NewMocknoteRepo(ctrl *gomock.Controller) *MocknoteRepo {
+	mock := &MocknoteRepo{ctrl: ctrl}
+	mock.recorder = &MocknoteRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknoteRepo) EXPECT() *MocknoteRepoMockRecorder {
+	return m.recorder
+}
+
+// CountNotes mocks base method.
+func (m *MocknoteRepo) CountNotes(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNotes", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNotes indicates an expected call of CountNotes.
+func (mr *MocknoteRepoMockRecorder) CountNotes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNotes", reflect.TypeOf((*MocknoteRepo)(nil).CountNotes), ctx)
+}
+
+// GetNotesForIndex mocks base method.
+func (m *MocknoteRepo) GetNotesForIndex(ctx context.Context, afterID uuid.UUID, limit int) ([]elastic.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesForIndex", ctx, afterID, limit)
+	ret0, _ := ret[0].([]elastic.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotesForIndex indicates an expected call of GetNotesForIndex.
+func (mr *MocknoteRepoMockRecorder) GetNotesForIndex(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesForIndex", reflect.TypeOf((*MocknoteRepo)(nil).GetNotesForIndex), ctx, afterID, limit)
+}
+
+// Mockindexer is a mock of indexer interface.
+type Mockindexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockindexerMockRecorder
+}
+
+// MockindexerMockRecorder is the mock recorder for Mockindexer.
+type MockindexerMockRecorder struct {
+	mock *Mockindexer
+}
+
+// NewMockindexer creates a new mock instance.
+func NewMockindexer(ctrl *gomock.Controller) *Mockindexer {
+	mock := &Mockindexer{ctrl: ctrl}
+	mock.recorder = &MockindexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockindexer) EXPECT() *MockindexerMockRecorder {
+	return m.recorder
+}
+
+// BulkIndex mocks base method.
+func (m *Mockindexer) BulkIndex(ctx context.Context, index string, notes []elastic.Note) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndex", ctx, index, notes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkIndex indicates an expected call of BulkIndex.
+func (mr *MockindexerMockRecorder) BulkIndex(ctx, index, notes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndex", reflect.TypeOf((*Mockindexer)(nil).BulkIndex), ctx, index, notes)
+}
+
+// CreateIndex mocks base method.
+func (m *Mockindexer) CreateIndex(ctx context.Context, def elastic.IndexDefinition, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, def, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockindexerMockRecorder) CreateIndex(ctx, def, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*Mockindexer)(nil).CreateIndex), ctx, def, name)
+}
+
+// SwitchAlias mocks base method.
+func (m *Mockindexer) SwitchAlias(ctx context.Context, alias elastic.ElasticIndex, index string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchAlias", ctx, alias, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwitchAlias indicates an expected call of SwitchAlias.
+func (mr *MockindexerMockRecorder) SwitchAlias(ctx, alias, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchAlias", reflect.TypeOf((*Mockindexer)(nil).SwitchAlias), ctx, alias, index)
+}
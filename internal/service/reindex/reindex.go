@@ -0,0 +1,113 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/google/uuid"
+)
+
+// ErrResumeIndexNotSet - продолжить переиндексацию можно только в индекс, в который она шла
+var ErrResumeIndexNotSet = errors.New("index to resume into is not set")
+
+// Params - параметры переиндексации
+type Params struct {
+	// DryRun - только прочитать заметки из базы и посчитать их, ничего не меняя в эластике
+	DryRun bool
+	// Index - существующий индекс, в который нужно продолжить переиндексацию. Если не указан, создается новый
+	Index string
+	// After - ID последней обработанной заметки: переиндексация продолжится со следующей
+	After uuid.UUID
+}
+
+// Result - итог переиндексации
+type Result struct {
+	Index   string    // индекс, в который сохранены заметки
+	Indexed int       // сколько заметок сохранено
+	LastID  uuid.UUID // ID последней обработанной заметки
+}
+
+// Reindex читает все заметки из базы пачками по ID, сохраняет их в новый индекс текущей версии
+// и переключает на него алиас. Пока идет переиндексация, запросы обслуживает старый индекс.
+// Если переиндексация прервалась, ее можно продолжить с последнего обработанного ID (см. Params).
+// Так же заменяется алиасом индекс со старым динамическим маппингом, который сервер при запуске не трогает.
+// Заметки, созданные, измененные или удаленные во время переиндексации, db-worker пишет через алиас в старый индекс,
+// и в новый они не переносятся: после переиндексации нужно запустить проверку согласованности с исправлением
+// (команда consistency -repair)
+func (s *Service) Reindex(ctx context.Context, params Params) (Result, error) {
+	def := elastic.NoteIndexDefinition()
+
+	if params.After != uuid.Nil && len(params.Index) == 0 {
+		return Result{}, ErrResumeIndexNotSet
+	}
+
+	res := Result{
+		Index:  params.Index,
+		LastID: params.After,
+	}
+
+	if len(res.Index) == 0 {
+		res.Index = def.NewIndexName(s.now())
+
+		if !params.DryRun {
+			if err := s.elastic.CreateIndex(ctx, def, res.Index); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	total, err := s.repo.CountNotes(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	s.logger.Infof("reindexing %d notes to `%s` (dry run: %t), starting after %s", total, res.Index, params.DryRun, res.LastID)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return res, s.interrupted(res, err)
+		}
+
+		notes, err := s.repo.GetNotesForIndex(ctx, res.LastID, s.batchSize)
+		if err != nil {
+			return res, s.interrupted(res, err)
+		}
+
+		if len(notes) == 0 {
+			break
+		}
+
+		if !params.DryRun {
+			if err := s.elastic.BulkIndex(ctx, res.Index, notes); err != nil {
+				return res, s.interrupted(res, err)
+			}
+		}
+
+		res.Indexed += len(notes)
+		res.LastID = notes[len(notes)-1].ID
+
+		s.logger.Infof("reindexed %d/%d notes to `%s`, last id: %s", res.Indexed, total, res.Index, res.LastID)
+	}
+
+	if params.DryRun {
+		s.logger.Infof("dry run: %d notes would be reindexed to `%s`", res.Indexed, res.Index)
+		return res, nil
+	}
+
+	if err := s.elastic.SwitchAlias(ctx, def.Alias, res.Index); err != nil {
+		return res, err
+	}
+
+	s.logger.Infof("reindexed %d notes, alias `%s` points to `%s`", res.Indexed, def.Alias, res.Index)
+
+	return res, nil
+}
+
+// interrupted возвращает ошибку с тем, откуда продолжить переиндексацию
+func (s *Service) interrupted(res Result, err error) error {
+	s.logger.Errorf("reindex interrupted, to resume run: reindex -index %s -after %s", res.Index, res.LastID)
+
+	return fmt.Errorf("reindex to `%s` interrupted after %s: %w", res.Index, res.LastID, err)
+}
@@ -0,0 +1,162 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/reindex/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindex(t *testing.T) {
+	type test struct {
+		name       string
+		params     Params
+		setupMocks func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer)
+		want       Result
+		err        error
+	}
+
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	newIndex := "notes_v1_20240515143000"
+
+	notes := []elastic.Note{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Text: "first"},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Text: "second"},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Text: "third", Deleted: true},
+	}
+
+	bulkErr := errors.New("cluster unavailable")
+
+	tests := []test{
+		{
+			name: "positive case: all batches indexed, alias switched",
+			setupMocks: func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer) {
+				gomock.InOrder(
+					es.EXPECT().CreateIndex(gomock.Any(), gomock.Any(), newIndex).Return(nil),
+					repo.EXPECT().CountNotes(gomock.Any()).Return(3, nil),
+					repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(notes[:2], nil),
+					es.EXPECT().BulkIndex(gomock.Any(), newIndex, notes[:2]).Return(nil),
+					repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[1].ID, 2).Return(notes[2:], nil),
+					es.EXPECT().BulkIndex(gomock.Any(), newIndex, notes[2:]).Return(nil),
+					repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[2].ID, 2).Return(nil, nil),
+					es.EXPECT().SwitchAlias(gomock.Any(), elastic.NoteIndex, newIndex).Return(nil),
+				)
+			},
+			want: Result{Index: newIndex, Indexed: 3, LastID: notes[2].ID},
+		},
+		{
+			name:   "positive case: dry run does not touch elastic",
+			params: Params{DryRun: true},
+			setupMocks: func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer) {
+				repo.EXPECT().CountNotes(gomock.Any()).Return(3, nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(notes[:2], nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[1].ID, 2).Return(notes[2:], nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[2].ID, 2).Return(nil, nil)
+			},
+			want: Result{Index: newIndex, Indexed: 3, LastID: notes[2].ID},
+		},
+		{
+			name:   "positive case: resume into existing index",
+			params: Params{Index: "notes_v1_20240514000000", After: notes[1].ID},
+			setupMocks: func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer) {
+				repo.EXPECT().CountNotes(gomock.Any()).Return(3, nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[1].ID, 2).Return(notes[2:], nil)
+				es.EXPECT().BulkIndex(gomock.Any(), "notes_v1_20240514000000", notes[2:]).Return(nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[2].ID, 2).Return(nil, nil)
+				es.EXPECT().SwitchAlias(gomock.Any(), elastic.NoteIndex, "notes_v1_20240514000000").Return(nil)
+			},
+			want: Result{Index: "notes_v1_20240514000000", Indexed: 1, LastID: notes[2].ID},
+		},
+		{
+			name:       "error case: resume without index",
+			params:     Params{After: notes[1].ID},
+			setupMocks: func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer) {},
+			want:       Result{},
+			err:        ErrResumeIndexNotSet,
+		},
+		{
+			name: "error case: bulk failed, alias not switched",
+			setupMocks: func(repo *mocks.MocknoteRepo, es *mocks.Mockindexer) {
+				es.EXPECT().CreateIndex(gomock.Any(), gomock.Any(), newIndex).Return(nil)
+				repo.EXPECT().CountNotes(gomock.Any()).Return(3, nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 2).Return(notes[:2], nil)
+				es.EXPECT().BulkIndex(gomock.Any(), newIndex, notes[:2]).Return(nil)
+				repo.EXPECT().GetNotesForIndex(gomock.Any(), notes[1].ID, 2).Return(notes[2:], nil)
+				es.EXPECT().BulkIndex(gomock.Any(), newIndex, notes[2:]).Return(bulkErr)
+			},
+			want: Result{Index: newIndex, Indexed: 2, LastID: notes[1].ID},
+			err:  bulkErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMocknoteRepo(ctrl)
+			es := mocks.NewMockindexer(ctrl)
+
+			tt.setupMocks(repo, es)
+
+			srv, err := New(
+				WithRepo(repo),
+				WithElastic(es),
+				WithLogger(createTestLogger(t)),
+				WithBatchSize(2),
+			)
+			require.NoError(t, err)
+
+			srv.now = func() time.Time { return now }
+
+			got, err := srv.Reindex(context.Background(), tt.params)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReindex_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMocknoteRepo(ctrl)
+	es := mocks.NewMockindexer(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	note := elastic.Note{ID: uuid.New()}
+
+	repo.EXPECT().CountNotes(gomock.Any()).Return(2, nil)
+	repo.EXPECT().GetNotesForIndex(gomock.Any(), uuid.Nil, 1).Return([]elastic.Note{note}, nil)
+	// остановка приходит, пока сохраняется первая пачка: следующая уже не читается
+	es.EXPECT().BulkIndex(gomock.Any(), "notes_v1_1", []elastic.Note{note}).DoAndReturn(
+		func(context.Context, string, []elastic.Note) error {
+			cancel()
+			return nil
+		})
+
+	srv, err := New(
+		WithRepo(repo),
+		WithElastic(es),
+		WithLogger(createTestLogger(t)),
+		WithBatchSize(1),
+	)
+	require.NoError(t, err)
+
+	got, err := srv.Reindex(ctx, Params{Index: "notes_v1_1"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Result{Index: "notes_v1_1", Indexed: 1, LastID: note.ID}, got)
+}
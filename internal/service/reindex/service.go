@@ -0,0 +1,99 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webserver/internal/model/elastic"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+)
+
+// DefaultBatchSize - сколько заметок читается из базы и сохраняется в эластик за один раз
+const DefaultBatchSize = 500
+
+// Service перестраивает индекс заметок по данным из базы
+type Service struct {
+	repo    noteRepo
+	elastic indexer
+	logger  *logger.Logger
+
+	batchSize int
+	now       func() time.Time // время для названия нового индекса
+}
+
+//go:generate mockgen -source ./service.go -destination=./mocks/reindex.go -package=mocks
+type noteRepo interface {
+	// GetNotesForIndex возвращает до limit заметок с ID больше afterID, отсортированных по ID
+	GetNotesForIndex(ctx context.Context, afterID uuid.UUID, limit int) ([]elastic.Note, error)
+	// CountNotes возвращает количество заметок, которые будут проиндексированы
+	CountNotes(ctx context.Context) (int, error)
+}
+
+type indexer interface {
+	// CreateIndex создает индекс name с маппингом из def
+	CreateIndex(ctx context.Context, def elastic.IndexDefinition, name string) error
+	// BulkIndex сохраняет заметки в индекс одним запросом
+	BulkIndex(ctx context.Context, index string, notes []elastic.Note) error
+	// SwitchAlias переключает алиас на индекс
+	SwitchAlias(ctx context.Context, alias elastic.ElasticIndex, index string) error
+}
+
+type ReindexOption func(*Service)
+
+func WithRepo(repo noteRepo) ReindexOption {
+	return func(s *Service) {
+		s.repo = repo
+	}
+}
+
+func WithElastic(elastic indexer) ReindexOption {
+	return func(s *Service) {
+		s.elastic = elastic
+	}
+}
+
+func WithLogger(logger *logger.Logger) ReindexOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithBatchSize задает, сколько заметок обрабатывается за один раз. По умолчанию DefaultBatchSize
+func WithBatchSize(size int) ReindexOption {
+	return func(s *Service) {
+		s.batchSize = size
+	}
+}
+
+func New(opts ...ReindexOption) (*Service, error) {
+	srv := &Service{
+		batchSize: DefaultBatchSize,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	if srv.repo == nil {
+		return nil, errors.New("repo is nil")
+	}
+
+	if srv.elastic == nil {
+		return nil, errors.New("elastic is nil")
+	}
+
+	if srv.logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+
+	if srv.batchSize <= 0 {
+		return nil, errors.New("batch size must be positive")
+	}
+
+	srv.logger.Info("reindex service initialized")
+
+	return srv, nil
+}
@@ -0,0 +1,109 @@
+package reindex
+
+import (
+	"errors"
+	"testing"
+	"webserver/internal/service/reindex/mocks"
+
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	type test struct {
+		name      string
+		repo      noteRepo
+		elastic   indexer
+		logger    *logger.Logger
+		batchSize int
+		wantBatch int
+		err       error
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMocknoteRepo(ctrl)
+	elastic := mocks.NewMockindexer(ctrl)
+	reindexLogger := createTestLogger(t)
+
+	tests := []test{
+		{
+			name:      "positive case: default batch size",
+			repo:      repo,
+			elastic:   elastic,
+			logger:    reindexLogger,
+			wantBatch: DefaultBatchSize,
+		},
+		{
+			name:      "positive case: custom batch size",
+			repo:      repo,
+			elastic:   elastic,
+			logger:    reindexLogger,
+			batchSize: 100,
+			wantBatch: 100,
+		},
+		{
+			name:    "error case: repo is nil",
+			elastic: elastic,
+			logger:  reindexLogger,
+			err:     errors.New("repo is nil"),
+		},
+		{
+			name:   "error case: elastic is nil",
+			repo:   repo,
+			logger: reindexLogger,
+			err:    errors.New("elastic is nil"),
+		},
+		{
+			name:    "error case: logger is nil",
+			repo:    repo,
+			elastic: elastic,
+			err:     errors.New("logger is nil"),
+		},
+		{
+			name:      "error case: negative batch size",
+			repo:      repo,
+			elastic:   elastic,
+			logger:    reindexLogger,
+			batchSize: -1,
+			err:       errors.New("batch size must be positive"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []ReindexOption{
+				WithRepo(tt.repo),
+				WithElastic(tt.elastic),
+				WithLogger(tt.logger),
+			}
+
+			if tt.batchSize != 0 {
+				opts = append(opts, WithBatchSize(tt.batchSize))
+			}
+
+			srv, err := New(opts...)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+				assert.Nil(t, srv)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantBatch, srv.batchSize)
+			}
+		})
+	}
+}
+
+func createTestLogger(t *testing.T) *logger.Logger {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	return log.WithService("reindex")
+}
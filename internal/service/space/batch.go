@@ -0,0 +1,232 @@
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+
+	"github.com/google/uuid"
+)
+
+// BatchNotes валидирует все операции пакета и отправляет их в db-worker.
+// Если пакет атомарный, он отправляется одним сообщением и только когда все операции валидны.
+// Иначе каждая валидная операция отправляется отдельно, а невалидные возвращаются в ответе с ошибкой.
+func (s *Service) BatchNotes(ctx context.Context, req model.BatchNotesRequest) (model.BatchNotesResponse, error) {
+	s.logger.WithField("space_id", req.SpaceID).WithField("items", len(req.Items)).Debug("processing notes batch")
+
+	notes, err := s.batchNotes(ctx, req.Items)
+	if err != nil {
+		return model.BatchNotesResponse{}, err
+	}
+
+	created := time.Now().In(time.UTC).Unix()
+
+	resp := model.BatchNotesResponse{
+		Results: make([]model.BatchNoteResult, len(req.Items)),
+	}
+
+	items := make([]rabbit.BatchNoteItem, len(req.Items))
+
+	for i, item := range req.Items {
+		resp.Results[i] = model.BatchNoteResult{
+			Index:     i,
+			Operation: item.Operation,
+			NoteID:    item.NoteID,
+		}
+
+		batchItem, err := s.batchItem(req, item, notes, created)
+		if err != nil {
+			resp.Results[i].Status = model.BatchItemRejected
+			resp.Results[i].Error = err.Error()
+			resp.Rejected++
+
+			continue
+		}
+
+		items[i] = batchItem
+		resp.Results[i].RequestID = batchItemID(batchItem)
+		resp.Results[i].Status = model.BatchItemAccepted
+	}
+
+	if req.Atomic {
+		return s.sendAtomicBatch(ctx, req.SpaceID, items, created, resp)
+	}
+
+	for i, item := range items {
+		if resp.Results[i].Status != model.BatchItemAccepted {
+			continue
+		}
+
+		if err := s.sendBatchItem(ctx, item); err != nil {
+			s.logger.WithField("request_id", resp.Results[i].RequestID).Errorf("error sending batch item: %+v", err)
+
+			resp.Results[i].Status = model.BatchItemFailed
+			resp.Results[i].Error = err.Error()
+			resp.Rejected++
+
+			continue
+		}
+
+		resp.Accepted++
+	}
+
+	return resp, nil
+}
+
+// sendAtomicBatch отправляет пакет одним сообщением. Если хотя бы одна операция невалидна, не отправляется ничего
+func (s *Service) sendAtomicBatch(ctx context.Context, spaceID uuid.UUID, items []rabbit.BatchNoteItem,
+	created int64, resp model.BatchNotesResponse) (model.BatchNotesResponse, error) {
+	if resp.Rejected > 0 {
+		for i := range resp.Results {
+			if resp.Results[i].Status == model.BatchItemAccepted {
+				resp.Results[i].Status = model.BatchItemSkipped
+				resp.Results[i].RequestID = uuid.Nil
+				resp.Rejected++
+			}
+		}
+
+		return resp, model.ErrBatchRejected
+	}
+
+	batch := rabbit.BatchNotesRequest{
+		ID:        uuid.New(),
+		SpaceID:   spaceID,
+		Items:     items,
+		Created:   created,
+		Operation: rabbit.BatchOp,
+	}
+
+	if err := s.worker.BatchNotes(ctx, &batch); err != nil {
+		return model.BatchNotesResponse{}, err
+	}
+
+	resp.RequestID = batch.ID
+	resp.Accepted = len(items)
+
+	return resp, nil
+}
+
+// batchNotes достает из базы заметки, которые обновляются или удаляются в пакете
+func (s *Service) batchNotes(ctx context.Context, items []model.BatchNoteItem) (map[uuid.UUID]model.GetNote, error) {
+	ids := make([]uuid.UUID, 0, len(items))
+
+	for _, item := range items {
+		if item.Operation != model.BatchCreate && item.NoteID != uuid.Nil {
+			ids = append(ids, item.NoteID)
+		}
+	}
+
+	notes := make(map[uuid.UUID]model.GetNote, len(ids))
+
+	if len(ids) == 0 {
+		return notes, nil
+	}
+
+	found, err := s.repo.GetNotesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting batch notes: %w", err)
+	}
+
+	for _, note := range found {
+		notes[note.ID] = note
+	}
+
+	return notes, nil
+}
+
+// batchItem собирает запрос в db-worker для одной операции пакета и валидирует его
+func (s *Service) batchItem(req model.BatchNotesRequest, item model.BatchNoteItem,
+	notes map[uuid.UUID]model.GetNote, created int64) (rabbit.BatchNoteItem, error) {
+	if item.Operation == model.BatchCreate {
+		create := &rabbit.CreateNoteRequest{
+			ID:        uuid.New(),
+			UserID:    req.UserID,
+			SpaceID:   req.SpaceID,
+			Text:      item.Text,
+			Type:      item.Type,
+			File:      item.File,
+			Created:   created,
+			Operation: rabbit.CreateOp,
+		}
+
+		return rabbit.BatchNoteItem{Create: create}, create.Validate()
+	}
+
+	if item.Operation != model.BatchUpdate && item.Operation != model.BatchDelete {
+		return rabbit.BatchNoteItem{}, model.ErrInvalidBatchOperation
+	}
+
+	if item.NoteID == uuid.Nil {
+		return rabbit.BatchNoteItem{}, model.ErrIDNotFilled
+	}
+
+	// проверяем, что в пространстве есть заметка с таким айди
+	note, ok := notes[item.NoteID]
+	if !ok {
+		return rabbit.BatchNoteItem{}, api_errors.ErrNoteNotFound
+	}
+
+	if note.SpaceID != req.SpaceID {
+		return rabbit.BatchNoteItem{}, api_errors.ErrNoteNotBelongsSpace
+	}
+
+	if item.Operation == model.BatchDelete {
+		del := &rabbit.DeleteNoteRequest{
+			ID:        uuid.New(),
+			SpaceID:   req.SpaceID,
+			NoteID:    item.NoteID,
+			Created:   created,
+			Operation: rabbit.DeleteOp,
+		}
+
+		return rabbit.BatchNoteItem{Delete: del}, del.Validate()
+	}
+
+	// обновлять можно только текстовые заметки (пока)
+	if note.Type != model.TextNoteType {
+		return rabbit.BatchNoteItem{}, model.ErrUpdateNotTextNote
+	}
+
+	update := &rabbit.UpdateNoteRequest{
+		ID:        uuid.New(),
+		SpaceID:   req.SpaceID,
+		UserID:    req.UserID,
+		NoteID:    item.NoteID,
+		Text:      item.Text,
+		File:      item.File,
+		Created:   created,
+		Operation: rabbit.UpdateOp,
+	}
+
+	return rabbit.BatchNoteItem{Update: update}, update.Validate()
+}
+
+// sendBatchItem отправляет одну операцию пакета отдельным сообщением
+func (s *Service) sendBatchItem(ctx context.Context, item rabbit.BatchNoteItem) error {
+	switch {
+	case item.Create != nil:
+		return s.worker.CreateNote(ctx, item.Create)
+	case item.Update != nil:
+		return s.worker.UpdateNote(ctx, item.Update)
+	case item.Delete != nil:
+		return s.worker.DeleteNote(ctx, item.Delete)
+	default:
+		return rabbit.ErrInvalidBatchItem
+	}
+}
+
+func batchItemID(item rabbit.BatchNoteItem) uuid.UUID {
+	switch {
+	case item.Create != nil:
+		return item.Create.ID
+	case item.Update != nil:
+		return item.Update.ID
+	case item.Delete != nil:
+		return item.Delete.ID
+	default:
+		return uuid.Nil
+	}
+}
@@ -0,0 +1,228 @@
+package space
+
+import (
+	"context"
+	"errors"
+	"testing"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/service/space/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchNotes(t *testing.T) {
+	type test struct {
+		name         string
+		req          model.BatchNotesRequest
+		dbNotes      []model.GetNote
+		dbErr        error
+		wantStatuses []model.BatchItemStatus
+		wantErrors   []string
+		wantAccepted int
+		wantRejected int
+		err          error
+		setupMocks   func(worker *mocks.MockdbWorker)
+	}
+
+	spaceID := uuid.New()
+	otherSpaceID := uuid.New()
+
+	textNote := model.GetNote{ID: uuid.New(), SpaceID: spaceID, Type: model.TextNoteType}
+	photoNote := model.GetNote{ID: uuid.New(), SpaceID: spaceID, Type: model.PhotoNoteType}
+	otherNote := model.GetNote{ID: uuid.New(), SpaceID: otherSpaceID, Type: model.TextNoteType}
+
+	publishErr := errors.New("channel closed")
+
+	tests := []test{
+		{
+			name: "not atomic: valid items are sent, invalid are rejected",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+					{Operation: model.BatchUpdate, NoteID: textNote.ID, Text: "new text"},
+					{Operation: model.BatchDelete, NoteID: photoNote.ID},
+					{Operation: model.BatchUpdate, NoteID: photoNote.ID, Text: "new text"},
+					{Operation: model.BatchDelete, NoteID: otherNote.ID},
+					{Operation: model.BatchDelete, NoteID: uuid.New()},
+					{Operation: "move", NoteID: textNote.ID},
+					{Operation: model.BatchCreate, Type: model.TextNoteType},
+				},
+			},
+			dbNotes: []model.GetNote{textNote, photoNote, otherNote},
+			wantStatuses: []model.BatchItemStatus{
+				model.BatchItemAccepted, model.BatchItemAccepted, model.BatchItemAccepted,
+				model.BatchItemRejected, model.BatchItemRejected, model.BatchItemRejected,
+				model.BatchItemRejected, model.BatchItemRejected,
+			},
+			wantErrors: []string{
+				"", "", "",
+				model.ErrUpdateNotTextNote.Error(),
+				api_errors.ErrNoteNotBelongsSpace.Error(),
+				api_errors.ErrNoteNotFound.Error(),
+				model.ErrInvalidBatchOperation.Error(),
+				model.ErrFieldTextNotFilled.Error(),
+			},
+			wantAccepted: 3,
+			wantRejected: 5,
+			setupMocks: func(w *mocks.MockdbWorker) {
+				t.Helper()
+				w.EXPECT().CreateNote(gomock.Any(), gomock.Any()).Return(nil)
+				w.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(nil)
+				w.EXPECT().DeleteNote(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "not atomic: publish error",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+					{Operation: model.BatchCreate, Text: "new note 2", Type: model.TextNoteType},
+				},
+			},
+			wantStatuses: []model.BatchItemStatus{model.BatchItemFailed, model.BatchItemAccepted},
+			wantErrors:   []string{publishErr.Error(), ""},
+			wantAccepted: 1,
+			wantRejected: 1,
+			setupMocks: func(w *mocks.MockdbWorker) {
+				t.Helper()
+				gomock.InOrder(
+					w.EXPECT().CreateNote(gomock.Any(), gomock.Any()).Return(publishErr),
+					w.EXPECT().CreateNote(gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+		},
+		{
+			name: "atomic: all items are sent in one message",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Atomic:  true,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+					{Operation: model.BatchDelete, NoteID: textNote.ID},
+				},
+			},
+			dbNotes:      []model.GetNote{textNote},
+			wantStatuses: []model.BatchItemStatus{model.BatchItemAccepted, model.BatchItemAccepted},
+			wantErrors:   []string{"", ""},
+			wantAccepted: 2,
+			setupMocks: func(w *mocks.MockdbWorker) {
+				t.Helper()
+				w.EXPECT().BatchNotes(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req rabbit.Model) {
+					batch, ok := req.(*rabbit.BatchNotesRequest)
+					require.True(t, ok)
+
+					assert.Equal(t, spaceID, batch.SpaceID)
+					assert.Equal(t, rabbit.BatchOp, batch.Operation)
+					require.Len(t, batch.Items, 2)
+					assert.NotNil(t, batch.Items[0].Create)
+					assert.NotNil(t, batch.Items[1].Delete)
+					assert.NoError(t, batch.Validate())
+				}).Return(nil)
+			},
+		},
+		{
+			name: "atomic: batch is rejected if some item is invalid",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Atomic:  true,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+					{Operation: model.BatchDelete, NoteID: otherNote.ID},
+				},
+			},
+			dbNotes:      []model.GetNote{otherNote},
+			wantStatuses: []model.BatchItemStatus{model.BatchItemSkipped, model.BatchItemRejected},
+			wantErrors:   []string{"", api_errors.ErrNoteNotBelongsSpace.Error()},
+			wantRejected: 2,
+			err:          model.ErrBatchRejected,
+			setupMocks:   func(w *mocks.MockdbWorker) {},
+		},
+		{
+			name: "atomic: publish error",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Atomic:  true,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchCreate, Text: "new note", Type: model.TextNoteType},
+				},
+			},
+			err: publishErr,
+			setupMocks: func(w *mocks.MockdbWorker) {
+				t.Helper()
+				w.EXPECT().BatchNotes(gomock.Any(), gomock.Any()).Return(publishErr)
+			},
+		},
+		{
+			name: "db error",
+			req: model.BatchNotesRequest{
+				UserID:  1,
+				SpaceID: spaceID,
+				Items: []model.BatchNoteItem{
+					{Operation: model.BatchDelete, NoteID: textNote.ID},
+				},
+			},
+			dbErr:      errors.New("db error"),
+			err:        errors.New("error getting batch notes: db error"),
+			setupMocks: func(w *mocks.MockdbWorker) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			if tt.dbNotes != nil || tt.dbErr != nil {
+				repo.EXPECT().GetNotesByIDs(gomock.Any(), gomock.Any()).Return(tt.dbNotes, tt.dbErr)
+			}
+
+			tt.setupMocks(worker)
+
+			resp, err := spaceSrv.BatchNotes(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, resp.Results, len(tt.wantStatuses))
+
+			for i, res := range resp.Results {
+				assert.Equal(t, i, res.Index)
+				assert.Equal(t, tt.req.Items[i].Operation, res.Operation)
+				assert.Equal(t, tt.wantStatuses[i], res.Status, "status of item %d", i)
+				assert.Equal(t, tt.wantErrors[i], res.Error, "error of item %d", i)
+
+				// айди запроса есть только у операций, которые отправлены в обработку
+				if res.Status == model.BatchItemAccepted || res.Status == model.BatchItemFailed {
+					assert.NotEqual(t, uuid.Nil, res.RequestID)
+				} else {
+					assert.Equal(t, uuid.Nil, res.RequestID)
+				}
+			}
+
+			assert.Equal(t, tt.wantAccepted, resp.Accepted)
+			assert.Equal(t, tt.wantRejected, resp.Rejected)
+
+			if tt.req.Atomic && tt.err == nil {
+				assert.NotEqual(t, uuid.Nil, resp.RequestID)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	model "webserver/internal/model"
 	rabbit "webserver/internal/model/rabbit"
 
@@ -52,21 +53,6 @@ func (mr *MockrepoMockRecorder) CheckInvitation(ctx, from, to, spaceID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInvitation", reflect.TypeOf((*Mockrepo)(nil).CheckInvitation), ctx, from, to, spaceID)
 }
 
-// CheckParticipant mocks base method.
-func (m *Mockrepo) CheckParticipant(ctx context.Context, userID int64, spaceID uuid.UUID) (bool, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckParticipant", ctx, userID, spaceID)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// CheckParticipant indicates an expected call of CheckParticipant.
-func (mr *MockrepoMockRecorder) CheckParticipant(ctx, userID, spaceID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckParticipant", reflect.TypeOf((*Mockrepo)(nil).CheckParticipant), ctx, userID, spaceID)
-}
-
 // GetAllNotesBySpaceID mocks base method.
 func (m *Mockrepo) GetAllNotesBySpaceID(ctx context.Context, spaceID uuid.UUID) ([]model.GetNote, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +98,21 @@ func (mr *MockrepoMockRecorder) GetNoteByID(ctx, noteID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteByID", reflect.TypeOf((*Mockrepo)(nil).GetNoteByID), ctx, noteID)
 }
 
+// GetNotesByIDs mocks base method.
+func (m *Mockrepo) GetNotesByIDs(ctx context.Context, ids []uuid.UUID) ([]model.GetNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesByIDs", ctx, ids)
+	ret0, _ := ret[0].([]model.GetNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotesByIDs indicates an expected call of GetNotesByIDs.
+func (mr *MockrepoMockRecorder) GetNotesByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByIDs", reflect.TypeOf((*Mockrepo)(nil).GetNotesByIDs), ctx, ids)
+}
+
 // GetNotesByType mocks base method.
 func (m *Mockrepo) GetNotesByType(ctx context.Context, spaceID uuid.UUID, noteType model.NoteType) ([]model.GetNote, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +158,81 @@ func (mr *MockrepoMockRecorder) GetSpaceByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByID", reflect.TypeOf((*Mockrepo)(nil).GetSpaceByID), ctx, id)
 }
 
+// GetSpaceMembers mocks base method.
+func (m *Mockrepo) GetSpaceMembers(ctx context.Context, spaceID uuid.UUID) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpaceMembers", ctx, spaceID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpaceMembers indicates an expected call of GetSpaceMembers.
+func (mr *MockrepoMockRecorder) GetSpaceMembers(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceMembers", reflect.TypeOf((*Mockrepo)(nil).GetSpaceMembers), ctx, spaceID)
+}
+
+// GetTrashBySpaceID mocks base method.
+func (m *Mockrepo) GetTrashBySpaceID(ctx context.Context, spaceID uuid.UUID, deletedAfter time.Time) ([]model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashBySpaceID", ctx, spaceID, deletedAfter)
+	ret0, _ := ret[0].([]model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashBySpaceID indicates an expected call of GetTrashBySpaceID.
+func (mr *MockrepoMockRecorder) GetTrashBySpaceID(ctx, spaceID, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashBySpaceID", reflect.TypeOf((*Mockrepo)(nil).GetTrashBySpaceID), ctx, spaceID, deletedAfter)
+}
+
+// GetTrashNoteByID mocks base method.
+func (m *Mockrepo) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID, deletedAfter time.Time) (model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashNoteByID", ctx, noteID, deletedAfter)
+	ret0, _ := ret[0].(model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashNoteByID indicates an expected call of GetTrashNoteByID.
+func (mr *MockrepoMockRecorder) GetTrashNoteByID(ctx, noteID, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashNoteByID", reflect.TypeOf((*Mockrepo)(nil).GetTrashNoteByID), ctx, noteID, deletedAfter)
+}
+
+// GetUserSpaceIDs mocks base method.
+func (m *Mockrepo) GetUserSpaceIDs(ctx context.Context, userID int64) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSpaceIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSpaceIDs indicates an expected call of GetUserSpaceIDs.
+func (mr *MockrepoMockRecorder) GetUserSpaceIDs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSpaceIDs", reflect.TypeOf((*Mockrepo)(nil).GetUserSpaceIDs), ctx, userID)
+}
+
+// GetUserTimezone mocks base method.
+func (m *Mockrepo) GetUserTimezone(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTimezone", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTimezone indicates an expected call of GetUserTimezone.
+func (mr *MockrepoMockRecorder) GetUserTimezone(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*Mockrepo)(nil).GetUserTimezone), ctx, userID)
+}
+
 // IsSpaceExists mocks base method.
 func (m *Mockrepo) IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -187,11 +263,26 @@ func (mr *MockrepoMockRecorder) IsSpacePersonal(ctx, spaceID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSpacePersonal", reflect.TypeOf((*Mockrepo)(nil).IsSpacePersonal), ctx, spaceID)
 }
 
+// SearchAllNotes mocks base method.
+func (m *Mockrepo) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest, spaceIDs []uuid.UUID) (model.SearchNoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", ctx, req, spaceIDs)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MockrepoMockRecorder) SearchAllNotes(ctx, req, spaceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*Mockrepo)(nil).SearchAllNotes), ctx, req, spaceIDs)
+}
+
 // SearchNoteByText mocks base method.
-func (m *Mockrepo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
+func (m *Mockrepo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchNoteByText", ctx, req)
-	ret0, _ := ret[0].([]model.GetNote)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -202,6 +293,21 @@ func (mr *MockrepoMockRecorder) SearchNoteByText(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*Mockrepo)(nil).SearchNoteByText), ctx, req)
 }
 
+// SuggestNotes mocks base method.
+func (m *Mockrepo) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", ctx, req)
+	ret0, _ := ret[0].(model.SuggestNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MockrepoMockRecorder) SuggestNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*Mockrepo)(nil).SuggestNotes), ctx, req)
+}
+
 // MockspaceRepo is a mock of spaceRepo interface.
 type MockspaceRepo struct {
 	ctrl     *gomock.Controller
@@ -225,34 +331,64 @@ func (m *MockspaceRepo) EXPECT() *MockspaceRepoMockRecorder {
 	return m.recorder
 }
 
-// CheckParticipant mocks base method.
-func (m *MockspaceRepo) CheckParticipant(ctx context.Context, userID int64, spaceID uuid.UUID) (bool, error) {
+// GetSpaceByID mocks base method.
+func (m *MockspaceRepo) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckParticipant", ctx, userID, spaceID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetSpaceByID", ctx, id)
+	ret0, _ := ret[0].(model.Space)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckParticipant indicates an expected call of CheckParticipant.
-func (mr *MockspaceRepoMockRecorder) CheckParticipant(ctx, userID, spaceID interface{}) *gomock.Call {
+// GetSpaceByID indicates an expected call of GetSpaceByID.
+func (mr *MockspaceRepoMockRecorder) GetSpaceByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckParticipant", reflect.TypeOf((*MockspaceRepo)(nil).CheckParticipant), ctx, userID, spaceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByID", reflect.TypeOf((*MockspaceRepo)(nil).GetSpaceByID), ctx, id)
 }
 
-// GetSpaceByID mocks base method.
-func (m *MockspaceRepo) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
+// GetSpaceMembers mocks base method.
+func (m *MockspaceRepo) GetSpaceMembers(ctx context.Context, spaceID uuid.UUID) ([]int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSpaceByID", ctx, id)
-	ret0, _ := ret[0].(model.Space)
+	ret := m.ctrl.Call(m, "GetSpaceMembers", ctx, spaceID)
+	ret0, _ := ret[0].([]int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSpaceByID indicates an expected call of GetSpaceByID.
-func (mr *MockspaceRepoMockRecorder) GetSpaceByID(ctx, id interface{}) *gomock.Call {
+// GetSpaceMembers indicates an expected call of GetSpaceMembers.
+func (mr *MockspaceRepoMockRecorder) GetSpaceMembers(ctx, spaceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByID", reflect.TypeOf((*MockspaceRepo)(nil).GetSpaceByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceMembers", reflect.TypeOf((*MockspaceRepo)(nil).GetSpaceMembers), ctx, spaceID)
+}
+
+// GetUserSpaceIDs mocks base method.
+func (m *MockspaceRepo) GetUserSpaceIDs(ctx context.Context, userID int64) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSpaceIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSpaceIDs indicates an expected call of GetUserSpaceIDs.
+func (mr *MockspaceRepoMockRecorder) GetUserSpaceIDs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSpaceIDs", reflect.TypeOf((*MockspaceRepo)(nil).GetUserSpaceIDs), ctx, userID)
+}
+
+// GetUserTimezone mocks base method.
+func (m *MockspaceRepo) GetUserTimezone(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTimezone", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTimezone indicates an expected call of GetUserTimezone.
+func (mr *MockspaceRepoMockRecorder) GetUserTimezone(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*MockspaceRepo)(nil).GetUserTimezone), ctx, userID)
 }
 
 // IsSpaceExists mocks base method.
@@ -353,6 +489,21 @@ func (mr *MocknoteRepoMockRecorder) GetNoteByID(ctx, noteID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteByID", reflect.TypeOf((*MocknoteRepo)(nil).GetNoteByID), ctx, noteID)
 }
 
+// GetNotesByIDs mocks base method.
+func (m *MocknoteRepo) GetNotesByIDs(ctx context.Context, ids []uuid.UUID) ([]model.GetNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesByIDs", ctx, ids)
+	ret0, _ := ret[0].([]model.GetNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotesByIDs indicates an expected call of GetNotesByIDs.
+func (mr *MocknoteRepoMockRecorder) GetNotesByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByIDs", reflect.TypeOf((*MocknoteRepo)(nil).GetNotesByIDs), ctx, ids)
+}
+
 // GetNotesByType mocks base method.
 func (m *MocknoteRepo) GetNotesByType(ctx context.Context, spaceID uuid.UUID, noteType model.NoteType) ([]model.GetNote, error) {
 	m.ctrl.T.Helper()
@@ -383,11 +534,26 @@ func (mr *MocknoteRepoMockRecorder) GetNotesTypes(ctx, spaceID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesTypes", reflect.TypeOf((*MocknoteRepo)(nil).GetNotesTypes), ctx, spaceID)
 }
 
+// SearchAllNotes mocks base method.
+func (m *MocknoteRepo) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest, spaceIDs []uuid.UUID) (model.SearchNoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAllNotes", ctx, req, spaceIDs)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAllNotes indicates an expected call of SearchAllNotes.
+func (mr *MocknoteRepoMockRecorder) SearchAllNotes(ctx, req, spaceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAllNotes", reflect.TypeOf((*MocknoteRepo)(nil).SearchAllNotes), ctx, req, spaceIDs)
+}
+
 // SearchNoteByText mocks base method.
-func (m *MocknoteRepo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
+func (m *MocknoteRepo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchNoteByText", ctx, req)
-	ret0, _ := ret[0].([]model.GetNote)
+	ret0, _ := ret[0].(model.SearchNoteResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -398,6 +564,74 @@ func (mr *MocknoteRepoMockRecorder) SearchNoteByText(ctx, req interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNoteByText", reflect.TypeOf((*MocknoteRepo)(nil).SearchNoteByText), ctx, req)
 }
 
+// SuggestNotes mocks base method.
+func (m *MocknoteRepo) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestNotes", ctx, req)
+	ret0, _ := ret[0].(model.SuggestNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestNotes indicates an expected call of SuggestNotes.
+func (mr *MocknoteRepoMockRecorder) SuggestNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestNotes", reflect.TypeOf((*MocknoteRepo)(nil).SuggestNotes), ctx, req)
+}
+
+// MocktrashRepo is a mock of trashRepo interface.
+type MocktrashRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrashRepoMockRecorder
+}
+
+// MocktrashRepoMockRecorder is the mock recorder for MocktrashRepo.
+type MocktrashRepoMockRecorder struct {
+	mock *MocktrashRepo
+}
+
+// NewMocktrashRepo creates a new mock instance.
+func NewMocktrashRepo(ctrl *gomock.Controller) *MocktrashRepo {
+	mock := &MocktrashRepo{ctrl: ctrl}
+	mock.recorder = &MocktrashRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrashRepo) EXPECT() *MocktrashRepoMockRecorder {
+	return m.recorder
+}
+
+// GetTrashBySpaceID mocks base method.
+func (m *MocktrashRepo) GetTrashBySpaceID(ctx context.Context, spaceID uuid.UUID, deletedAfter time.Time) ([]model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashBySpaceID", ctx, spaceID, deletedAfter)
+	ret0, _ := ret[0].([]model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashBySpaceID indicates an expected call of GetTrashBySpaceID.
+func (mr *MocktrashRepoMockRecorder) GetTrashBySpaceID(ctx, spaceID, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashBySpaceID", reflect.TypeOf((*MocktrashRepo)(nil).GetTrashBySpaceID), ctx, spaceID, deletedAfter)
+}
+
+// GetTrashNoteByID mocks base method.
+func (m *MocktrashRepo) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID, deletedAfter time.Time) (model.TrashNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashNoteByID", ctx, noteID, deletedAfter)
+	ret0, _ := ret[0].(model.TrashNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashNoteByID indicates an expected call of GetTrashNoteByID.
+func (mr *MocktrashRepoMockRecorder) GetTrashNoteByID(ctx, noteID, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashNoteByID", reflect.TypeOf((*MocktrashRepo)(nil).GetTrashNoteByID), ctx, noteID, deletedAfter)
+}
+
 // MockspaceCache is a mock of spaceCache interface.
 type MockspaceCache struct {
 	ctrl     *gomock.Controller
@@ -421,19 +655,34 @@ func (m *MockspaceCache) EXPECT() *MockspaceCacheMockRecorder {
 	return m.recorder
 }
 
-// GetSpaceByID mocks base method.
-func (m *MockspaceCache) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
+// IsMember mocks base method.
+func (m *MockspaceCache) IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(context.Context, uuid.UUID) ([]int64, error)) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSpaceByID", ctx, id)
+	ret := m.ctrl.Call(m, "IsMember", ctx, spaceID, userID, load)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMember indicates an expected call of IsMember.
+func (mr *MockspaceCacheMockRecorder) IsMember(ctx, spaceID, userID, load interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockspaceCache)(nil).IsMember), ctx, spaceID, userID, load)
+}
+
+// LoadSpace mocks base method.
+func (m *MockspaceCache) LoadSpace(ctx context.Context, id uuid.UUID, load func(context.Context, uuid.UUID) (model.Space, error)) (model.Space, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSpace", ctx, id, load)
 	ret0, _ := ret[0].(model.Space)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSpaceByID indicates an expected call of GetSpaceByID.
-func (mr *MockspaceCacheMockRecorder) GetSpaceByID(ctx, id interface{}) *gomock.Call {
+// LoadSpace indicates an expected call of LoadSpace.
+func (mr *MockspaceCacheMockRecorder) LoadSpace(ctx, id, load interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpaceByID", reflect.TypeOf((*MockspaceCache)(nil).GetSpaceByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSpace", reflect.TypeOf((*MockspaceCache)(nil).LoadSpace), ctx, id, load)
 }
 
 // MockdbWorker is a mock of dbWorker interface.
@@ -473,6 +722,20 @@ func (mr *MockdbWorkerMockRecorder) AddParticipant(ctx, req interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockdbWorker)(nil).AddParticipant), ctx, req)
 }
 
+// BatchNotes mocks base method.
+func (m *MockdbWorker) BatchNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MockdbWorkerMockRecorder) BatchNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MockdbWorker)(nil).BatchNotes), ctx, req)
+}
+
 // CreateNote mocks base method.
 func (m *MockdbWorker) CreateNote(ctx context.Context, req rabbit.Model) error {
 	m.ctrl.T.Helper()
@@ -529,6 +792,76 @@ func (mr *MockdbWorkerMockRecorder) DeleteNote(ctx, req interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockdbWorker)(nil).DeleteNote), ctx, req)
 }
 
+// MoveNote mocks base method.
+func (m *MockdbWorker) MoveNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockdbWorkerMockRecorder) MoveNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockdbWorker)(nil).MoveNote), ctx, req)
+}
+
+// PurgeAllNotes mocks base method.
+func (m *MockdbWorker) PurgeAllNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MockdbWorkerMockRecorder) PurgeAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*MockdbWorker)(nil).PurgeAllNotes), ctx, req)
+}
+
+// PurgeNote mocks base method.
+func (m *MockdbWorker) PurgeNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MockdbWorkerMockRecorder) PurgeNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MockdbWorker)(nil).PurgeNote), ctx, req)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *MockdbWorker) RestoreAllNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MockdbWorkerMockRecorder) RestoreAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*MockdbWorker)(nil).RestoreAllNotes), ctx, req)
+}
+
+// RestoreNote mocks base method.
+func (m *MockdbWorker) RestoreNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MockdbWorkerMockRecorder) RestoreNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MockdbWorker)(nil).RestoreNote), ctx, req)
+}
+
 // UpdateNote mocks base method.
 func (m *MockdbWorker) UpdateNote(ctx context.Context, req rabbit.Model) error {
 	m.ctrl.T.Helper()
@@ -641,6 +974,20 @@ func (m *MocknoteEditor) EXPECT() *MocknoteEditorMockRecorder {
 	return m.recorder
 }
 
+// BatchNotes mocks base method.
+func (m *MocknoteEditor) BatchNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MocknoteEditorMockRecorder) BatchNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MocknoteEditor)(nil).BatchNotes), ctx, req)
+}
+
 // CreateNote mocks base method.
 func (m *MocknoteEditor) CreateNote(ctx context.Context, req rabbit.Model) error {
 	m.ctrl.T.Helper()
@@ -683,6 +1030,20 @@ func (mr *MocknoteEditorMockRecorder) DeleteNote(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MocknoteEditor)(nil).DeleteNote), ctx, req)
 }
 
+// MoveNote mocks base method.
+func (m *MocknoteEditor) MoveNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MocknoteEditorMockRecorder) MoveNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MocknoteEditor)(nil).MoveNote), ctx, req)
+}
+
 // UpdateNote mocks base method.
 func (m *MocknoteEditor) UpdateNote(ctx context.Context, req rabbit.Model) error {
 	m.ctrl.T.Helper()
@@ -697,6 +1058,85 @@ func (mr *MocknoteEditorMockRecorder) UpdateNote(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MocknoteEditor)(nil).UpdateNote), ctx, req)
 }
 
+// MocktrashEditor is a mock of trashEditor interface.
+type MocktrashEditor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrashEditorMockRecorder
+}
+
+// MocktrashEditorMockRecorder is the mock recorder for MocktrashEditor.
+type MocktrashEditorMockRecorder struct {
+	mock *MocktrashEditor
+}
+
+// NewMocktrashEditor creates a new mock instance.
+func NewMocktrashEditor(ctrl *gomock.Controller) *MocktrashEditor {
+	mock := &MocktrashEditor{ctrl: ctrl}
+	mock.recorder = &MocktrashEditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrashEditor) EXPECT() *MocktrashEditorMockRecorder {
+	return m.recorder
+}
+
+// PurgeAllNotes mocks base method.
+func (m *MocktrashEditor) PurgeAllNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAllNotes indicates an expected call of PurgeAllNotes.
+func (mr *MocktrashEditorMockRecorder) PurgeAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAllNotes", reflect.TypeOf((*MocktrashEditor)(nil).PurgeAllNotes), ctx, req)
+}
+
+// PurgeNote mocks base method.
+func (m *MocktrashEditor) PurgeNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MocktrashEditorMockRecorder) PurgeNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MocktrashEditor)(nil).PurgeNote), ctx, req)
+}
+
+// RestoreAllNotes mocks base method.
+func (m *MocktrashEditor) RestoreAllNotes(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAllNotes", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAllNotes indicates an expected call of RestoreAllNotes.
+func (mr *MocktrashEditorMockRecorder) RestoreAllNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAllNotes", reflect.TypeOf((*MocktrashEditor)(nil).RestoreAllNotes), ctx, req)
+}
+
+// RestoreNote mocks base method.
+func (m *MocktrashEditor) RestoreNote(ctx context.Context, req rabbit.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MocktrashEditorMockRecorder) RestoreNote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MocktrashEditor)(nil).RestoreNote), ctx, req)
+}
+
 // MockparticipantEditor is a mock of participantEditor interface.
 type MockparticipantEditor struct {
 	ctrl     *gomock.Controller
@@ -2,7 +2,11 @@ package space
 
 import (
 	"context"
+	"errors"
+	"time"
+	api_errors "webserver/internal/errors"
 	"webserver/internal/model"
+	"webserver/internal/model/elastic"
 	"webserver/internal/model/rabbit"
 
 	"github.com/google/uuid"
@@ -51,16 +55,116 @@ func (s *Service) GetNotesByType(ctx context.Context, spaceID uuid.UUID, noteTyp
 	return s.repo.GetNotesByType(ctx, spaceID, noteType)
 }
 
-func (s *Service) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
+func (s *Service) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
 	s.logger.WithField("space_id", req.SpaceID).Debug("searching note by text")
 
-	if len(req.Type) == 0 { // по умолчанию, если не указано, ищем среди текстовых
+	if len(req.Type) == 0 && len(req.Types) == 0 { // по умолчанию, если не указано, ищем среди текстовых
 		req.Type = model.TextNoteType
 	}
 
+	if req.Size == 0 {
+		req.Size = model.DefaultSearchSize
+	}
+
+	if err := s.applyDate(ctx, req.UserID, req.Text, &req.SearchFilters); err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
 	return s.repo.SearchNoteByText(ctx, req)
 }
 
+// SearchAllNotes ищет заметки по тексту во всех пространствах, в которых состоит пользователь.
+// Пространства достаются из базы, поэтому заметки из чужих пространств в результат не попадут
+func (s *Service) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest) (model.SearchNoteResponse, error) {
+	s.logger.WithField("user_id", req.UserID).Debug("searching note by text in all user's spaces")
+
+	if len(req.Type) == 0 && len(req.Types) == 0 { // по умолчанию, если не указано, ищем среди текстовых
+		req.Type = model.TextNoteType
+	}
+
+	if req.Size == 0 {
+		req.Size = model.DefaultSearchSize
+	}
+
+	if err := s.applyDate(ctx, req.UserID, req.Text, &req.SearchFilters); err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
+	spaceIDs, err := s.repo.GetUserSpaceIDs(ctx, req.UserID)
+	if err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
+	// пользователь не состоит ни в одном пространстве - искать негде
+	if len(spaceIDs) == 0 {
+		return model.SearchNoteResponse{}, api_errors.ErrNoNotesFoundByText
+	}
+
+	return s.repo.SearchAllNotes(ctx, req, spaceIDs)
+}
+
+// SuggestNotes возвращает подсказки для текста, который пользователь вводит в поиск
+func (s *Service) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	s.logger.WithField("space_id", req.SpaceID).Debug("suggesting notes")
+
+	if req.Size == 0 {
+		req.Size = model.DefaultSuggestSize
+	}
+
+	return s.repo.SuggestNotes(ctx, req)
+}
+
+// applyDate переводит период, указанный словами, в границы дат в часовом поясе пользователя.
+// В том же часовом поясе разбираются даты из операторов before: и after: в тексте запроса
+func (s *Service) applyDate(ctx context.Context, userID int64, text string, filters *model.SearchFilters) error {
+	// синтаксическую ошибку в запросе вернет поиск
+	query, _ := elastic.ParseSearchQuery(text)
+
+	if len(filters.Date) == 0 && !query.HasDates() {
+		return nil
+	}
+
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	filters.Location = now.Location()
+
+	return filters.ApplyDate(now)
+}
+
+// userNow возвращает текущее время в часовом поясе пользователя.
+// Если пользователь не указан или его часовой пояс неизвестен, время возвращается в UTC
+func (s *Service) userNow(ctx context.Context, userID int64) (time.Time, error) {
+	now := time.Now().UTC()
+
+	if userID == 0 {
+		return now, nil
+	}
+
+	timezone, err := s.repo.GetUserTimezone(ctx, userID)
+	if err != nil {
+		if errors.Is(err, api_errors.ErrUnknownUser) {
+			return now, nil
+		}
+
+		return time.Time{}, err
+	}
+
+	if len(timezone) == 0 {
+		return now, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		s.logger.WithField("timezone", timezone).Warnf("unknown user's timezone, using UTC: %+v", err)
+		return now, nil
+	}
+
+	return now.In(loc), nil
+}
+
 func (s *Service) DeleteNote(ctx context.Context, req rabbit.DeleteNoteRequest) error {
 	s.logger.WithField("request_id", req.ID).Debug("deleting note")
 	return s.worker.DeleteNote(ctx, &req)
@@ -70,3 +174,24 @@ func (s *Service) DeleteAllNotes(ctx context.Context, req rabbit.DeleteAllNotesR
 	s.logger.WithField("request_id", req.ID).Debug("deleting all notes")
 	return s.worker.DeleteAllNotes(ctx, &req)
 }
+
+// MoveNote отправляет запрос на перенос заметки в другое пространство в db-worker.
+// db-worker переносит заметку и в базе, и в индексе эластика, как при создании и изменении заметок:
+// сам вебсервер индекс заметок не пишет. Если индекс не обновился, проверка согласованности покажет
+// заметку как DriftSpaceMismatch, исправляет ее команда consistency -repair
+func (s *Service) MoveNote(ctx context.Context, req rabbit.MoveNoteRequest) error {
+	s.logger.WithField("request_id", req.ID).WithField("target_space_id", req.TargetSpaceID).Debug("moving note")
+	return s.worker.MoveNote(ctx, &req)
+}
+
+// CopyNote отправляет запрос на создание копии заметки в другом пространстве.
+// Копия создается как новая заметка с тем же текстом, типом и файлом
+func (s *Service) CopyNote(ctx context.Context, note model.GetNote, req rabbit.CreateNoteRequest) error {
+	req.Text = note.Text
+	req.Type = note.Type
+	req.File = note.File.String
+
+	s.logger.WithField("request_id", req.ID).WithField("note_id", note.ID).Debug("copying note")
+
+	return s.worker.CreateNote(ctx, &req)
+}
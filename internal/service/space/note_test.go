@@ -2,9 +2,11 @@ package space
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
+	api_errors "webserver/internal/errors"
 	"webserver/internal/model"
 	"webserver/internal/model/rabbit"
 
@@ -416,7 +418,7 @@ func TestSearchNoteByText(t *testing.T) {
 	type test struct {
 		name string
 		req  model.SearchNoteByTextRequest
-		want []model.GetNote
+		want model.SearchNoteResponse
 		err  error
 	}
 
@@ -428,13 +430,20 @@ func TestSearchNoteByText(t *testing.T) {
 				Text:    "test",
 				Type:    model.TextNoteType,
 			},
-			want: []model.GetNote{
-				{
-					ID:      uuid.New(),
-					Created: time.Now(),
-					UserID:  123,
-					Text:    "test note",
-					Type:    model.TextNoteType,
+			want: model.SearchNoteResponse{
+				Total: 1,
+				Hits: []model.SearchNoteHit{
+					{
+						GetNote: model.GetNote{
+							ID:      uuid.New(),
+							Created: time.Now(),
+							UserID:  123,
+							Text:    "test note",
+							Type:    model.TextNoteType,
+						},
+						Score:      1.5,
+						Highlights: []string{"<em>test</em> note"},
+					},
 				},
 			},
 			err: nil,
@@ -445,15 +454,52 @@ func TestSearchNoteByText(t *testing.T) {
 				SpaceID: uuid.New(),
 				Text:    "test",
 			},
-			want: []model.GetNote{
-				{
-					ID:      uuid.New(),
-					Created: time.Now(),
-					UserID:  123,
-					Text:    "test note",
-					Type:    model.TextNoteType,
+			want: model.SearchNoteResponse{
+				Total: 1,
+				Hits: []model.SearchNoteHit{
+					{
+						GetNote: model.GetNote{
+							ID:      uuid.New(),
+							Created: time.Now(),
+							UserID:  123,
+							Text:    "test note",
+							Type:    model.TextNoteType,
+						},
+						Score:      1.5,
+						Highlights: []string{"<em>test</em> note"},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "positive case: several types without default",
+			req: model.SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				Text:          "test",
+				SearchFilters: model.SearchFilters{Types: []model.NoteType{model.PhotoNoteType}},
+			},
+			want: model.SearchNoteResponse{
+				Total: 1,
+				Hits:  []model.SearchNoteHit{},
+			},
+			err: nil,
+		},
+		{
+			name: "positive case: custom page",
+			req: model.SearchNoteByTextRequest{
+				SpaceID: uuid.New(),
+				Text:    "test",
+				Type:    model.TextNoteType,
+				SearchPage: model.SearchPage{
+					From: 20,
+					Size: 5,
 				},
 			},
+			want: model.SearchNoteResponse{
+				Total: 21,
+				Hits:  []model.SearchNoteHit{},
+			},
 			err: nil,
 		},
 		{
@@ -463,7 +509,7 @@ func TestSearchNoteByText(t *testing.T) {
 				Text:    "test",
 				Type:    model.TextNoteType,
 			},
-			want: nil,
+			want: model.SearchNoteResponse{},
 			err:  errors.New("db error"),
 		},
 	}
@@ -477,11 +523,15 @@ func TestSearchNoteByText(t *testing.T) {
 			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
 
 			expectedReq := tt.req
-			if len(tt.req.Type) == 0 {
+			if len(tt.req.Type) == 0 && len(tt.req.Types) == 0 {
 				expectedReq.Type = model.TextNoteType
 			}
 
-			repo.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).Return(tt.want, tt.err)
+			if tt.req.Size == 0 {
+				expectedReq.Size = model.DefaultSearchSize
+			}
+
+			repo.EXPECT().SearchNoteByText(gomock.Any(), expectedReq).Return(tt.want, tt.err)
 
 			got, err := spaceSrv.SearchNoteByText(context.Background(), tt.req)
 			if tt.err != nil {
@@ -495,6 +545,203 @@ func TestSearchNoteByText(t *testing.T) {
 	}
 }
 
+func TestSearchNoteByText_Date(t *testing.T) {
+	type test struct {
+		name        string
+		userID      int64
+		timezone    string
+		timezoneErr error
+		loc         *time.Location // в каком часовом поясе должен считаться период
+		err         error
+	}
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	tests := []test{
+		{
+			name:     "user's timezone",
+			userID:   1234,
+			timezone: "Europe/Moscow",
+			loc:      moscow,
+		},
+		{
+			name: "without user",
+			loc:  time.UTC,
+		},
+		{
+			name:        "unknown user",
+			userID:      1234,
+			timezoneErr: api_errors.ErrUnknownUser,
+			loc:         time.UTC,
+		},
+		{
+			name:   "timezone not saved",
+			userID: 1234,
+			loc:    time.UTC,
+		},
+		{
+			name:     "invalid timezone",
+			userID:   1234,
+			timezone: "Mars/Olympus",
+			loc:      time.UTC,
+		},
+		{
+			name:        "db error",
+			userID:      1234,
+			timezoneErr: errors.New("db error"),
+			err:         errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			req := model.SearchNoteByTextRequest{
+				SpaceID:       uuid.New(),
+				UserID:        tt.userID,
+				Text:          "test",
+				SearchFilters: model.SearchFilters{Date: "вчера"},
+			}
+
+			if tt.userID != 0 {
+				repo.EXPECT().GetUserTimezone(gomock.Any(), tt.userID).Return(tt.timezone, tt.timezoneErr)
+			}
+
+			if tt.err == nil {
+				repo.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, got model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
+						period, err := model.ParseDateExpression("вчера", time.Now().In(tt.loc))
+						require.NoError(t, err)
+
+						assert.Empty(t, got.Date)
+						require.NotNil(t, got.CreatedFrom)
+						require.NotNil(t, got.CreatedTo)
+						assert.True(t, period.From.Equal(*got.CreatedFrom))
+						assert.True(t, period.To.Equal(*got.CreatedTo))
+
+						return model.SearchNoteResponse{}, nil
+					})
+			}
+
+			_, err := spaceSrv.SearchNoteByText(context.Background(), req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchAllNotes(t *testing.T) {
+	type test struct {
+		name       string
+		req        model.SearchAllNotesRequest
+		spaceIDs   []uuid.UUID
+		spacesErr  error
+		want       model.SearchNoteResponse
+		repoErr    error
+		err        error
+		searchCall bool
+	}
+
+	spaceIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	resp := model.SearchNoteResponse{
+		Total: 1,
+		Hits: []model.SearchNoteHit{
+			{
+				GetNote: model.GetNote{
+					ID:      uuid.New(),
+					UserID:  123,
+					Text:    "dentist on monday",
+					SpaceID: spaceIDs[1],
+					Type:    model.TextNoteType,
+				},
+				Score:      1.2,
+				Highlights: []string{"<em>dentist</em> on monday"},
+			},
+		},
+		Spaces: []model.SpaceHits{{SpaceID: spaceIDs[1], Count: 1}},
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: model.SearchAllNotesRequest{
+				UserID: 123,
+				Text:   "dentist",
+			},
+			spaceIDs:   spaceIDs,
+			want:       resp,
+			searchCall: true,
+		},
+		{
+			name: "user has no spaces",
+			req: model.SearchAllNotesRequest{
+				UserID: 123,
+				Text:   "dentist",
+			},
+			err: api_errors.ErrNoNotesFoundByText,
+		},
+		{
+			name: "error getting spaces",
+			req: model.SearchAllNotesRequest{
+				UserID: 123,
+				Text:   "dentist",
+			},
+			spacesErr: errors.New("db error"),
+			err:       errors.New("db error"),
+		},
+		{
+			name: "search error",
+			req: model.SearchAllNotesRequest{
+				UserID: 123,
+				Text:   "dentist",
+			},
+			spaceIDs:   spaceIDs,
+			repoErr:    errors.New("elastic error"),
+			err:        errors.New("elastic error"),
+			searchCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			repo.EXPECT().GetUserSpaceIDs(gomock.Any(), tt.req.UserID).Return(tt.spaceIDs, tt.spacesErr)
+
+			if tt.searchCall {
+				expectedReq := tt.req
+				expectedReq.Type = model.TextNoteType
+				expectedReq.Size = model.DefaultSearchSize
+
+				repo.EXPECT().SearchAllNotes(gomock.Any(), expectedReq, tt.spaceIDs).Return(tt.want, tt.repoErr)
+			}
+
+			got, err := spaceSrv.SearchAllNotes(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func TestDeleteNote(t *testing.T) {
 	type test struct {
 		name string
@@ -590,3 +837,284 @@ func TestDeleteAllNotes(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.MoveNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Operation:     rabbit.MoveOp,
+			},
+			err: nil,
+		},
+		{
+			name: "error case: db error",
+			req: rabbit.MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Operation:     rabbit.MoveOp,
+			},
+			err: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().MoveNote(context.Background(), &tt.req).Return(tt.err)
+
+			err := spaceSrv.MoveNote(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchNoteByText_DateOperators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, cache, worker := createMockServices(ctrl)
+	spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	req := model.SearchNoteByTextRequest{
+		SpaceID: uuid.New(),
+		UserID:  1234,
+		Text:    "молоко after:2024-05-15",
+	}
+
+	// before: и after: разбираются в том же часовом поясе, что и период словами
+	repo.EXPECT().GetUserTimezone(gomock.Any(), req.UserID).Return("Europe/Moscow", nil)
+	repo.EXPECT().SearchNoteByText(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, got model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
+			assert.Equal(t, moscow, got.Location)
+			assert.Nil(t, got.CreatedFrom)
+
+			return model.SearchNoteResponse{}, nil
+		})
+
+	_, err = spaceSrv.SearchNoteByText(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func TestCopyNote(t *testing.T) {
+	type test struct {
+		name     string
+		note     model.GetNote
+		req      rabbit.CreateNoteRequest
+		expected rabbit.CreateNoteRequest
+		err      error
+	}
+
+	requestID := uuid.New()
+	targetSpaceID := uuid.New()
+
+	req := rabbit.CreateNoteRequest{
+		ID:        requestID,
+		UserID:    1,
+		SpaceID:   targetSpaceID,
+		Created:   1234,
+		Operation: rabbit.CreateOp,
+	}
+
+	tests := []test{
+		{
+			name: "positive case: text note",
+			note: model.GetNote{ID: uuid.New(), SpaceID: uuid.New(), Text: "note", Type: model.TextNoteType},
+			req:  req,
+			expected: rabbit.CreateNoteRequest{
+				ID:        requestID,
+				UserID:    1,
+				SpaceID:   targetSpaceID,
+				Text:      "note",
+				Type:      model.TextNoteType,
+				Created:   1234,
+				Operation: rabbit.CreateOp,
+			},
+		},
+		{
+			name: "positive case: file is kept",
+			note: model.GetNote{
+				ID:      uuid.New(),
+				SpaceID: uuid.New(),
+				Text:    "photo",
+				Type:    model.PhotoNoteType,
+				File:    sql.NullString{String: "photo.jpg", Valid: true},
+			},
+			req: req,
+			expected: rabbit.CreateNoteRequest{
+				ID:        requestID,
+				UserID:    1,
+				SpaceID:   targetSpaceID,
+				Text:      "photo",
+				Type:      model.PhotoNoteType,
+				File:      "photo.jpg",
+				Created:   1234,
+				Operation: rabbit.CreateOp,
+			},
+		},
+		{
+			name: "error case: worker error",
+			note: model.GetNote{ID: uuid.New(), SpaceID: uuid.New(), Text: "note", Type: model.TextNoteType},
+			req:  req,
+			expected: rabbit.CreateNoteRequest{
+				ID:        requestID,
+				UserID:    1,
+				SpaceID:   targetSpaceID,
+				Text:      "note",
+				Type:      model.TextNoteType,
+				Created:   1234,
+				Operation: rabbit.CreateOp,
+			},
+			err: errors.New("channel closed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().CreateNote(context.Background(), &tt.expected).Return(tt.err)
+
+			err := spaceSrv.CopyNote(context.Background(), tt.note, tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestCopyNote_FileFromRepo проверяет путь ручки копирования: заметка читается через GetNoteByID,
+// и ее файл попадает в запрос на создание копии
+func TestCopyNote_FileFromRepo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo, cache, worker := createMockServices(ctrl)
+	spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+	note := model.GetNote{
+		ID:      uuid.New(),
+		SpaceID: uuid.New(),
+		Text:    "photo",
+		Type:    model.PhotoNoteType,
+		File:    sql.NullString{String: "photo.jpg", Valid: true},
+	}
+
+	req := rabbit.CreateNoteRequest{
+		ID:        uuid.New(),
+		UserID:    1,
+		SpaceID:   uuid.New(),
+		Created:   1234,
+		Operation: rabbit.CreateOp,
+	}
+
+	repo.EXPECT().GetNoteByID(gomock.Any(), note.ID).Return(note, nil)
+	worker.EXPECT().CreateNote(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, got *rabbit.CreateNoteRequest) error {
+		assert.Equal(t, "photo.jpg", got.File)
+		assert.Equal(t, model.PhotoNoteType, got.Type)
+		assert.Equal(t, req.SpaceID, got.SpaceID)
+
+		return nil
+	})
+
+	found, err := spaceSrv.GetNoteByID(context.Background(), note.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, spaceSrv.CopyNote(context.Background(), found, req))
+}
+
+func TestSuggestNotes(t *testing.T) {
+	type test struct {
+		name     string
+		req      model.SuggestNotesRequest
+		wantSize int // с каким количеством подсказок сервис идет в репозиторий
+		want     model.SuggestNotesResponse
+		err      error
+	}
+
+	spaceID := uuid.New()
+
+	suggestions := model.SuggestNotesResponse{
+		Suggestions: []model.NoteSuggestion{
+			{ID: uuid.New(), Type: model.TextNoteType, Snippet: "<em>купить</em> молоко"},
+		},
+	}
+
+	tests := []test{
+		{
+			name:     "positive case: default size",
+			req:      model.SuggestNotesRequest{SpaceID: spaceID, Query: "куп"},
+			wantSize: model.DefaultSuggestSize,
+			want:     suggestions,
+		},
+		{
+			name:     "positive case: custom size",
+			req:      model.SuggestNotesRequest{SpaceID: spaceID, Query: "куп", Size: 3},
+			wantSize: 3,
+			want:     suggestions,
+		},
+		{
+			name:     "error case: elastic error",
+			req:      model.SuggestNotesRequest{SpaceID: spaceID, Query: "куп"},
+			wantSize: model.DefaultSuggestSize,
+			err:      errors.New("elastic error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			expectedReq := tt.req
+			expectedReq.Size = tt.wantSize
+
+			repo.EXPECT().SuggestNotes(gomock.Any(), expectedReq).Return(tt.want, tt.err)
+
+			got, err := spaceSrv.SuggestNotes(context.Background(), tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package space
 import (
 	"context"
 	"errors"
+	"time"
 	"webserver/internal/model"
 	"webserver/internal/model/rabbit"
 
@@ -15,6 +16,8 @@ type Service struct {
 	cache  spaceCache
 	worker dbWorker // создание / обновление записей
 	logger *logger.Logger
+
+	trashRetention time.Duration // сколько заметки хранятся в корзине до окончательного удаления
 }
 
 //go:generate mockgen -source ./space.go -destination=./mocks/space_srv.go -package=mocks
@@ -22,15 +25,20 @@ type repo interface {
 	noteRepo
 	spaceRepo
 	spaceChecker
+	trashRepo
 }
 
 //go:generate mockgen -source ./space.go -destination=./mocks/space_srv.go -package=mocks
 type spaceRepo interface {
 	GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error)
-	// CheckParticipant проверяет, является ли пользователь участником пространства
-	CheckParticipant(ctx context.Context, userID int64, spaceID uuid.UUID) (bool, error)
+	// GetSpaceMembers возвращает телеграм айди всех участников пространства, включая создателя
+	GetSpaceMembers(ctx context.Context, spaceID uuid.UUID) ([]int64, error)
 	IsSpacePersonal(ctx context.Context, spaceID uuid.UUID) (bool, error)
 	IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, error)
+	// GetUserSpaceIDs возвращает айди всех пространств, в которых состоит пользователь
+	GetUserSpaceIDs(ctx context.Context, userID int64) ([]uuid.UUID, error)
+	// GetUserTimezone возвращает часовой пояс пользователя
+	GetUserTimezone(ctx context.Context, userID int64) (string, error)
 }
 
 //go:generate mockgen -source ./space.go -destination=./mocks/space_srv.go -package=mocks
@@ -45,12 +53,29 @@ type noteRepo interface {
 	GetNotesTypes(ctx context.Context, spaceID uuid.UUID) ([]model.NoteTypeResponse, error)
 	// GetNotesByType возвращает все заметки указанного типа из пространства
 	GetNotesByType(ctx context.Context, spaceID uuid.UUID, noteType model.NoteType) ([]model.GetNote, error)
-	SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error)
+	SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error)
+	// SearchAllNotes ищет заметки по тексту сразу в нескольких пространствах
+	SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest, spaceIDs []uuid.UUID) (model.SearchNoteResponse, error)
+	// SuggestNotes возвращает подсказки по началу текста
+	SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error)
+	// GetNotesByIDs возвращает заметки с указанными айди. Заметки, которых не существует, в результат не попадают
+	GetNotesByIDs(ctx context.Context, ids []uuid.UUID) ([]model.GetNote, error)
+}
+
+//go:generate mockgen -source ./space.go -destination=./mocks/space_srv.go -package=mocks
+type trashRepo interface {
+	// GetTrashBySpaceID возвращает заметки из корзины пространства, перемещенные в корзину после deletedAfter
+	GetTrashBySpaceID(ctx context.Context, spaceID uuid.UUID, deletedAfter time.Time) ([]model.TrashNote, error)
+	// GetTrashNoteByID возвращает заметку из корзины, перемещенную в корзину после deletedAfter
+	GetTrashNoteByID(ctx context.Context, noteID uuid.UUID, deletedAfter time.Time) (model.TrashNote, error)
 }
 
 //go:generate mockgen -source ./service.go -destination=./mocks/space_srv.go -package=mocks
 type spaceCache interface {
-	GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error)
+	// LoadSpace возвращает пространство из кэша, а если его там нет - загружает через load и сохраняет в кэш
+	LoadSpace(ctx context.Context, id uuid.UUID, load func(ctx context.Context, id uuid.UUID) (model.Space, error)) (model.Space, error)
+	// IsMember проверяет по кэшу, состоит ли пользователь в пространстве. Если участников нет в кэше, загружает их через load
+	IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(ctx context.Context, spaceID uuid.UUID) ([]int64, error)) (bool, error)
 }
 
 // dbWorker работает на создание / обновление записей
@@ -58,6 +83,7 @@ type dbWorker interface {
 	noteEditor
 	spaceEditor
 	participantEditor
+	trashEditor
 }
 
 type spaceEditor interface {
@@ -73,6 +99,15 @@ type noteEditor interface {
 	UpdateNote(ctx context.Context, req rabbit.Model) error
 	DeleteNote(ctx context.Context, req rabbit.Model) error
 	DeleteAllNotes(ctx context.Context, req rabbit.Model) error
+	BatchNotes(ctx context.Context, req rabbit.Model) error
+	MoveNote(ctx context.Context, req rabbit.Model) error
+}
+
+type trashEditor interface {
+	RestoreNote(ctx context.Context, req rabbit.Model) error
+	RestoreAllNotes(ctx context.Context, req rabbit.Model) error
+	PurgeNote(ctx context.Context, req rabbit.Model) error
+	PurgeAllNotes(ctx context.Context, req rabbit.Model) error
 }
 
 type participantEditor interface {
@@ -105,6 +140,12 @@ func WithLogger(logger *logger.Logger) SpaceOption {
 	}
 }
 
+func WithTrashRetention(retention time.Duration) SpaceOption {
+	return func(s *Service) {
+		s.trashRetention = retention
+	}
+}
+
 func New(opts ...SpaceOption) (*Service, error) {
 	space := &Service{}
 
@@ -128,6 +169,10 @@ func New(opts ...SpaceOption) (*Service, error) {
 		return nil, errors.New("logger is nil")
 	}
 
+	if space.trashRetention <= 0 {
+		return nil, errors.New("trash retention is not set")
+	}
+
 	space.logger.Info("space service initialized")
 
 	return space, nil
@@ -3,6 +3,7 @@ package space
 import (
 	"errors"
 	"testing"
+	"time"
 	"webserver/internal/service/space/mocks"
 
 	"github.com/ex-rate/logger"
@@ -13,13 +14,14 @@ import (
 
 func TestNew(t *testing.T) {
 	type test struct {
-		name   string
-		repo   repo
-		cache  spaceCache
-		worker dbWorker
-		logger *logger.Logger
-		want   *Service
-		err    error
+		name           string
+		repo           repo
+		cache          spaceCache
+		worker         dbWorker
+		logger         *logger.Logger
+		trashRetention time.Duration
+		want           *Service
+		err            error
 	}
 
 	ctrl := gomock.NewController(t)
@@ -35,47 +37,62 @@ func TestNew(t *testing.T) {
 
 	spaceLogger := logger.WithService("space")
 
+	trashRetention := 30 * 24 * time.Hour
+
 	tests := []test{
 		{
-			name:   "positive case",
-			repo:   repo,
-			cache:  cache,
-			worker: worker,
-			logger: spaceLogger,
-			want:   &Service{repo: repo, cache: cache, worker: worker, logger: spaceLogger},
-			err:    nil,
+			name:           "positive case",
+			repo:           repo,
+			cache:          cache,
+			worker:         worker,
+			logger:         spaceLogger,
+			trashRetention: trashRetention,
+			want:           &Service{repo: repo, cache: cache, worker: worker, logger: spaceLogger, trashRetention: trashRetention},
+			err:            nil,
 		},
 		{
-			name:   "error case: repo is nil",
-			repo:   nil,
-			cache:  cache,
-			worker: worker,
-			logger: spaceLogger,
-			err:    errors.New("repo is nil"),
+			name:           "error case: repo is nil",
+			repo:           nil,
+			cache:          cache,
+			worker:         worker,
+			logger:         spaceLogger,
+			trashRetention: trashRetention,
+			err:            errors.New("repo is nil"),
 		},
 		{
-			name:   "error case: cache is nil",
-			repo:   repo,
-			cache:  nil,
-			worker: worker,
-			logger: spaceLogger,
-			err:    errors.New("cache is nil"),
+			name:           "error case: cache is nil",
+			repo:           repo,
+			cache:          nil,
+			worker:         worker,
+			logger:         spaceLogger,
+			trashRetention: trashRetention,
+			err:            errors.New("cache is nil"),
 		},
 		{
-			name:   "error case: worker is nil",
-			repo:   repo,
-			cache:  cache,
-			worker: nil,
-			logger: spaceLogger,
-			err:    errors.New("worker is nil"),
+			name:           "error case: worker is nil",
+			repo:           repo,
+			cache:          cache,
+			worker:         nil,
+			logger:         spaceLogger,
+			trashRetention: trashRetention,
+			err:            errors.New("worker is nil"),
+		},
+		{
+			name:           "error case: logger is nil",
+			repo:           repo,
+			cache:          cache,
+			worker:         worker,
+			logger:         nil,
+			trashRetention: trashRetention,
+			err:            errors.New("logger is nil"),
 		},
 		{
-			name:   "error case: logger is nil",
+			name:   "error case: trash retention is not set",
 			repo:   repo,
 			cache:  cache,
 			worker: worker,
-			logger: nil,
-			err:    errors.New("logger is nil"),
+			logger: spaceLogger,
+			err:    errors.New("trash retention is not set"),
 		},
 	}
 
@@ -86,6 +103,7 @@ func TestNew(t *testing.T) {
 				WithCache(tt.cache),
 				WithWorker(tt.worker),
 				WithLogger(tt.logger),
+				WithTrashRetention(tt.trashRetention),
 			)
 			if tt.err != nil {
 				require.Error(t, err)
@@ -113,6 +131,7 @@ func createTestSpaceSrv(t *testing.T, repo *mocks.Mockrepo, cache *mocks.Mockspa
 		WithCache(cache),
 		WithWorker(worker),
 		WithLogger(spaceLogger),
+		WithTrashRetention(30*24*time.Hour),
 	)
 	require.NoError(t, err)
 
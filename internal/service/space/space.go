@@ -14,25 +14,14 @@ import (
 func (s *Service) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
 	s.logger.WithField("id", id).Debug("getting space by id")
 
-	space, err := s.cache.GetSpaceByID(ctx, id)
-	if err != nil {
-		if !errors.Is(err, api_errors.ErrSpaceNotExists) {
-			return model.Space{}, err
-		}
-	}
-
-	if err == nil {
-		return space, nil
-	}
-
-	return s.repo.GetSpaceByID(ctx, id)
+	return s.cache.LoadSpace(ctx, id, s.repo.GetSpaceByID)
 }
 
 // IsUserInSpace проверяет, состоит ли пользователь в пространстве
 func (s *Service) IsUserInSpace(ctx context.Context, userID int64, spaceID uuid.UUID) (bool, error) {
 	s.logger.WithField("user_id", userID).WithField("space_id", spaceID).Debug("checking if user is in space")
 
-	return s.repo.CheckParticipant(ctx, userID, spaceID)
+	return s.cache.IsMember(ctx, spaceID, userID, s.repo.GetSpaceMembers)
 }
 
 func (s *Service) CreateSpace(ctx context.Context, req rabbit.CreateSpaceRequest) error {
@@ -50,35 +39,27 @@ func (s *Service) AddParticipant(ctx context.Context, req rabbit.AddParticipantR
 func (s *Service) IsSpacePersonal(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	s.logger.WithField("space_id", spaceID).Debug("checking if space is personal")
 
-	space, err := s.cache.GetSpaceByID(ctx, spaceID)
+	space, err := s.cache.LoadSpace(ctx, spaceID, s.repo.GetSpaceByID)
 	if err != nil {
-		if !errors.Is(err, api_errors.ErrSpaceNotExists) {
-			return false, err
-		}
+		return false, err
 	}
 
-	if err == nil {
-		return space.Personal, nil
-	}
-
-	return s.repo.IsSpacePersonal(ctx, spaceID)
+	return space.Personal, nil
 }
 
 func (s *Service) IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	s.logger.WithField("space_id", spaceID).Debug("checking if space exists")
 
-	_, err := s.cache.GetSpaceByID(ctx, spaceID)
+	_, err := s.cache.LoadSpace(ctx, spaceID, s.repo.GetSpaceByID)
 	if err != nil {
-		if !errors.Is(err, api_errors.ErrSpaceNotExists) {
-			return false, err
+		if errors.Is(err, api_errors.ErrSpaceNotExists) {
+			return false, nil
 		}
-	}
 
-	if err == nil {
-		return true, nil
+		return false, err
 	}
 
-	return s.repo.IsSpaceExists(ctx, spaceID)
+	return true, nil
 }
 
 func (s *Service) CheckInvitation(ctx context.Context, from, to int64, spaceID uuid.UUID) (bool, error) {
@@ -3,6 +3,9 @@ package space
 import (
 	"context"
 	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	api_errors "webserver/internal/errors"
@@ -10,6 +13,7 @@ import (
 	"webserver/internal/model/rabbit"
 	"webserver/internal/service/space/mocks"
 
+	"github.com/ex-rate/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -39,6 +43,11 @@ func TestGetSpaceByID(t *testing.T) {
 		Personal: true,
 	}
 
+	// cacheMiss - поведение кэша, в котором нет пространства: оно загружается из базы
+	cacheMiss := func(ctx context.Context, id uuid.UUID, load func(context.Context, uuid.UUID) (model.Space, error)) (model.Space, error) {
+		return load(ctx, id)
+	}
+
 	tests := []test{
 		{
 			name:    "positive case: from cache",
@@ -46,7 +55,7 @@ func TestGetSpaceByID(t *testing.T) {
 			space:   space,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(space, nil)
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(space, nil)
 			},
 			err: nil,
 		},
@@ -56,28 +65,29 @@ func TestGetSpaceByID(t *testing.T) {
 			space:   space,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
 				mocks.repo.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(space, nil)
 			},
 			err: nil,
 		},
 		{
-			name:    "error case: cache error",
+			name:    "error case: db error",
 			spaceID: uuid.New(),
 			space:   space,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, errors.New("cache error"))
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, errors.New("db error"))
 			},
-			err: errors.New("cache error"),
+			err: errors.New("db error"),
 		},
 		{
-			name:    "error case: db error",
+			name:    "error case: space not exists",
 			spaceID: uuid.New(),
 			space:   space,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
 				mocks.repo.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, api_errors.ErrSpaceNotExists)
 			},
 			err: api_errors.ErrSpaceNotExists,
@@ -106,6 +116,75 @@ func TestGetSpaceByID(t *testing.T) {
 	}
 }
 
+func TestIsSpaceExists(t *testing.T) {
+	type fields struct {
+		repo   *mocks.Mockrepo
+		cache  *mocks.MockspaceCache
+		worker *mocks.MockdbWorker
+	}
+
+	type test struct {
+		name       string
+		exists     bool
+		err        error
+		setupMocks func(mocks *fields)
+	}
+
+	cacheMiss := func(ctx context.Context, id uuid.UUID, load func(context.Context, uuid.UUID) (model.Space, error)) (model.Space, error) {
+		return load(ctx, id)
+	}
+
+	tests := []test{
+		{
+			name:   "positive case: from cache",
+			exists: true,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Space{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name:   "positive case: not exists",
+			exists: false,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, api_errors.ErrSpaceNotExists)
+			},
+		},
+		{
+			name: "error case: db error",
+			err:  errors.New("db error"),
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.cache.EXPECT().LoadSpace(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceByID(gomock.Any(), gomock.Any()).Return(model.Space{}, errors.New("db error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			tt.setupMocks(&fields{repo: repo, cache: cache, worker: worker})
+
+			exists, err := spaceSrv.IsSpaceExists(context.Background(), uuid.New())
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.exists, exists)
+			}
+		})
+	}
+}
+
 func TestIsUserInSpace(t *testing.T) {
 	type fields struct {
 		repo   *mocks.Mockrepo
@@ -122,15 +201,49 @@ func TestIsUserInSpace(t *testing.T) {
 		setupMocks func(mocks *fields)
 	}
 
+	// cacheMiss - поведение кэша, в котором нет участников пространства: они загружаются из базы
+	cacheMiss := func(ctx context.Context, spaceID uuid.UUID, userID int64, load func(context.Context, uuid.UUID) ([]int64, error)) (bool, error) {
+		members, err := load(ctx, spaceID)
+		if err != nil {
+			return false, err
+		}
+
+		return slices.Contains(members, userID), nil
+	}
+
 	tests := []test{
 		{
-			name:    "positive case",
+			name:    "positive case: from cache",
 			userID:  123,
 			spaceID: uuid.New(),
 			exists:  true,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.repo.EXPECT().CheckParticipant(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mocks.cache.EXPECT().IsMember(gomock.Any(), gomock.Any(), int64(123), gomock.Any()).Return(true, nil)
+			},
+			err: nil,
+		},
+		{
+			name:    "positive case: from db",
+			userID:  123,
+			spaceID: uuid.New(),
+			exists:  true,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.cache.EXPECT().IsMember(gomock.Any(), gomock.Any(), int64(123), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceMembers(gomock.Any(), gomock.Any()).Return([]int64{456, 123}, nil)
+			},
+			err: nil,
+		},
+		{
+			name:    "positive case: not a member",
+			userID:  123,
+			spaceID: uuid.New(),
+			exists:  false,
+			setupMocks: func(mocks *fields) {
+				t.Helper()
+				mocks.cache.EXPECT().IsMember(gomock.Any(), gomock.Any(), int64(123), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceMembers(gomock.Any(), gomock.Any()).Return([]int64{456}, nil)
 			},
 			err: nil,
 		},
@@ -142,7 +255,8 @@ func TestIsUserInSpace(t *testing.T) {
 			err:     errors.New("db error"),
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.repo.EXPECT().CheckParticipant(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+				mocks.cache.EXPECT().IsMember(gomock.Any(), gomock.Any(), int64(123), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetSpaceMembers(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
 			},
 		},
 	}
@@ -232,3 +346,149 @@ func createMockServices(ctrl *gomock.Controller) (*mocks.Mockrepo, *mocks.Mocksp
 	worker := mocks.NewMockdbWorker(ctrl)
 	return repo, cache, worker
 }
+
+// dbRoundTrip - задержка одного запроса к базе в бенчмарках: порядок сетевой задержки внутри дата-центра
+const dbRoundTrip = 300 * time.Microsecond
+
+// membersRepo - репозиторий для бенчмарков: отдает участников пространства с задержкой запроса к базе и считает запросы
+type membersRepo struct {
+	repo
+
+	members []int64
+	queries atomic.Int64
+}
+
+func (r *membersRepo) GetSpaceMembers(_ context.Context, _ uuid.UUID) ([]int64, error) {
+	r.queries.Add(1)
+	time.Sleep(dbRoundTrip)
+
+	return r.members, nil
+}
+
+// membersCache - кэш участников в памяти для бенчмарков. Если disabled, кэш всегда пуст
+type membersCache struct {
+	spaceCache
+
+	disabled bool
+
+	mu      sync.Mutex
+	members map[uuid.UUID][]int64
+}
+
+func (c *membersCache) IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(context.Context, uuid.UUID) ([]int64, error)) (bool, error) {
+	c.mu.Lock()
+	members, ok := c.members[spaceID]
+	c.mu.Unlock()
+
+	if !ok {
+		var err error
+
+		members, err = load(ctx, spaceID)
+		if err != nil {
+			return false, err
+		}
+
+		if !c.disabled {
+			c.mu.Lock()
+			c.members[spaceID] = members
+			c.mu.Unlock()
+		}
+	}
+
+	return slices.Contains(members, userID), nil
+}
+
+// legacyRepo повторяет запросы прежней проверки участника (CheckParticipant до объединения в один запрос):
+// пространство, чтобы узнать, личное ли оно, затем участник и, если пользователь не участник, создатель
+type legacyRepo struct {
+	participants []int64
+	creator      int64
+	queries      atomic.Int64
+}
+
+func (r *legacyRepo) query() {
+	r.queries.Add(1)
+	time.Sleep(dbRoundTrip)
+}
+
+func (r *legacyRepo) CheckParticipant(_ context.Context, userID int64, _ uuid.UUID) (bool, error) {
+	r.query() // пространство
+
+	r.query() // участник, принявший приглашение
+	if slices.Contains(r.participants, userID) {
+		return true, nil
+	}
+
+	r.query() // создатель
+
+	return userID == r.creator, nil
+}
+
+// BenchmarkIsUserInSpace сравнивает проверку участника совместного пространства прежними запросами к базе (legacy),
+// одним запросом к базе на каждую проверку (db) и через кэш участников (cache).
+// Создатель проверяется отдельно: прежней проверке для него нужно больше всего запросов.
+// db_queries/op - сколько запросов к базе приходится на одну проверку
+func BenchmarkIsUserInSpace(b *testing.B) {
+	log, err := logger.New(logger.Config{
+		Level:  logger.InfoLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(b, err)
+
+	spaceID := uuid.New()
+
+	const creator = 1
+
+	participants := []int64{2, 3}
+
+	users := []struct {
+		name string
+		id   int64
+	}{
+		{name: "participant", id: 2},
+		{name: "creator", id: creator},
+	}
+
+	for _, user := range users {
+		b.Run("legacy/"+user.name, func(b *testing.B) {
+			repo := &legacyRepo{participants: participants, creator: creator}
+
+			for range b.N {
+				ok, err := repo.CheckParticipant(context.Background(), user.id, spaceID)
+				if err != nil || !ok {
+					b.Fatalf("unexpected result: %v, %+v", ok, err)
+				}
+			}
+
+			b.ReportMetric(float64(repo.queries.Load())/float64(b.N), "db_queries/op")
+		})
+
+		for _, disabled := range []bool{true, false} {
+			name := "cache/" + user.name
+			if disabled {
+				name = "db/" + user.name
+			}
+
+			b.Run(name, func(b *testing.B) {
+				repo := &membersRepo{members: append([]int64{creator}, participants...)}
+
+				srv := &Service{
+					repo:   repo,
+					cache:  &membersCache{disabled: disabled, members: map[uuid.UUID][]int64{}},
+					logger: log.WithService("space"),
+				}
+
+				b.ResetTimer()
+
+				for range b.N {
+					ok, err := srv.IsUserInSpace(context.Background(), user.id, spaceID)
+					if err != nil || !ok {
+						b.Fatalf("unexpected result: %v, %+v", ok, err)
+					}
+				}
+
+				b.ReportMetric(float64(repo.queries.Load())/float64(b.N), "db_queries/op")
+			})
+		}
+	}
+}
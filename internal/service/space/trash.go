@@ -0,0 +1,73 @@
+package space
+
+import (
+	"context"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+
+	"github.com/google/uuid"
+)
+
+// GetTrash возвращает заметки из корзины пространства, срок хранения которых еще не истек
+func (s *Service) GetTrash(ctx context.Context, spaceID uuid.UUID) ([]model.TrashNote, error) {
+	s.logger.WithField("space_id", spaceID).Debug("getting trash")
+
+	notes, err := s.repo.GetTrashBySpaceID(ctx, spaceID, s.trashDeadline())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range notes {
+		notes[i].ExpiresAt = notes[i].Deleted.Add(s.trashRetention)
+	}
+
+	return notes, nil
+}
+
+// GetTrashNoteByID возвращает заметку из корзины, либо ошибку о том, что такой заметки в корзине нет
+func (s *Service) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID) (model.TrashNote, error) {
+	s.logger.WithField("note_id", noteID).Debug("getting note from trash by id")
+
+	note, err := s.repo.GetTrashNoteByID(ctx, noteID, s.trashDeadline())
+	if err != nil {
+		return model.TrashNote{}, err
+	}
+
+	note.ExpiresAt = note.Deleted.Add(s.trashRetention)
+
+	return note, nil
+}
+
+// RestoreNote отправляет запрос на восстановление заметки из корзины в db-worker
+func (s *Service) RestoreNote(ctx context.Context, req rabbit.RestoreNoteRequest) error {
+	s.logger.WithField("request_id", req.ID).Debug("restoring note")
+
+	return s.worker.RestoreNote(ctx, &req)
+}
+
+// RestoreAllNotes отправляет запрос на восстановление всех заметок из корзины в db-worker
+func (s *Service) RestoreAllNotes(ctx context.Context, req rabbit.RestoreAllNotesRequest) error {
+	s.logger.WithField("request_id", req.ID).Debug("restoring all notes")
+
+	return s.worker.RestoreAllNotes(ctx, &req)
+}
+
+// PurgeNote отправляет запрос на окончательное удаление заметки из корзины в db-worker
+func (s *Service) PurgeNote(ctx context.Context, req rabbit.PurgeNoteRequest) error {
+	s.logger.WithField("request_id", req.ID).Debug("purging note")
+
+	return s.worker.PurgeNote(ctx, &req)
+}
+
+// PurgeAllNotes отправляет запрос на очистку корзины в db-worker
+func (s *Service) PurgeAllNotes(ctx context.Context, req rabbit.PurgeAllNotesRequest) error {
+	s.logger.WithField("request_id", req.ID).Debug("purging all notes")
+
+	return s.worker.PurgeAllNotes(ctx, &req)
+}
+
+// trashDeadline возвращает дату, раньше которой заметки в корзине считаются окончательно удаленными
+func (s *Service) trashDeadline() time.Time {
+	return time.Now().Add(-s.trashRetention)
+}
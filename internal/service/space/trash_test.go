@@ -0,0 +1,333 @@
+package space
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/rabbit"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrash(t *testing.T) {
+	type test struct {
+		name    string
+		spaceID uuid.UUID
+		dbNotes []model.TrashNote
+		want    []model.TrashNote
+		err     error
+	}
+
+	spaceID := uuid.New()
+	noteID := uuid.New()
+	deleted := time.Now().Add(-time.Hour)
+
+	tests := []test{
+		{
+			name:    "positive case",
+			spaceID: spaceID,
+			dbNotes: []model.TrashNote{
+				{
+					GetNote: model.GetNote{ID: noteID, SpaceID: spaceID, Text: "test note", Type: model.TextNoteType},
+					Deleted: deleted,
+				},
+			},
+			want: []model.TrashNote{
+				{
+					GetNote:   model.GetNote{ID: noteID, SpaceID: spaceID, Text: "test note", Type: model.TextNoteType},
+					Deleted:   deleted,
+					ExpiresAt: deleted.Add(30 * 24 * time.Hour),
+				},
+			},
+		},
+		{
+			name:    "error case: trash is empty",
+			spaceID: spaceID,
+			err:     api_errors.ErrNoNotesInTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			repo.EXPECT().GetTrashBySpaceID(gomock.Any(), tt.spaceID, gomock.Any()).
+				Do(func(_ context.Context, _ uuid.UUID, deletedAfter time.Time) {
+					// заметки старше срока хранения не должны запрашиваться
+					assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), deletedAfter, time.Minute)
+				}).Return(tt.dbNotes, tt.err)
+
+			got, err := spaceSrv.GetTrash(context.Background(), tt.spaceID)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetTrashNoteByID(t *testing.T) {
+	type test struct {
+		name   string
+		noteID uuid.UUID
+		dbNote model.TrashNote
+		want   model.TrashNote
+		err    error
+	}
+
+	noteID := uuid.New()
+	deleted := time.Now().Add(-time.Hour)
+
+	tests := []test{
+		{
+			name:   "positive case",
+			noteID: noteID,
+			dbNote: model.TrashNote{
+				GetNote: model.GetNote{ID: noteID},
+				Deleted: deleted,
+			},
+			want: model.TrashNote{
+				GetNote:   model.GetNote{ID: noteID},
+				Deleted:   deleted,
+				ExpiresAt: deleted.Add(30 * 24 * time.Hour),
+			},
+		},
+		{
+			name:   "error case: note not found in trash",
+			noteID: noteID,
+			want:   model.TrashNote{},
+			err:    api_errors.ErrNoteNotFoundInTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			repo.EXPECT().GetTrashNoteByID(gomock.Any(), tt.noteID, gomock.Any()).Return(tt.dbNote, tt.err)
+
+			got, err := spaceSrv.GetTrashNoteByID(context.Background(), tt.noteID)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRestoreNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.RestoreNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: rabbit.RestoreOp,
+			},
+		},
+		{
+			name: "error case: worker error",
+			req: rabbit.RestoreNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: rabbit.RestoreOp,
+			},
+			err: errors.New("worker error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().RestoreNote(context.Background(), &tt.req).Return(tt.err)
+
+			err := spaceSrv.RestoreNote(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreAllNotes(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.RestoreAllNotesRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: rabbit.RestoreAllOp,
+			},
+		},
+		{
+			name: "error case: worker error",
+			req: rabbit.RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: rabbit.RestoreAllOp,
+			},
+			err: errors.New("worker error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().RestoreAllNotes(context.Background(), &tt.req).Return(tt.err)
+
+			err := spaceSrv.RestoreAllNotes(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.PurgeNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: rabbit.PurgeOp,
+			},
+		},
+		{
+			name: "error case: worker error",
+			req: rabbit.PurgeNoteRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				NoteID:    uuid.New(),
+				Operation: rabbit.PurgeOp,
+			},
+			err: errors.New("worker error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().PurgeNote(context.Background(), &tt.req).Return(tt.err)
+
+			err := spaceSrv.PurgeNote(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeAllNotes(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.PurgeAllNotesRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: rabbit.PurgeAllOp,
+			},
+		},
+		{
+			name: "error case: worker error",
+			req: rabbit.PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Operation: rabbit.PurgeAllOp,
+			},
+			err: errors.New("worker error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, cache, worker := createMockServices(ctrl)
+			spaceSrv := createTestSpaceSrv(t, repo, cache, worker)
+
+			worker.EXPECT().PurgeAllNotes(context.Background(), &tt.req).Return(tt.err)
+
+			err := spaceSrv.PurgeAllNotes(context.Background(), tt.req)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
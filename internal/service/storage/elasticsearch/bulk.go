@@ -0,0 +1,124 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/bulk"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// ErrBulkFailed - эластик не сохранил часть документов из пачки
+var ErrBulkFailed = errors.New("bulk request failed")
+
+// BulkIndex сохраняет заметки в индекс одним запросом. ID документа в эластике совпадает с ID заметки из базы,
+// поэтому повторная запись той же заметки перезаписывает документ, а не создает дубликат
+func (c *Client) BulkIndex(ctx context.Context, index string, notes []elastic.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	req := c.cl.Bulk().Index(index)
+
+	for _, note := range notes {
+		id := note.ID.String()
+		note.ElasticID = id
+
+		if err := req.IndexOp(types.IndexOperation{Id_: &id}, note); err != nil {
+			return fmt.Errorf("error adding note %s to bulk request: %w", id, err)
+		}
+	}
+
+	res, err := req.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error indexing notes to `%s`: %w", index, err)
+	}
+
+	return bulkError(res, index)
+}
+
+// BulkUpdate обновляет документы заметок одним запросом. Документ ищется по id в эластике (ElasticID),
+// поля документа заменяются полями заметки. Отсутствующий документ считается ошибкой: он не создается
+func (c *Client) BulkUpdate(ctx context.Context, index string, notes []elastic.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	req := c.cl.Bulk().Index(index)
+
+	for _, note := range notes {
+		if len(note.ElasticID) == 0 {
+			return fmt.Errorf("error adding note %s to bulk request: %w", note.ID, elastic.ErrFieldElasticIDNotFilled)
+		}
+
+		if err := req.UpdateOp(types.UpdateOperation{Id_: &note.ElasticID}, note, nil); err != nil {
+			return fmt.Errorf("error adding note %s to bulk request: %w", note.ID, err)
+		}
+	}
+
+	res, err := req.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error updating notes in `%s`: %w", index, err)
+	}
+
+	return bulkError(res, index)
+}
+
+// BulkDelete удаляет документы с id в эластике elasticIDs одним запросом. Уже удаленные документы не считаются ошибкой
+func (c *Client) BulkDelete(ctx context.Context, index string, elasticIDs []string) error {
+	if len(elasticIDs) == 0 {
+		return nil
+	}
+
+	req := c.cl.Bulk().Index(index)
+
+	for _, id := range elasticIDs {
+		if err := req.DeleteOp(types.DeleteOperation{Id_: &id}); err != nil {
+			return fmt.Errorf("error adding document %s to bulk request: %w", id, err)
+		}
+	}
+
+	res, err := req.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting documents from `%s`: %w", index, err)
+	}
+
+	return bulkError(res, index)
+}
+
+// bulkError возвращает ошибку, если эластик не выполнил часть операций из пачки
+func bulkError(res *bulk.Response, index string) error {
+	if !res.Errors {
+		return nil
+	}
+
+	failed := 0
+	var firstErr *types.ErrorCause
+
+	for _, item := range res.Items {
+		for _, result := range item {
+			if result.Error == nil {
+				continue
+			}
+
+			failed++
+
+			if firstErr == nil {
+				firstErr = result.Error
+			}
+		}
+	}
+
+	if firstErr == nil {
+		return fmt.Errorf("%w: index `%s`", ErrBulkFailed, index)
+	}
+
+	var reason string
+	if firstErr.Reason != nil {
+		reason = *firstErr.Reason
+	}
+
+	return fmt.Errorf("%w: %d of %d operations failed in `%s`, first error: %s: %s", ErrBulkFailed, failed, len(res.Items), index, firstErr.Type, reason)
+}
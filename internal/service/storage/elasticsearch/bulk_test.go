@@ -0,0 +1,123 @@
+package elasticsearch
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulk(t *testing.T) {
+	note1 := elastic.Note{
+		ID:      uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		TgID:    12345,
+		Text:    "one",
+		SpaceID: uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000"),
+		Type:    model.TextNoteType,
+		Created: 1715783400,
+	}
+
+	note2 := note1
+	note2.ID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	note2.ElasticID = "random"
+	note2.Text = "two"
+
+	okResponse := fakeResponse{Body: `{"took":1,"errors":false,"items":[]}`}
+
+	t.Run("index: document id is note id", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, map[string]fakeResponse{"POST /notes_v1/_bulk": okResponse})
+
+		err := cl.BulkIndex(context.Background(), "notes_v1", []elastic.Note{note1})
+		require.NoError(t, err)
+
+		lines := bulkLines(t, fake)
+		require.Len(t, lines, 2)
+
+		assert.JSONEq(t, `{"index":{"_id":"00000000-0000-0000-0000-000000000001"}}`, lines[0])
+		assert.JSONEq(t, `{
+			"ID":"00000000-0000-0000-0000-000000000001",
+			"ElasticID":"00000000-0000-0000-0000-000000000001",
+			"TgID":12345,
+			"Text":"one",
+			"SpaceID":"aaaaaaaa-0000-0000-0000-000000000000",
+			"Type":"text",
+			"Deleted":false,
+			"Created":1715783400
+		}`, lines[1])
+	})
+
+	t.Run("update: by elastic id", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, map[string]fakeResponse{"POST /notes/_bulk": okResponse})
+
+		err := cl.BulkUpdate(context.Background(), "notes", []elastic.Note{note2})
+		require.NoError(t, err)
+
+		lines := bulkLines(t, fake)
+		require.Len(t, lines, 2)
+
+		assert.JSONEq(t, `{"update":{"_id":"random"}}`, lines[0])
+		assert.Contains(t, lines[1], `"doc":{`)
+		assert.Contains(t, lines[1], `"Text":"two"`)
+	})
+
+	t.Run("update: elastic id not filled", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, nil)
+
+		err := cl.BulkUpdate(context.Background(), "notes", []elastic.Note{note1})
+		assert.ErrorIs(t, err, elastic.ErrFieldElasticIDNotFilled)
+		assert.Empty(t, fake.Requests())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, map[string]fakeResponse{"POST /notes/_bulk": okResponse})
+
+		err := cl.BulkDelete(context.Background(), "notes", []string{"a", "b"})
+		require.NoError(t, err)
+
+		lines := bulkLines(t, fake)
+		require.Len(t, lines, 2)
+
+		assert.JSONEq(t, `{"delete":{"_id":"a"}}`, lines[0])
+		assert.JSONEq(t, `{"delete":{"_id":"b"}}`, lines[1])
+	})
+
+	t.Run("empty batch: no request", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, nil)
+
+		require.NoError(t, cl.BulkIndex(context.Background(), "notes", nil))
+		require.NoError(t, cl.BulkUpdate(context.Background(), "notes", nil))
+		require.NoError(t, cl.BulkDelete(context.Background(), "notes", nil))
+
+		assert.Empty(t, fake.Requests())
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		cl, _ := newFakeElastic(t, map[string]fakeResponse{
+			"POST /notes/_bulk": {Body: `{"took":1,"errors":true,"items":[
+				{"delete":{"_index":"notes_v1","_id":"a","status":200}},
+				{"delete":{"_index":"notes_v1","_id":"b","status":429,
+					"error":{"type":"es_rejected_execution_exception","reason":"queue is full"}}}
+			]}`},
+		})
+
+		err := cl.BulkDelete(context.Background(), "notes", []string{"a", "b"})
+		require.ErrorIs(t, err, ErrBulkFailed)
+		assert.Contains(t, err.Error(), "1 of 2 operations failed")
+		assert.Contains(t, err.Error(), "es_rejected_execution_exception: queue is full")
+	})
+}
+
+// bulkLines возвращает строки тела единственного bulk запроса
+func bulkLines(t *testing.T, fake *fakeElastic) []string {
+	t.Helper()
+
+	requests := fake.Requests()
+	require.Len(t, requests, 1)
+
+	return strings.Split(strings.TrimSpace(requests[0].Body), "\n")
+}
@@ -0,0 +1,119 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/deletebyquery"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
+	"github.com/sirupsen/logrus"
+)
+
+// SearchByID ищет документы записи по ID из базы. Возвращает id в эластике всех найденных документов:
+// их может быть несколько, если запись по ошибке проиндексирована дважды
+func (c *Client) SearchByID(ctx context.Context, data elastic.Data) ([]string, error) {
+	// полную валидацию здесь не делаем: id в эластике у записи еще не заполнен
+	if data.Index != elastic.NoteIndex {
+		return nil, fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
+	}
+
+	req, err := data.SearchByIDQuery()
+	if err != nil {
+		return nil, fmt.Errorf("error while creating query for search by id: %w", err)
+	}
+
+	res, err := c.cl.Search().
+		Index(data.Index.String()).
+		Request(req).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error searching note by id: %w", err)
+	}
+
+	if len(res.Hits.Hits) == 0 {
+		return nil, ErrRecordsNotFound
+	}
+
+	ids := make([]string, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		if hit.Id_ != nil {
+			ids = append(ids, *hit.Id_)
+		}
+	}
+
+	return ids, nil
+}
+
+// Delete удаляет все документы записи по ID из базы. Если документов нет, ничего не делает
+func (c *Client) Delete(ctx context.Context, data elastic.Data) error {
+	if data.Index != elastic.NoteIndex {
+		return fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
+	}
+
+	req, err := data.DeleteByQuery()
+	if err != nil {
+		return fmt.Errorf("error while creating query for delete: %w", err)
+	}
+
+	deleted, err := c.deleteByQuery(ctx, data.Index, req)
+	if err != nil {
+		return fmt.Errorf("error deleting note: %w", err)
+	}
+
+	logrus.Debugf("Elastic: deleted %d documents of user's note", deleted)
+
+	return nil
+}
+
+// DeleteAllBySpaceID удаляет документы всех записей пространства
+func (c *Client) DeleteAllBySpaceID(ctx context.Context, data elastic.Data) error {
+	if data.Index != elastic.NoteIndex {
+		return fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
+	}
+
+	req, err := data.DeleteBySpaceQuery()
+	if err != nil {
+		return fmt.Errorf("error while creating query for delete by space: %w", err)
+	}
+
+	deleted, err := c.deleteByQuery(ctx, data.Index, req)
+	if err != nil {
+		return fmt.Errorf("error deleting space notes: %w", err)
+	}
+
+	logrus.Debugf("Elastic: deleted %d documents of space's notes", deleted)
+
+	return nil
+}
+
+// deleteByQuery удаляет документы, подходящие под запрос, и возвращает, сколько удалено.
+// Документы, измененные во время удаления (конфликт версий), пропускаются и не прерывают удаление остальных
+func (c *Client) deleteByQuery(ctx context.Context, index elastic.ElasticIndex, req *deletebyquery.Request) (int64, error) {
+	res, err := c.cl.DeleteByQuery(index.String()).
+		Request(req).
+		Conflicts(conflicts.Proceed).
+		Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(res.Failures) > 0 {
+		failure := res.Failures[0]
+		return 0, fmt.Errorf("%w: %d documents not deleted from `%s`, first error: %s: %s",
+			ErrBulkFailed, len(res.Failures), index, failure.Cause.Type, deref(failure.Cause.Reason))
+	}
+
+	if res.Deleted == nil {
+		return 0, nil
+	}
+
+	return *res.Deleted, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
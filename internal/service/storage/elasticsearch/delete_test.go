@@ -0,0 +1,163 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchHitsResponse = `{
+	"took":1,"timed_out":false,
+	"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},
+	"hits":{"total":{"value":%d,"relation":"eq"},"hits":[%s]}
+}`
+
+func TestSearchByID(t *testing.T) {
+	noteID := uuid.New()
+
+	data := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{ID: noteID},
+	}
+
+	t.Run("note indexed twice", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, map[string]fakeResponse{
+			"POST /notes/_search": {
+				Body: fmt.Sprintf(searchHitsResponse, 2, `
+					{"_index":"notes_v1","_id":"first","_score":1,"_source":{}},
+					{"_index":"notes_v1","_id":"second","_score":1,"_source":{}}`),
+			},
+		})
+
+		ids, err := cl.SearchByID(context.Background(), data)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"first", "second"}, ids)
+
+		requests := fake.Requests()
+		require.Len(t, requests, 1)
+		assert.JSONEq(t, fmt.Sprintf(`{"query":{"term":{"ID":{"value":"%s"}}}}`, noteID), requests[0].Body)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cl, _ := newFakeElastic(t, map[string]fakeResponse{
+			"POST /notes/_search": {Body: fmt.Sprintf(searchHitsResponse, 0, "")},
+		})
+
+		_, err := cl.SearchByID(context.Background(), data)
+		assert.ErrorIs(t, err, ErrRecordsNotFound)
+	})
+
+	t.Run("id not filled: no request", func(t *testing.T) {
+		cl, fake := newFakeElastic(t, nil)
+
+		_, err := cl.SearchByID(context.Background(), elastic.Data{Index: elastic.NoteIndex, Model: &elastic.Note{}})
+		assert.ErrorIs(t, err, elastic.ErrFieldIDNotFilled)
+		assert.Empty(t, fake.Requests())
+	})
+}
+
+func TestDelete(t *testing.T) {
+	noteID := uuid.New()
+
+	data := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{ID: noteID, TgID: 12345},
+	}
+
+	type test struct {
+		name     string
+		response fakeResponse
+		err      error
+	}
+
+	tests := []test{
+		{
+			name:     "positive case",
+			response: fakeResponse{Body: `{"took":3,"deleted":2,"failures":[]}`},
+		},
+		{
+			name:     "nothing to delete",
+			response: fakeResponse{Body: `{"took":1,"deleted":0,"failures":[]}`},
+		},
+		{
+			name: "some documents not deleted",
+			response: fakeResponse{Body: `{"took":3,"deleted":1,"failures":[
+				{"index":"notes_v1","id":"second","status":500,"cause":{"type":"exception","reason":"shard failed"}}
+			]}`},
+			err: ErrBulkFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, fake := newFakeElastic(t, map[string]fakeResponse{
+				"POST /notes/_delete_by_query": tt.response,
+			})
+
+			err := cl.Delete(context.Background(), data)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			requests := fake.Requests()
+			require.Len(t, requests, 1)
+
+			// удаляются документы заметки, а не все заметки автора
+			assert.JSONEq(t, fmt.Sprintf(`{"query":{"term":{"ID":{"value":"%s"}}}}`, noteID), requests[0].Body)
+			assert.Contains(t, requests[0].Query, "conflicts=proceed")
+		})
+	}
+
+	t.Run("elastic unavailable: retried, error keeps status", func(t *testing.T) {
+		url, fake := startFakeElastic(t, map[string]fakeResponse{
+			"POST /notes/_delete_by_query": {
+				Status: http.StatusServiceUnavailable,
+				Body:   `{"error":{"type":"cluster_block_exception","reason":"blocked"},"status":503}`,
+			},
+		})
+
+		cl, err := New(context.Background(), Config{Addresses: []string{url}, MaxRetries: 2})
+		require.NoError(t, err)
+
+		err = cl.Delete(context.Background(), data)
+
+		var esErr *types.ElasticsearchError
+		require.ErrorAs(t, err, &esErr)
+		assert.Equal(t, http.StatusServiceUnavailable, esErr.Status)
+
+		// первая попытка и два повтора
+		assert.Len(t, fake.Requests(), 3)
+	})
+}
+
+func TestDeleteAllBySpaceID(t *testing.T) {
+	spaceID := uuid.New()
+
+	cl, fake := newFakeElastic(t, map[string]fakeResponse{
+		"POST /notes/_delete_by_query": {Body: `{"took":3,"deleted":10,"failures":[]}`},
+	})
+
+	err := cl.DeleteAllBySpaceID(context.Background(), elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{SpaceID: spaceID},
+	})
+	require.NoError(t, err)
+
+	requests := fake.Requests()
+	require.Len(t, requests, 1)
+	assert.JSONEq(t, fmt.Sprintf(`{"query":{"term":{"SpaceID":{"value":"%s"}}}}`, spaceID), requests[0].Body)
+
+	err = cl.DeleteAllBySpaceID(context.Background(), elastic.Data{Index: elastic.NoteIndex, Model: &elastic.Note{}})
+	assert.ErrorIs(t, err, elastic.ErrFieldSpaceIDNotFilled)
+	assert.Len(t, fake.Requests(), 1)
+}
@@ -0,0 +1,120 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRequest - запрос, который получил фейковый эластик
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// fakeResponse - ответ фейкового эластика на запрос
+type fakeResponse struct {
+	Status int
+	Body   string
+}
+
+// fakeInfoResponse - ответ на запрос информации о кластере, который клиент делает при подключении
+const fakeInfoResponse = `{
+	"name":"node-1","cluster_name":"test","cluster_uuid":"uuid",
+	"version":{"number":"%s","build_flavor":"default","build_type":"docker","build_hash":"hash","build_date":"2024-01-01T00:00:00Z",
+		"build_snapshot":false,"lucene_version":"9.12.0","minimum_wire_compatibility_version":"7.17.0",
+		"minimum_index_compatibility_version":"7.0.0"},
+	"tagline":"You Know, for Search"
+}`
+
+// fakeElastic - http сервер, который притворяется эластиком: запоминает запросы и отвечает заданными ответами.
+// Ответы задаются по методу и пути запроса: "POST /notes/_bulk". На запрос информации о кластере (GET /)
+// по умолчанию отвечает версией 8.17.1, этот запрос не запоминается
+type fakeElastic struct {
+	mu        sync.Mutex
+	requests  []fakeRequest
+	responses map[string]fakeResponse
+}
+
+// newFakeElastic запускает фейковый эластик и возвращает клиент, подключенный к нему
+func newFakeElastic(t *testing.T, responses map[string]fakeResponse) (*Client, *fakeElastic) {
+	t.Helper()
+
+	url, fake := startFakeElastic(t, responses)
+
+	cl, err := New(context.Background(), Config{Addresses: []string{url}})
+	require.NoError(t, err)
+
+	return cl, fake
+}
+
+// startFakeElastic запускает фейковый эластик и возвращает его адрес
+func startFakeElastic(t *testing.T, responses map[string]fakeResponse) (string, *fakeElastic) {
+	t.Helper()
+
+	fake := &fakeElastic{responses: map[string]fakeResponse{
+		"GET /": {Body: fmt.Sprintf(fakeInfoResponse, "8.17.1")},
+	}}
+
+	for route, res := range responses {
+		fake.responses[route] = res
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		fake.mu.Lock()
+		if r.URL.Path != "/" {
+			fake.requests = append(fake.requests, fakeRequest{
+				Method: r.Method,
+				Path:   r.URL.Path,
+				Query:  r.URL.RawQuery,
+				Header: r.Header.Clone(),
+				Body:   string(body),
+			})
+		}
+		fake.mu.Unlock()
+
+		// без этого заголовка клиент считает, что отвечает не эластик
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		res, ok := fake.responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to fake elastic: %s %s", r.Method, r.URL.Path)
+
+			res = fakeResponse{
+				Status: http.StatusNotFound,
+				Body:   `{"error":{"type":"not_found","reason":"unexpected request"},"status":404}`,
+			}
+		}
+
+		if res.Status == 0 {
+			res.Status = http.StatusOK
+		}
+
+		w.WriteHeader(res.Status)
+		_, _ = w.Write([]byte(res.Body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL, fake
+}
+
+// Requests возвращает запросы, которые получил фейковый эластик
+func (f *fakeElastic) Requests() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]fakeRequest(nil), f.requests...)
+}
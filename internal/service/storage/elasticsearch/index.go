@@ -0,0 +1,204 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrMappingVersionMismatch - индекс с таким названием уже есть, но создан с другой версией маппинга
+var ErrMappingVersionMismatch = errors.New("index mapping version mismatch")
+
+// Bootstrap создает индексы текущих версий с явным маппингом и проверяет алиасы на них.
+// Если под названием алиаса лежит индекс со старым динамическим маппингом, он не трогается:
+// запросы идут в него, пока команда reindex не заменит его алиасом (см. SwitchAlias)
+func (c *Client) Bootstrap(ctx context.Context) error {
+	for _, def := range elastic.Indices() {
+		indices, err := c.aliasIndices(ctx, def.Alias.String())
+		if err != nil {
+			return err
+		}
+
+		// алиас уже указывает на индекс текущей версии, например созданный переиндексацией
+		if i := slices.IndexFunc(indices, def.Owns); i >= 0 {
+			if err := c.checkMappingVersion(ctx, indices[i], def.Version); err != nil {
+				return err
+			}
+
+			logrus.Debugf("Elastic: alias `%s` points to `%s`", def.Alias, indices[i])
+
+			continue
+		}
+
+		if err := c.ensureIndex(ctx, def); err != nil {
+			return err
+		}
+
+		if err := c.ensureAlias(ctx, def, indices); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureIndex создает индекс, если его нет. Если индекс есть, проверяет, что он создан с той же версией маппинга
+func (c *Client) ensureIndex(ctx context.Context, def elastic.IndexDefinition) error {
+	name := def.Name()
+
+	exists, err := c.cl.Indices.Exists(name).IsSuccess(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking index `%s`: %w", name, err)
+	}
+
+	if exists {
+		return c.checkMappingVersion(ctx, name, def.Version)
+	}
+
+	err = c.CreateIndex(ctx, def, name)
+	// индекс мог создать другой экземпляр приложения, запущенный одновременно с нами
+	if isElasticError(err, "resource_already_exists_exception") {
+		return nil
+	}
+
+	return err
+}
+
+// CreateIndex создает индекс name с маппингом и настройками из описания def
+func (c *Client) CreateIndex(ctx context.Context, def elastic.IndexDefinition, name string) error {
+	_, err := c.cl.Indices.Create(name).
+		Settings(def.Settings).
+		Mappings(def.Mappings).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating index `%s`: %w", name, err)
+	}
+
+	logrus.Infof("Elastic: created index `%s`", name)
+
+	return nil
+}
+
+// checkMappingVersion проверяет, что индекс создан с версией маппинга version
+func (c *Client) checkMappingVersion(ctx context.Context, name string, version int) error {
+	res, err := c.cl.Indices.GetMapping().Index(name).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting mapping of index `%s`: %w", name, err)
+	}
+
+	got, err := elastic.MappingVersion(res[name].Mappings)
+	if err != nil {
+		return fmt.Errorf("error checking mapping of index `%s`: %w", name, err)
+	}
+
+	if got != version {
+		return fmt.Errorf("%w: index `%s` has version %d, expected %d", ErrMappingVersionMismatch, name, got, version)
+	}
+
+	return nil
+}
+
+// ensureAlias создает алиас на индекс текущей версии, если алиаса нет (indices - индексы, на которые он указывает сейчас).
+// Если алиас указывает на индекс другой версии, он не переключается: сначала нужно переиндексировать данные
+func (c *Client) ensureAlias(ctx context.Context, def elastic.IndexDefinition, indices []string) error {
+	alias := def.Alias.String()
+
+	if len(indices) > 0 {
+		logrus.Warnf("Elastic: alias `%s` points to %v, not to `%s`. Run `reindex` to switch the alias", alias, indices, def.Name())
+		return nil
+	}
+
+	// алиаса нет, но может быть индекс с таким же названием, созданный эластиком при первой записи
+	legacy, err := c.isLegacyIndex(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	// переносить данные при запуске нельзя: экземпляры, запущенные одновременно, мешали бы друг другу,
+	// а заметки, сохраненные во время переноса, терялись бы. Старый индекс заменяет команда reindex
+	if legacy {
+		logrus.Warnf("Elastic: `%s` is a legacy index with dynamic mapping, not an alias. Run `reindex` to replace it with alias to `%s`", alias, def.Name())
+		return nil
+	}
+
+	_, err = c.cl.Indices.UpdateAliases().
+		Actions(addAliasAction(alias, def.Name())).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error adding alias `%s` to index `%s`: %w", alias, def.Name(), err)
+	}
+
+	logrus.Infof("Elastic: alias `%s` points to `%s`", alias, def.Name())
+
+	return nil
+}
+
+// aliasIndices возвращает индексы, на которые указывает алиас. Если алиаса нет, возвращает пустой список
+func (c *Client) aliasIndices(ctx context.Context, alias string) ([]string, error) {
+	exists, err := c.cl.Indices.ExistsAlias(alias).IsSuccess(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking alias `%s`: %w", alias, err)
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	res, err := c.cl.Indices.GetAlias().Name(alias).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting alias `%s`: %w", alias, err)
+	}
+
+	indices := make([]string, 0, len(res))
+	for index := range res {
+		indices = append(indices, index)
+	}
+
+	slices.Sort(indices)
+
+	return indices, nil
+}
+
+// isLegacyIndex проверяет, что под названием name лежит сам индекс, а не алиас
+func (c *Client) isLegacyIndex(ctx context.Context, name string) (bool, error) {
+	isAlias, err := c.cl.Indices.ExistsAlias(name).IsSuccess(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking alias `%s`: %w", name, err)
+	}
+
+	if isAlias {
+		return false, nil
+	}
+
+	exists, err := c.cl.Indices.Exists(name).IsSuccess(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking index `%s`: %w", name, err)
+	}
+
+	return exists, nil
+}
+
+// addAliasAction - действие, которое добавляет алиас на индекс. Индекс становится индексом для записи по алиасу
+func addAliasAction(alias, index string) types.IndicesAction {
+	isWriteIndex := true
+
+	return types.IndicesAction{
+		Add: &types.AddAction{
+			Alias:        &alias,
+			Index:        &index,
+			IsWriteIndex: &isWriteIndex,
+		},
+	}
+}
+
+// isElasticError проверяет, что эластик вернул ошибку с типом errType
+func isElasticError(err error, errType string) bool {
+	var esErr *types.ElasticsearchError
+
+	return errors.As(err, &esErr) && esErr.ErrorCause.Type == errType
+}
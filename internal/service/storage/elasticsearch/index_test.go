@@ -0,0 +1,81 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeNotFound     = `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`
+	fakeAcknowledged = `{"acknowledged":true}`
+)
+
+func TestBootstrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		legacy    bool
+		wantPaths []string
+	}{
+		{
+			name:      "new cluster: index and alias created",
+			wantPaths: []string{"HEAD /_alias/notes", "HEAD /notes_v1", "PUT /notes_v1", "HEAD /_alias/notes", "HEAD /notes", "POST /_aliases"},
+		},
+		{
+			// данные переносит команда reindex, а не запуск сервера
+			name:      "legacy index: left as is",
+			legacy:    true,
+			wantPaths: []string{"HEAD /_alias/notes", "HEAD /notes_v1", "PUT /notes_v1", "HEAD /_alias/notes", "HEAD /notes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := fakeResponse{Status: http.StatusNotFound, Body: fakeNotFound}
+			if tt.legacy {
+				legacy = fakeResponse{}
+			}
+
+			cl, fake := newFakeElastic(t, map[string]fakeResponse{
+				"HEAD /_alias/notes": {Status: http.StatusNotFound, Body: fakeNotFound},
+				"HEAD /notes_v1":     {Status: http.StatusNotFound, Body: fakeNotFound},
+				"PUT /notes_v1":      {Body: `{"acknowledged":true,"shards_acknowledged":true,"index":"notes_v1"}`},
+				"HEAD /notes":        legacy,
+				"POST /_aliases":     {Body: fakeAcknowledged},
+			})
+
+			require.NoError(t, cl.Bootstrap(context.Background()))
+
+			var paths []string
+			for _, req := range fake.Requests() {
+				paths = append(paths, req.Method+" "+req.Path)
+			}
+
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
+
+func TestSwitchAlias_LegacyIndex(t *testing.T) {
+	cl, fake := newFakeElastic(t, map[string]fakeResponse{
+		"HEAD /_alias/notes": {Status: http.StatusNotFound, Body: fakeNotFound},
+		"HEAD /notes":        {},
+		"POST /_aliases":     {Body: fakeAcknowledged},
+	})
+
+	require.NoError(t, cl.SwitchAlias(context.Background(), "notes", "notes_v1_20240514000000"))
+
+	requests := fake.Requests()
+	require.Len(t, requests, 4)
+
+	// старый индекс удаляется и заменяется алиасом одним запросом
+	last := requests[len(requests)-1]
+	assert.Equal(t, "POST /_aliases", last.Method+" "+last.Path)
+	assert.JSONEq(t, `{"actions":[
+		{"remove_index":{"index":"notes"}},
+		{"add":{"alias":"notes","index":"notes_v1_20240514000000","is_write_index":true}}
+	]}`, last.Body)
+}
@@ -0,0 +1,55 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/sirupsen/logrus"
+)
+
+// SwitchAlias одним запросом переключает алиас на индекс index. Индексы, на которые алиас указывал раньше, не удаляются,
+// чтобы можно было вернуться на них. Если под названием алиаса лежит индекс со старым динамическим маппингом, он удаляется
+func (c *Client) SwitchAlias(ctx context.Context, alias elastic.ElasticIndex, index string) error {
+	name := alias.String()
+
+	indices, err := c.aliasIndices(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	actions := make([]types.IndicesAction, 0, len(indices)+2)
+
+	for _, old := range indices {
+		if old == index {
+			continue
+		}
+
+		actions = append(actions, types.IndicesAction{
+			Remove: &types.RemoveAction{Alias: &name, Index: &old},
+		})
+	}
+
+	legacy, err := c.isLegacyIndex(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if legacy {
+		actions = append(actions, types.IndicesAction{
+			RemoveIndex: &types.RemoveIndexAction{Index: &name},
+		})
+	}
+
+	actions = append(actions, addAliasAction(name, index))
+
+	_, err = c.cl.Indices.UpdateAliases().Actions(actions...).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error switching alias `%s` to `%s`: %w", name, index, err)
+	}
+
+	logrus.Infof("Elastic: alias `%s` switched from %v to `%s`", name, indices, index)
+
+	return nil
+}
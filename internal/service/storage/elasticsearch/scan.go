@@ -0,0 +1,70 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ScanNotes обходит все документы индекса заметок в порядке ID страницами по size документов и передает каждую страницу в fn.
+// У документов заполнены только ID, Text, SpaceID и ElasticID. Если fn вернула ошибку, обход прекращается
+func (c *Client) ScanNotes(ctx context.Context, size int, fn func(notes []elastic.Note) error) error {
+	index := elastic.NoteIndex.String()
+
+	pit, err := c.cl.OpenPointInTime(index).KeepAlive(elastic.ScanKeepAlive).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error opening point in time for `%s`: %w", index, err)
+	}
+
+	pitID := pit.Id
+
+	defer func() {
+		// снимок закрывается и после отмены ctx, иначе эластик держит его до истечения keep alive
+		if _, err := c.cl.ClosePointInTime().Id(pitID).Do(context.WithoutCancel(ctx)); err != nil {
+			logrus.Warnf("Elastic: error closing point in time: %+v", err)
+		}
+	}()
+
+	var after []types.FieldValue
+
+	for {
+		res, err := c.cl.Search().Request(elastic.ScanNotesQuery(pitID, size, after)).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("error scanning `%s`: %w", index, err)
+		}
+
+		if len(res.Hits.Hits) == 0 {
+			return nil
+		}
+
+		// снимок может получить новый id, следующие страницы нужно запрашивать по нему
+		if res.PitId != nil {
+			pitID = *res.PitId
+		}
+
+		notes := make([]elastic.Note, 0, len(res.Hits.Hits))
+
+		for _, hit := range res.Hits.Hits {
+			var note elastic.Note
+			if err := json.Unmarshal(hit.Source_, &note); err != nil {
+				return fmt.Errorf("error unmarshalling JSON while scanning notes: %w", err)
+			}
+
+			if hit.Id_ != nil {
+				note.ElasticID = *hit.Id_
+			}
+
+			notes = append(notes, note)
+		}
+
+		if err := fn(notes); err != nil {
+			return err
+		}
+
+		after = res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+	}
+}
@@ -6,48 +6,122 @@ import (
 	"fmt"
 	"webserver/internal/model/elastic"
 
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 	"github.com/google/uuid"
 )
 
-// Search производит поиск по переданным данным. Возвращает ID подходящих записей
-func (c *Client) SearchByText(ctx context.Context, data elastic.Data) ([]uuid.UUID, error) {
-	_, err := data.ValidateNote()
-	if err != nil {
-		return nil, err
+// SearchByText производит поиск по тексту. Возвращает страницу подходящих записей,
+// отсортированную по релевантности, с оценкой и подсвеченными фрагментами текста
+func (c *Client) SearchByText(ctx context.Context, data elastic.Data, page elastic.Page) (elastic.SearchResult, error) {
+	// полную валидацию здесь не делаем: у поискового запроса нет id и id в эластике
+	if data.Index != elastic.NoteIndex {
+		return elastic.SearchResult{}, fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
 	}
 
 	query, err := data.SearchByTextQuery()
 	if err != nil {
-		return nil, fmt.Errorf("error while creating query for search note: %+v", err)
+		return elastic.SearchResult{}, fmt.Errorf("error while creating query for search note: %w", err)
+	}
+
+	return c.search(ctx, data.Index, query, page)
+}
+
+// SearchByTextInSpaces производит поиск по тексту сразу в нескольких пространствах.
+// Помимо страницы результатов возвращает, сколько записей нашлось в каждом пространстве
+func (c *Client) SearchByTextInSpaces(ctx context.Context, data elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error) {
+	if data.Index != elastic.NoteIndex {
+		return elastic.SearchResult{}, fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
 	}
 
+	query, err := data.SearchByTextInSpacesQuery(spaceIDs)
+	if err != nil {
+		return elastic.SearchResult{}, fmt.Errorf("error while creating query for search note in spaces: %w", err)
+	}
+
+	return c.search(ctx, data.Index, query, page)
+}
+
+func (c *Client) search(ctx context.Context, index elastic.ElasticIndex, query *search.Request, page elastic.Page) (elastic.SearchResult, error) {
 	res, err := c.cl.Search().
-		Index(data.Index.String()).
-		Request(query).Do(ctx)
+		Index(index.String()).
+		TypedKeys(true). // без этого агрегации не разберутся в типизированные структуры
+		Request(elastic.WithPage(query, page)).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error searching note: %+v", err)
+		return elastic.SearchResult{}, fmt.Errorf("error searching note: %w", err)
 	}
 
-	var ids []uuid.UUID
+	var result elastic.SearchResult
+
+	if res.Hits.Total != nil {
+		result.Total = res.Hits.Total.Value
+	}
 
 	for _, val := range res.Hits.Hits {
 		bytesJSON, err := val.Source_.MarshalJSON()
 		if err != nil {
-			return nil, fmt.Errorf("error marshalling JSON while searching notes: %+v", err)
+			return elastic.SearchResult{}, fmt.Errorf("error marshalling JSON while searching notes: %+v", err)
 		}
 
 		var note elastic.Note
 		err = json.Unmarshal(bytesJSON, &note)
 		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON while searching notes: %+v", err)
+			return elastic.SearchResult{}, fmt.Errorf("error unmarshalling JSON while searching notes: %+v", err)
+		}
+
+		hit := elastic.SearchHit{
+			ID:         note.ID,
+			Highlights: val.Highlight["Text"],
+			Sort:       val.Sort,
+		}
+
+		if val.Score_ != nil {
+			hit.Score = float64(*val.Score_)
 		}
 
-		ids = append(ids, note.ID)
+		result.Hits = append(result.Hits, hit)
 	}
 
-	if len(ids) == 0 {
-		return nil, ErrRecordsNotFound
+	if result.Total == 0 {
+		return elastic.SearchResult{}, ErrRecordsNotFound
+	}
+
+	result.Spaces, err = spaceCounts(res.Aggregations[elastic.SpacesAggregation])
+	if err != nil {
+		return elastic.SearchResult{}, fmt.Errorf("error parsing spaces aggregation: %+v", err)
+	}
+
+	return result, nil
+}
+
+// spaceCounts разбирает агрегацию с количеством найденных записей по пространствам
+func spaceCounts(agg types.Aggregate) ([]elastic.SpaceCount, error) {
+	terms, ok := agg.(*types.StringTermsAggregate)
+	if !ok {
+		return nil, nil
+	}
+
+	var buckets []types.StringTermsBucket
+
+	switch val := terms.Buckets.(type) {
+	case []types.StringTermsBucket:
+		buckets = val
+	case map[string]types.StringTermsBucket:
+		for _, bucket := range val {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	res := make([]elastic.SpaceCount, 0, len(buckets))
+
+	for _, bucket := range buckets {
+		id, err := uuid.Parse(fmt.Sprint(bucket.Key))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, elastic.SpaceCount{SpaceID: id, Count: bucket.DocCount})
 	}
 
-	return ids, nil
+	return res, nil
 }
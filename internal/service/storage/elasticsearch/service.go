@@ -4,58 +4,170 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 	"webserver/internal/model/elastic"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/sirupsen/logrus"
 )
 
+// SupportedMajorVersion - мажорная версия эластика, с которой работает клиент
+const SupportedMajorVersion = 8
+
+const (
+	pingTimeout     = 10 * time.Second
+	dialTimeout     = 5 * time.Second
+	maxRetryBackoff = 5 * time.Second
+)
+
+var (
+	// ErrUnsupportedVersion - версия кластера не совпадает с версией клиента
+	ErrUnsupportedVersion = errors.New("unsupported elastic version")
+	// ErrUnavailable - кластер не отвечает
+	ErrUnavailable = errors.New("elastic is unavailable")
+)
+
+// Config - параметры подключения к кластеру
+type Config struct {
+	Addresses []string // узлы кластера
+
+	// Авторизация: логин и пароль или API ключ (если задан, логин и пароль не используются)
+	Username string
+	Password string
+	APIKey   string
+
+	CACertFile string // путь к сертификату CA в формате PEM, если кластер использует свой CA
+
+	RequestTimeout time.Duration // сколько ждем ответа на запрос. Если не задано, ждем, пока не отменят контекст
+	MaxRetries     int           // сколько раз повторять запрос на другой узел. Если не задано, 3 раза
+	RetryOnStatus  []int         // при каких статусах ответа повторять запрос. Если не заданы, 502, 503 и 504
+}
+
 type Client struct {
 	addresses []string
 	cl        *elasticsearch.TypedClient
 }
 
-func New(addresses []string) (*Client, error) {
-	cl, err := elasticsearch.NewTypedClient(elasticsearch.Config{
-		Addresses: addresses,
-	})
+// New подключается к кластеру и проверяет, что его версия поддерживается клиентом
+func New(ctx context.Context, cfg Config) (*Client, error) {
+	c, err := Open(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	c := &Client{cl: cl, addresses: addresses}
-
-	logrus.Infof("sucessfully connected elastic on %v", addresses)
+	if err := c.CheckVersion(ctx); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
 
-var ErrRecordsNotFound = errors.New(`records not found in elastic`)
+// Open создает клиент, не обращаясь к кластеру. Перед работой с кластером нужно проверить его версию (CheckVersion)
+func Open(cfg Config) (*Client, error) {
+	esCfg, err := clientConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-// getElasticID ищет запись в elasticSearch по тексту и userID. Возвращает id в elastic search
-func (c *Client) getElasticID(ctx context.Context, data elastic.Data) (string, error) {
-	_, err := data.ValidateNote()
+	cl, err := elasticsearch.NewTypedClient(esCfg)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	req, err := data.SearchByIDQuery()
+	return &Client{cl: cl, addresses: cfg.Addresses}, nil
+}
+
+// clientConfig переводит параметры подключения в конфиг клиента эластика
+func clientConfig(cfg Config) (elasticsearch.Config, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout}).DialContext
+	transport.ResponseHeaderTimeout = cfg.RequestTimeout
+
+	esCfg := elasticsearch.Config{
+		Addresses:     cfg.Addresses,
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		APIKey:        cfg.APIKey,
+		MaxRetries:    cfg.MaxRetries,
+		RetryOnStatus: cfg.RetryOnStatus,
+		RetryBackoff:  retryBackoff,
+		Transport:     transport,
+	}
+
+	if len(cfg.CACertFile) > 0 {
+		cert, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return elasticsearch.Config{}, fmt.Errorf("error reading elastic CA certificate: %w", err)
+		}
+
+		esCfg.CACert = cert
+	}
+
+	return esCfg, nil
+}
+
+// retryBackoff - пауза перед повтором запроса: 100ms, 200ms, 400ms... но не больше maxRetryBackoff
+func retryBackoff(attempt int) time.Duration {
+	backoff := 100 * time.Millisecond << (attempt - 1)
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// CheckVersion запрашивает информацию о кластере и проверяет, что его мажорная версия совпадает с версией клиента.
+// Так несовместимый кластер обнаружится при запуске, а не на первом запросе.
+// Если кластер не отвечает, возвращает ErrUnavailable, если версия не та - ErrUnsupportedVersion
+func (c *Client) CheckVersion(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	info, err := c.cl.Info().Do(ctx)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("%w: error connecting elastic on %v: %w", ErrUnavailable, c.addresses, err)
 	}
 
-	res, err := c.cl.Search().
-		Index(data.Index.String()).
-		Request(req).Do(ctx)
+	major, err := majorVersion(info.Version.Int)
 	if err != nil {
-		return "", fmt.Errorf("error searching note: %+v", err)
+		return err
 	}
 
-	if len(res.Hits.Hits) == 0 {
-		return "", ErrRecordsNotFound
+	if major != SupportedMajorVersion {
+		return fmt.Errorf("%w: cluster `%s` has version %s, expected %d.x", ErrUnsupportedVersion, info.ClusterName, info.Version.Int, SupportedMajorVersion)
 	}
 
-	elasticID := res.Hits.Hits[0].Id_
+	logrus.Infof("sucessfully connected elastic cluster `%s` (version %s) on %v", info.ClusterName, info.Version.Int, c.addresses)
+
+	return nil
+}
+
+// majorVersion возвращает мажорную версию из номера версии вида 8.17.1
+func majorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+
+	res, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("%w: cannot parse version `%s`", ErrUnsupportedVersion, version)
+	}
+
+	return res, nil
+}
+
+var ErrRecordsNotFound = errors.New(`records not found in elastic`)
+
+// getElasticID ищет запись в elasticSearch по ID из базы. Возвращает id в elastic search.
+// Если документов у записи несколько, возвращает первый
+func (c *Client) getElasticID(ctx context.Context, data elastic.Data) (string, error) {
+	ids, err := c.SearchByID(ctx, data)
+	if err != nil {
+		return "", err
+	}
 
-	return *elasticID, nil
+	return ids[0], nil
 }
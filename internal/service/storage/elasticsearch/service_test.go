@@ -0,0 +1,115 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	type test struct {
+		name    string
+		version string
+		status  int
+		err     error
+	}
+
+	tests := []test{
+		{
+			name:    "positive case",
+			version: "8.17.1",
+		},
+		{
+			name:    "error case: old cluster",
+			version: "7.17.22",
+			err:     ErrUnsupportedVersion,
+		},
+		{
+			name:    "error case: unparsable version",
+			version: "latest",
+			err:     ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, _ := startFakeElastic(t, map[string]fakeResponse{
+				"GET /": {Body: fmt.Sprintf(fakeInfoResponse, tt.version)},
+			})
+
+			cl, err := New(context.Background(), Config{Addresses: []string{url}})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Nil(t, cl)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []string{url}, cl.addresses)
+			}
+		})
+	}
+
+	t.Run("error case: cluster unavailable", func(t *testing.T) {
+		url, _ := startFakeElastic(t, map[string]fakeResponse{
+			"GET /": {Status: http.StatusServiceUnavailable, Body: `{"error":{"type":"unavailable","reason":"starting"},"status":503}`},
+		})
+
+		_, err := New(context.Background(), Config{Addresses: []string{url}, MaxRetries: 1, RetryOnStatus: []int{502}})
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.ErrorContains(t, err, "error connecting elastic")
+	})
+
+	t.Run("error case: CA file not found", func(t *testing.T) {
+		_, err := New(context.Background(), Config{Addresses: []string{"http://localhost:9200"}, CACertFile: "testdata/missing.pem"})
+		assert.ErrorContains(t, err, "error reading elastic CA certificate")
+	})
+}
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		header string
+	}{
+		{
+			name:   "basic auth",
+			cfg:    Config{Username: "elastic", Password: "secret"},
+			header: "Basic ZWxhc3RpYzpzZWNyZXQ=",
+		},
+		{
+			name:   "api key overrides basic auth",
+			cfg:    Config{Username: "elastic", Password: "secret", APIKey: "a2V5"},
+			header: "APIKey a2V5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, fake := startFakeElastic(t, map[string]fakeResponse{
+				"POST /notes/_bulk": {Body: `{"took":1,"errors":false,"items":[]}`},
+			})
+
+			tt.cfg.Addresses = []string{url}
+
+			cl, err := New(context.Background(), tt.cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, cl.BulkDelete(context.Background(), "notes", []string{"a"}))
+
+			requests := fake.Requests()
+			require.Len(t, requests, 1)
+			assert.Equal(t, tt.header, requests[0].Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, retryBackoff(1))
+	assert.Equal(t, 400*time.Millisecond, retryBackoff(3))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(10))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(100))
+}
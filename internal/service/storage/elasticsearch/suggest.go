@@ -0,0 +1,62 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"webserver/internal/model/elastic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// suggestRequestTimeout - сколько ждем ответа эластика с подсказками, с запасом на сеть поверх elastic.SuggestTimeout
+const suggestRequestTimeout = elastic.SuggestTimeout + elastic.SuggestTimeout/2
+
+// Suggest возвращает подсказки для введенного текста: заметки, в которых есть слова, начинающиеся с введенных.
+// Если подходящих заметок нет, возвращает пустой список
+func (c *Client) Suggest(ctx context.Context, data elastic.Data, size int) ([]elastic.Suggestion, error) {
+	if data.Index != elastic.NoteIndex {
+		return nil, fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
+	}
+
+	query, err := data.SuggestQuery(size)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating query for suggest: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestRequestTimeout)
+	defer cancel()
+
+	res, err := c.cl.Search().
+		Index(data.Index.String()).
+		Request(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error searching suggestions: %w", err)
+	}
+
+	if res.TimedOut {
+		logrus.Warnf("Elastic: suggest timed out, returning %d suggestions", len(res.Hits.Hits))
+	}
+
+	suggestions := make([]elastic.Suggestion, 0, len(res.Hits.Hits))
+
+	for _, val := range res.Hits.Hits {
+		var note elastic.Note
+		if err := json.Unmarshal(val.Source_, &note); err != nil {
+			return nil, fmt.Errorf("error unmarshalling JSON while searching suggestions: %+v", err)
+		}
+
+		suggestion := elastic.Suggestion{
+			ID:   note.ID,
+			Type: note.Type,
+		}
+
+		if snippets := val.Highlight["Text"]; len(snippets) > 0 {
+			suggestion.Snippet = snippets[0]
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,28 @@
+package local
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultTTL - сколько запись живет в локальном кэше, если не задано в конфиге. Ограничивает,
+// как долго экземпляр может отдавать устаревшие данные, если пропустил сообщение об удалении
+const DefaultTTL = 30 * time.Second
+
+// Config - параметры локального кэша
+type Config struct {
+	Size int           // сколько записей хранить в каждом кэше
+	TTL  time.Duration // сколько хранить запись
+}
+
+func (c Config) validate() (Config, error) {
+	if c.Size <= 0 {
+		return c, errors.New("size must be positive")
+	}
+
+	if c.TTL == 0 {
+		c.TTL = DefaultTTL
+	}
+
+	return c, nil
+}
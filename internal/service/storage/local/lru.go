@@ -0,0 +1,157 @@
+package local
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru - потокобезопасный кэш в памяти ограниченного размера: при переполнении вытесняются записи,
+// к которым дольше всего не обращались. Записи старше ttl считаются отсутствующими
+type lru[K comparable, V any] struct {
+	name string // имя кэша в метриках
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	// groupOf возвращает группу записи: записи одной группы удаляются разом (RemoveGroup), не перебирая весь кэш.
+	// Если не задана, группы не ведутся
+	groupOf func(K) any
+
+	mu     sync.Mutex
+	items  map[K]*list.Element
+	order  *list.List // в начале - последние использованные записи
+	groups map[any]map[K]struct{}
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+func newLRU[K comparable, V any](name string, size int, ttl time.Duration) *lru[K, V] {
+	return &lru[K, V]{
+		name:  name,
+		size:  size,
+		ttl:   ttl,
+		now:   time.Now,
+		items: make(map[K]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+// newGroupedLRU создает кэш, записи которого можно удалять группами (см. groupOf)
+func newGroupedLRU[K comparable, V any](name string, size int, ttl time.Duration, groupOf func(K) any) *lru[K, V] {
+	c := newLRU[K, V](name, size, ttl)
+	c.groupOf = groupOf
+	c.groups = map[any]map[K]struct{}{}
+
+	return c
+}
+
+func (c *lru[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		requests.WithLabelValues(c.name, "miss").Inc()
+
+		var zero V
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+
+	if !c.now().Before(e.expires) {
+		c.remove(el)
+		evictions.WithLabelValues(c.name, "expired").Inc()
+		requests.WithLabelValues(c.name, "miss").Inc()
+
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+	requests.WithLabelValues(c.name, "hit").Inc()
+
+	return e.value, true
+}
+
+func (c *lru[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(el)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+
+	if c.groupOf != nil {
+		group := c.groupOf(key)
+		if c.groups[group] == nil {
+			c.groups[group] = map[K]struct{}{}
+		}
+
+		c.groups[group][key] = struct{}{}
+	}
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		evictions.WithLabelValues(c.name, "size").Inc()
+	}
+}
+
+// Remove удаляет запись по ключу
+func (c *lru[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+		evictions.WithLabelValues(c.name, "invalidated").Inc()
+	}
+}
+
+// RemoveGroup удаляет все записи группы group
+func (c *lru[K, V]) RemoveGroup(group any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.groups[group] {
+		c.remove(c.items[key])
+		evictions.WithLabelValues(c.name, "invalidated").Inc()
+	}
+}
+
+func (c *lru[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *lru[K, V]) remove(el *list.Element) {
+	key := el.Value.(*entry[K, V]).key
+
+	c.order.Remove(el)
+	delete(c.items, key)
+
+	if c.groupOf != nil {
+		group := c.groupOf(key)
+
+		delete(c.groups[group], key)
+
+		if len(c.groups[group]) == 0 {
+			delete(c.groups, group)
+		}
+	}
+}
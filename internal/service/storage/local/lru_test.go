@@ -0,0 +1,115 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("least recently used evicted", func(t *testing.T) {
+		c := newLRU[int, string]("test_size", 2, time.Minute)
+		evicted := testutil.ToFloat64(evictions.WithLabelValues("test_size", "size"))
+
+		c.Add(1, "a")
+		c.Add(2, "b")
+
+		_, ok := c.Get(1) // 2 становится самой старой записью
+		assert.True(t, ok)
+
+		c.Add(3, "c")
+
+		_, ok = c.Get(2)
+		assert.False(t, ok)
+
+		v, ok := c.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+
+		assert.Equal(t, 2, c.Len())
+		assert.Equal(t, evicted+1, testutil.ToFloat64(evictions.WithLabelValues("test_size", "size")))
+	})
+
+	t.Run("expired entry is a miss", func(t *testing.T) {
+		now := time.Now()
+
+		c := newLRU[int, string]("test_ttl", 2, time.Minute)
+		c.now = func() time.Time { return now }
+
+		c.Add(1, "a")
+
+		now = now.Add(time.Minute)
+
+		_, ok := c.Get(1)
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("update refreshes value and ttl", func(t *testing.T) {
+		now := time.Now()
+
+		c := newLRU[int, string]("test_update", 2, time.Minute)
+		c.now = func() time.Time { return now }
+
+		c.Add(1, "a")
+
+		now = now.Add(30 * time.Second)
+		c.Add(1, "b")
+
+		now = now.Add(45 * time.Second)
+
+		v, ok := c.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "b", v)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		c := newGroupedLRU[int, string]("test_remove", 10, time.Minute, func(key int) any { return key % 2 })
+
+		for i := range 5 {
+			c.Add(i, "v")
+		}
+
+		c.Remove(0)
+		c.RemoveGroup(1)
+
+		assert.Equal(t, 2, c.Len())
+
+		for _, key := range []int{2, 4} {
+			_, ok := c.Get(key)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("groups follow evictions", func(t *testing.T) {
+		c := newGroupedLRU[int, string]("test_groups", 2, time.Minute, func(key int) any { return key % 2 })
+
+		c.Add(1, "a")
+		c.Add(2, "b")
+		c.Add(3, "c") // вытесняет 1
+
+		assert.Equal(t, map[any]map[int]struct{}{0: {2: {}}, 1: {3: {}}}, c.groups)
+
+		c.RemoveGroup(1)
+		c.RemoveGroup(5) // пустая группа
+
+		assert.Equal(t, map[any]map[int]struct{}{0: {2: {}}}, c.groups)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("hits and misses counted", func(t *testing.T) {
+		c := newLRU[int, string]("test_metrics", 2, time.Minute)
+		hits := testutil.ToFloat64(requests.WithLabelValues("test_metrics", "hit"))
+		misses := testutil.ToFloat64(requests.WithLabelValues("test_metrics", "miss"))
+
+		c.Add(1, "a")
+		c.Get(1)
+		c.Get(1)
+		c.Get(2)
+
+		assert.Equal(t, hits+2, testutil.ToFloat64(requests.WithLabelValues("test_metrics", "hit")))
+		assert.Equal(t, misses+1, testutil.ToFloat64(requests.WithLabelValues("test_metrics", "miss")))
+	})
+}
@@ -0,0 +1,23 @@
+package local
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// метрики локального кэша отдаются сервером на /metrics вместе с метриками http
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "webserver",
+		Subsystem: "local_cache",
+		Name:      "requests_total",
+		Help:      "Обращения к локальному кэшу по кэшу (spaces, members, users) и результату (hit, miss)",
+	}, []string{"cache", "result"})
+
+	evictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "webserver",
+		Subsystem: "local_cache",
+		Name:      "evictions_total",
+		Help:      "Удаления из локального кэша по кэшу и причине (size, expired, invalidated)",
+	}, []string{"cache", "reason"})
+)
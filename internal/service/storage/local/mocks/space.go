@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./space.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	model "webserver/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSpaceStore is a mock of SpaceStore interface.
+type MockSpaceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpaceStoreMockRecorder
+}
+
+// MockSpaceStoreMockRecorder is the mock recorder for MockSpaceStore.
+type MockSpaceStoreMockRecorder struct {
+	mock *MockSpaceStore
+}
+
+// NewMockSpaceStore creates a new mock instance.
+func NewMockSpaceStore(ctrl *gomock.Controller) *MockSpaceStore {
+	mock := &MockSpaceStore{ctrl: ctrl}
+	mock.recorder = &MockSpaceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpaceStore) EXPECT() *MockSpaceStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteMembers mocks base method.
+func (m *MockSpaceStore) DeleteMembers(ctx context.Context, spaceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembers", ctx, spaceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMembers indicates an expected call of DeleteMembers.
+func (mr *MockSpaceStoreMockRecorder) DeleteMembers(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembers", reflect.TypeOf((*MockSpaceStore)(nil).DeleteMembers), ctx, spaceID)
+}
+
+// DeleteSpace mocks base method.
+func (m *MockSpaceStore) DeleteSpace(ctx context.Context, spaceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSpace", ctx, spaceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSpace indicates an expected call of DeleteSpace.
+func (mr *MockSpaceStoreMockRecorder) DeleteSpace(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSpace", reflect.TypeOf((*MockSpaceStore)(nil).DeleteSpace), ctx, spaceID)
+}
+
+// IsMember mocks base method.
+func (m *MockSpaceStore) IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(context.Context, uuid.UUID) ([]int64, error)) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMember", ctx, spaceID, userID, load)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMember indicates an expected call of IsMember.
+func (mr *MockSpaceStoreMockRecorder) IsMember(ctx, spaceID, userID, load interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockSpaceStore)(nil).IsMember), ctx, spaceID, userID, load)
+}
+
+// LoadSpace mocks base method.
+func (m *MockSpaceStore) LoadSpace(ctx context.Context, id uuid.UUID, load func(context.Context, uuid.UUID) (model.Space, error)) (model.Space, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSpace", ctx, id, load)
+	ret0, _ := ret[0].(model.Space)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSpace indicates an expected call of LoadSpace.
+func (mr *MockSpaceStoreMockRecorder) LoadSpace(ctx, id, load interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSpace", reflect.TypeOf((*MockSpaceStore)(nil).LoadSpace), ctx, id, load)
+}
@@ -0,0 +1,122 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"webserver/internal/model"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+)
+
+// SpaceStore - общий для всех экземпляров кэш пространств (редис), перед которым стоит локальный кэш
+//
+//go:generate mockgen -source ./space.go -destination=./mocks/space.go -package=mocks
+type SpaceStore interface {
+	LoadSpace(ctx context.Context, id uuid.UUID, load func(ctx context.Context, id uuid.UUID) (model.Space, error)) (model.Space, error)
+	IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(ctx context.Context, spaceID uuid.UUID) ([]int64, error)) (bool, error)
+	DeleteSpace(ctx context.Context, spaceID uuid.UUID) error
+	DeleteMembers(ctx context.Context, spaceID uuid.UUID) error
+}
+
+type member struct {
+	spaceID uuid.UUID
+	userID  int64
+}
+
+// SpaceCache - кэш пространств и участников в памяти экземпляра перед SpaceStore.
+// Участники сгруппированы по пространствам, чтобы удалять их, не перебирая весь кэш
+type SpaceCache struct {
+	next    SpaceStore
+	spaces  *lru[uuid.UUID, model.Space]
+	members *lru[member, bool]
+	logger  *logger.Logger
+}
+
+func NewSpaceCache(next SpaceStore, cfg Config, logger *logger.Logger) (*SpaceCache, error) {
+	if next == nil {
+		return nil, errors.New("next is nil")
+	}
+
+	if logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+
+	cfg, err := cfg.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpaceCache{
+		next:    next,
+		spaces:  newLRU[uuid.UUID, model.Space]("spaces", cfg.Size, cfg.TTL),
+		members: newGroupedLRU[member, bool]("members", cfg.Size, cfg.TTL, memberSpace),
+		logger:  logger,
+	}, nil
+}
+
+func (c *SpaceCache) LoadSpace(ctx context.Context, id uuid.UUID, load func(ctx context.Context, id uuid.UUID) (model.Space, error)) (model.Space, error) {
+	if space, ok := c.spaces.Get(id); ok {
+		return space, nil
+	}
+
+	space, err := c.next.LoadSpace(ctx, id, load)
+	if err != nil {
+		return model.Space{}, err
+	}
+
+	c.spaces.Add(id, space)
+
+	return space, nil
+}
+
+func (c *SpaceCache) IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(ctx context.Context, spaceID uuid.UUID) ([]int64, error)) (bool, error) {
+	key := member{spaceID: spaceID, userID: userID}
+
+	if ok, found := c.members.Get(key); found {
+		return ok, nil
+	}
+
+	ok, err := c.next.IsMember(ctx, spaceID, userID, load)
+	if err != nil {
+		return false, err
+	}
+
+	c.members.Add(key, ok)
+
+	return ok, nil
+}
+
+// DeleteSpace удаляет пространство вместе с участниками из SpaceStore и из локального кэша.
+// Остальным экземплярам удаление не рассылается: DeleteSpace вызывается по событию об изменении, а события получает каждый экземпляр
+func (c *SpaceCache) DeleteSpace(ctx context.Context, spaceID uuid.UUID) error {
+	err := c.next.DeleteSpace(ctx, spaceID)
+
+	c.logger.WithField("space_id", spaceID).Debug("evicting space from local cache")
+	c.evictSpace(spaceID)
+
+	return err
+}
+
+// DeleteMembers удаляет участников пространства из SpaceStore и из локального кэша
+func (c *SpaceCache) DeleteMembers(ctx context.Context, spaceID uuid.UUID) error {
+	err := c.next.DeleteMembers(ctx, spaceID)
+
+	c.logger.WithField("space_id", spaceID).Debug("evicting space members from local cache")
+	c.evictMembers(spaceID)
+
+	return err
+}
+
+func (c *SpaceCache) evictSpace(spaceID uuid.UUID) {
+	c.spaces.Remove(spaceID)
+	c.evictMembers(spaceID)
+}
+
+func (c *SpaceCache) evictMembers(spaceID uuid.UUID) {
+	c.members.RemoveGroup(spaceID)
+}
+
+func memberSpace(key member) any {
+	return key.spaceID
+}
@@ -0,0 +1,210 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/service/storage/local/mocks"
+
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpaceCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := createTestLogger(t)
+	next := mocks.NewMockSpaceStore(ctrl)
+
+	type test struct {
+		name string
+		next SpaceStore
+		cfg  Config
+		err  error
+	}
+
+	tests := []test{
+		{name: "positive case", next: next, cfg: Config{Size: 10}},
+		{name: "next is nil", cfg: Config{Size: 10}, err: errors.New("next is nil")},
+		{name: "size not set", next: next, err: errors.New("size must be positive")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSpaceCache(tt.next, tt.cfg, log)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, DefaultTTL, got.spaces.ttl)
+		})
+	}
+}
+
+func TestSpaceCache_LoadSpace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockSpaceStore(ctrl)
+	c := createTestSpaceCache(t, next)
+
+	space := model.Space{ID: uuid.New(), Name: "test"}
+
+	// в редис идет только первый запрос, остальные берутся из памяти
+	next.EXPECT().LoadSpace(gomock.Any(), space.ID, gomock.Any()).Return(space, nil).Times(1)
+
+	for range 3 {
+		got, err := c.LoadSpace(context.Background(), space.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, space, got)
+	}
+
+	t.Run("error not cached", func(t *testing.T) {
+		id := uuid.New()
+
+		next.EXPECT().LoadSpace(gomock.Any(), id, gomock.Any()).Return(model.Space{}, errors.New("redis error")).Times(2)
+
+		for range 2 {
+			_, err := c.LoadSpace(context.Background(), id, nil)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestSpaceCache_IsMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockSpaceStore(ctrl)
+	c := createTestSpaceCache(t, next)
+
+	spaceID := uuid.New()
+
+	next.EXPECT().IsMember(gomock.Any(), spaceID, int64(1), gomock.Any()).Return(true, nil).Times(1)
+	next.EXPECT().IsMember(gomock.Any(), spaceID, int64(2), gomock.Any()).Return(false, nil).Times(1)
+
+	for range 2 {
+		ok, err := c.IsMember(context.Background(), spaceID, 1, nil)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = c.IsMember(context.Background(), spaceID, 2, nil)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func TestSpaceCache_Delete(t *testing.T) {
+	spaceID, otherID := uuid.New(), uuid.New()
+
+	type test struct {
+		name string
+		// del удаляет записи пространства spaceID
+		del        func(c *SpaceCache) error
+		setupMocks func(next *mocks.MockSpaceStore)
+		space      bool // пространство осталось в локальном кэше
+		err        error
+	}
+
+	tests := []test{
+		{
+			name: "delete space: space and members evicted",
+			del: func(c *SpaceCache) error {
+				return c.DeleteSpace(context.Background(), spaceID)
+			},
+			setupMocks: func(next *mocks.MockSpaceStore) {
+				next.EXPECT().DeleteSpace(gomock.Any(), spaceID).Return(nil)
+			},
+		},
+		{
+			name: "delete members: space kept",
+			del: func(c *SpaceCache) error {
+				return c.DeleteMembers(context.Background(), spaceID)
+			},
+			setupMocks: func(next *mocks.MockSpaceStore) {
+				next.EXPECT().DeleteMembers(gomock.Any(), spaceID).Return(nil)
+			},
+			space: true,
+		},
+		{
+			name: "redis error: evicted locally anyway",
+			del: func(c *SpaceCache) error {
+				return c.DeleteSpace(context.Background(), spaceID)
+			},
+			setupMocks: func(next *mocks.MockSpaceStore) {
+				next.EXPECT().DeleteSpace(gomock.Any(), spaceID).Return(errors.New("redis error"))
+			},
+			err: errors.New("redis error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			next := mocks.NewMockSpaceStore(ctrl)
+			c := createTestSpaceCache(t, next)
+
+			for _, id := range []uuid.UUID{spaceID, otherID} {
+				c.spaces.Add(id, model.Space{ID: id})
+				c.members.Add(member{spaceID: id, userID: 1}, true)
+				c.members.Add(member{spaceID: id, userID: 2}, false)
+			}
+
+			tt.setupMocks(next)
+
+			err := tt.del(c)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			_, ok := c.spaces.Get(spaceID)
+			assert.Equal(t, tt.space, ok)
+
+			_, ok = c.members.Get(member{spaceID: spaceID, userID: 1})
+			assert.False(t, ok)
+
+			_, ok = c.members.Get(member{spaceID: spaceID, userID: 2})
+			assert.False(t, ok)
+
+			// записи других пространств не удаляются
+			_, ok = c.spaces.Get(otherID)
+			assert.True(t, ok)
+
+			_, ok = c.members.Get(member{spaceID: otherID, userID: 1})
+			assert.True(t, ok)
+		})
+	}
+}
+
+func createTestSpaceCache(t *testing.T, next SpaceStore) *SpaceCache {
+	t.Helper()
+
+	c, err := NewSpaceCache(next, Config{Size: 100, TTL: time.Minute}, createTestLogger(t))
+	require.NoError(t, err)
+
+	return c
+}
+
+func createTestLogger(t *testing.T) *logger.Logger {
+	t.Helper()
+
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	return log.WithService("local_cache")
+}
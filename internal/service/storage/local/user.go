@@ -0,0 +1,81 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"webserver/internal/model"
+
+	"github.com/ex-rate/logger"
+)
+
+// UserStore - общий для всех экземпляров кэш пользователей (редис), перед которым стоит локальный кэш
+//
+//go:generate mockgen -source ./user.go -destination=./mocks/user.go -package=mocks
+type UserStore interface {
+	LoadUser(ctx context.Context, tgID int64, load func(ctx context.Context, tgID int64) (model.User, error)) (model.User, error)
+	DeleteUser(ctx context.Context, tgID int64) error
+}
+
+// UserCache - кэш пользователей в памяти экземпляра перед UserStore: пользователь проверяется на каждом запросе
+type UserCache struct {
+	next   UserStore
+	users  *lru[int64, model.User]
+	logger *logger.Logger
+}
+
+func NewUserCache(next UserStore, cfg Config, logger *logger.Logger) (*UserCache, error) {
+	if next == nil {
+		return nil, errors.New("next is nil")
+	}
+
+	if logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+
+	cfg, err := cfg.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserCache{
+		next:   next,
+		users:  newLRU[int64, model.User]("users", cfg.Size, cfg.TTL),
+		logger: logger,
+	}, nil
+}
+
+func (c *UserCache) LoadUser(ctx context.Context, tgID int64, load func(ctx context.Context, tgID int64) (model.User, error)) (model.User, error) {
+	if user, ok := c.users.Get(tgID); ok {
+		return copyUser(user), nil
+	}
+
+	user, err := c.next.LoadUser(ctx, tgID, load)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	c.users.Add(tgID, copyUser(user))
+
+	return user, nil
+}
+
+// DeleteUser удаляет пользователя из UserStore и из локального кэша. Остальным экземплярам удаление не рассылается:
+// DeleteUser вызывается по событию об изменении, а события получает каждый экземпляр
+func (c *UserCache) DeleteUser(ctx context.Context, tgID int64) error {
+	err := c.next.DeleteUser(ctx, tgID)
+
+	c.logger.WithField("tg_id", tgID).Debug("evicting user from local cache")
+	c.users.Remove(tgID)
+
+	return err
+}
+
+// copyUser копирует пользователя вместе с личным пространством, чтобы вызывающий код не менял запись в кэше
+func copyUser(user model.User) model.User {
+	if user.PersonalSpace != nil {
+		space := *user.PersonalSpace
+		user.PersonalSpace = &space
+	}
+
+	return user
+}
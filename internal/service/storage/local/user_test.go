@@ -0,0 +1,107 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/service/storage/local/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserCache_LoadUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	next := mocks.NewMockUserStore(ctrl)
+	c := createTestUserCache(t, next)
+
+	spaceID := uuid.New()
+	user := model.User{ID: 1, TgID: 123, PersonalSpace: &model.Space{ID: spaceID}}
+
+	next.EXPECT().LoadUser(gomock.Any(), int64(123), gomock.Any()).Return(user, nil).Times(1)
+
+	got, err := c.LoadUser(context.Background(), 123, nil)
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	// изменения у вызывающего кода не попадают в кэш
+	got.PersonalSpace.Name = "changed"
+
+	got, err = c.LoadUser(context.Background(), 123, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.User{ID: 1, TgID: 123, PersonalSpace: &model.Space{ID: spaceID}}, got)
+}
+
+func TestUserCache_Delete(t *testing.T) {
+	type test struct {
+		name       string
+		del        func(c *UserCache) error
+		setupMocks func(next *mocks.MockUserStore)
+		err        error
+	}
+
+	tests := []test{
+		{
+			name: "delete user",
+			del: func(c *UserCache) error {
+				return c.DeleteUser(context.Background(), 123)
+			},
+			setupMocks: func(next *mocks.MockUserStore) {
+				next.EXPECT().DeleteUser(gomock.Any(), int64(123)).Return(nil)
+			},
+		},
+		{
+			name: "redis error: evicted locally anyway",
+			del: func(c *UserCache) error {
+				return c.DeleteUser(context.Background(), 123)
+			},
+			setupMocks: func(next *mocks.MockUserStore) {
+				next.EXPECT().DeleteUser(gomock.Any(), int64(123)).Return(errors.New("redis error"))
+			},
+			err: errors.New("redis error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			next := mocks.NewMockUserStore(ctrl)
+			c := createTestUserCache(t, next)
+
+			c.users.Add(123, model.User{ID: 1, TgID: 123})
+			c.users.Add(456, model.User{ID: 2, TgID: 456})
+
+			tt.setupMocks(next)
+
+			err := tt.del(c)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			_, ok := c.users.Get(123)
+			assert.False(t, ok)
+
+			_, ok = c.users.Get(456)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func createTestUserCache(t *testing.T, next UserStore) *UserCache {
+	t.Helper()
+
+	c, err := NewUserCache(next, Config{Size: 100, TTL: time.Minute}, createTestLogger(t))
+	require.NoError(t, err)
+
+	return c
+}
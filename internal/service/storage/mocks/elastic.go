@@ -51,18 +51,48 @@ func (mr *MockelasticClientMockRecorder) Save(ctx, search interface{}) *gomock.C
 }
 
 // SearchByText mocks base method.
-func (m *MockelasticClient) SearchByText(ctx context.Context, search elastic.Data) ([]uuid.UUID, error) {
+func (m *MockelasticClient) SearchByText(ctx context.Context, search elastic.Data, page elastic.Page) (elastic.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByText", ctx, search)
-	ret0, _ := ret[0].([]uuid.UUID)
+	ret := m.ctrl.Call(m, "SearchByText", ctx, search, page)
+	ret0, _ := ret[0].(elastic.SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchByText indicates an expected call of SearchByText.
-func (mr *MockelasticClientMockRecorder) SearchByText(ctx, search interface{}) *gomock.Call {
+func (mr *MockelasticClientMockRecorder) SearchByText(ctx, search, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByText", reflect.TypeOf((*MockelasticClient)(nil).SearchByText), ctx, search)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByText", reflect.TypeOf((*MockelasticClient)(nil).SearchByText), ctx, search, page)
+}
+
+// SearchByTextInSpaces mocks base method.
+func (m *MockelasticClient) SearchByTextInSpaces(ctx context.Context, search elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByTextInSpaces", ctx, search, spaceIDs, page)
+	ret0, _ := ret[0].(elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByTextInSpaces indicates an expected call of SearchByTextInSpaces.
+func (mr *MockelasticClientMockRecorder) SearchByTextInSpaces(ctx, search, spaceIDs, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTextInSpaces", reflect.TypeOf((*MockelasticClient)(nil).SearchByTextInSpaces), ctx, search, spaceIDs, page)
+}
+
+// Suggest mocks base method.
+func (m *MockelasticClient) Suggest(ctx context.Context, search elastic.Data, size int) ([]elastic.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, search, size)
+	ret0, _ := ret[0].([]elastic.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockelasticClientMockRecorder) Suggest(ctx, search, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockelasticClient)(nil).Suggest), ctx, search, size)
 }
 
 // UpdateNote mocks base method.
@@ -0,0 +1,62 @@
+package fulltext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/storage/postgres/pool"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Repo - полнотекстовый поиск заметок средствами постгреса (tsvector + pg_trgm).
+// Используется вместо эластика, когда он недоступен или выключен в конфиге.
+// Ищет прямо в таблице заметок, поэтому индексировать ничего не нужно: методы записи ничего не делают
+type Repo struct {
+	db   *pool.DB
+	trgm bool // установлено расширение pg_trgm: кроме совпадения слов ищем по их сходству (опечатки)
+}
+
+func New(ctx context.Context, db *pool.DB) (*Repo, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
+	}
+
+	repo := &Repo{db: db}
+
+	err := db.Primary().QueryRowContext(ctx, "select exists(select 1 from pg_extension where extname = 'pg_trgm')").Scan(&repo.trgm)
+	if err != nil {
+		return nil, fmt.Errorf("error checking pg_trgm extension: %w", err)
+	}
+
+	if !repo.trgm {
+		logrus.Warn("Postgres: extension pg_trgm is not installed, full-text search will not tolerate typos")
+	}
+
+	return repo, nil
+}
+
+// Save ничего не делает: заметка уже сохранена в базе, где ее и будет искать поиск
+func (db *Repo) Save(ctx context.Context, data elastic.Data) error {
+	return nil
+}
+
+// UpdateNote ничего не делает: поиск берет текст прямо из базы
+func (db *Repo) UpdateNote(ctx context.Context, data elastic.Data) error {
+	return nil
+}
+
+// note достает заметку из запроса на поиск
+func note(data elastic.Data) (elastic.Note, error) {
+	if data.Index != elastic.NoteIndex {
+		return elastic.Note{}, fmt.Errorf("index is not equal to `notes`: `%s`", data.Index)
+	}
+
+	n, ok := data.Model.(*elastic.Note)
+	if !ok {
+		return elastic.Note{}, fmt.Errorf("unexpected search model: %T", data.Model)
+	}
+
+	return *n, nil
+}
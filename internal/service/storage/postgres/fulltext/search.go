@@ -0,0 +1,330 @@
+package fulltext
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/storage/elasticsearch"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// конфигурация полнотекстового поиска: та же морфология, что и у анализатора в эластике
+const textSearchConfig = "russian"
+
+// параметры подсветки совпадений в тексте, как у эластика (см. elastic.WithPage)
+var headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=3, MaxWords=25, MinWords=10",
+	elastic.HighlightPreTag, elastic.HighlightPostTag)
+
+// SearchByText производит поиск по тексту в пространстве заметки. Возвращает страницу подходящих записей,
+// отсортированную по релевантности, с оценкой и подсвеченными фрагментами текста
+func (db *Repo) SearchByText(ctx context.Context, data elastic.Data, page elastic.Page) (elastic.SearchResult, error) {
+	n, err := note(data)
+	if err != nil {
+		return elastic.SearchResult{}, err
+	}
+
+	return db.search(ctx, n, []uuid.UUID{n.SpaceID}, false, data.Filter, page)
+}
+
+// SearchByTextInSpaces производит поиск по тексту сразу в нескольких пространствах.
+// Помимо страницы результатов возвращает, сколько записей нашлось в каждом пространстве
+func (db *Repo) SearchByTextInSpaces(ctx context.Context, data elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error) {
+	n, err := note(data)
+	if err != nil {
+		return elastic.SearchResult{}, err
+	}
+
+	if len(spaceIDs) == 0 {
+		return elastic.SearchResult{}, elastic.ErrSpacesNotFilled
+	}
+
+	return db.search(ctx, n, spaceIDs, true, data.Filter, page)
+}
+
+func (db *Repo) search(ctx context.Context, n elastic.Note, spaceIDs []uuid.UUID, countSpaces bool, filter elastic.SearchFilter, page elastic.Page) (elastic.SearchResult, error) {
+	logrus.WithField("spaces", len(spaceIDs)).Debug("searching notes in postgres")
+
+	query, err := elastic.ParseSearchQuery(n.Text)
+	if err != nil {
+		return elastic.SearchResult{}, err
+	}
+
+	q, args, err := searchQuery(query, spaceIDs, countSpaces, query.ApplyTo(filter.WithType(n.Type)), page, db.trgm)
+	if err != nil {
+		return elastic.SearchResult{}, err
+	}
+
+	rows, err := db.db.Reader().QueryContext(ctx, q, args...)
+	if err != nil {
+		return elastic.SearchResult{}, fmt.Errorf("error searching notes in postgres: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		result elastic.SearchResult
+		spaces []byte
+	)
+
+	for rows.Next() {
+		var (
+			id       uuid.NullUUID
+			rank     sql.NullFloat64
+			headline sql.NullString
+		)
+
+		if err := rows.Scan(&result.Total, &spaces, &id, &rank, &headline); err != nil {
+			return elastic.SearchResult{}, fmt.Errorf("error scanning found note: %w", err)
+		}
+
+		// страница пустая: строка только с количеством найденных записей
+		if !id.Valid {
+			continue
+		}
+
+		hit := elastic.SearchHit{
+			ID:    id.UUID,
+			Score: rank.Float64,
+			Sort:  []types.FieldValue{rank.Float64, id.UUID.String()},
+		}
+
+		if strings.Contains(headline.String, elastic.HighlightPreTag) {
+			hit.Highlights = []string{headline.String}
+		}
+
+		result.Hits = append(result.Hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return elastic.SearchResult{}, fmt.Errorf("error searching notes in postgres: %w", err)
+	}
+
+	if result.Total == 0 {
+		return elastic.SearchResult{}, elasticsearch.ErrRecordsNotFound
+	}
+
+	result.Spaces, err = spaceCounts(spaces)
+	if err != nil {
+		return elastic.SearchResult{}, fmt.Errorf("error parsing spaces counts: %w", err)
+	}
+
+	return result, nil
+}
+
+// searchQuery возвращает запрос на поиск заметок и его аргументы.
+// Запрос всегда возвращает хотя бы одну строку: количество найденных записей (и количество по пространствам,
+// если нужно), а к ней - найденные записи страницы (если есть), отсортированные по релевантности и id, как в эластике
+func searchQuery(query elastic.SearchQuery, spaceIDs []uuid.UUID, countSpaces bool, filter elastic.SearchFilter, page elastic.Page, trgm bool) (string, []any, error) {
+	var (
+		rank, headline         = "0::real", "null::text"
+		rankArgs, headlineArgs []any
+		where                  = []string{"notes.notes.deleted_at is null", "notes.notes.space_id in (?)"}
+		whereArgs              = []any{spaceIDs}
+	)
+
+	// без слов и фраз (только исключения и операторы) подходят все заметки, релевантность у всех одинаковая
+	if tsq, tsArgs := tsQuery(query.Groups()); len(tsq) > 0 {
+		vector := fmt.Sprintf("to_tsvector('%s', notes.notes.text)", textSearchConfig)
+
+		rank = fmt.Sprintf("ts_rank(%s, %s)", vector, tsq)
+		rankArgs = tsArgs
+
+		match := fmt.Sprintf("%s @@ %s", vector, tsq)
+		matchArgs := tsArgs
+
+		// слова с опечатками не совпадут по словоформам, но будут похожи по триграммам
+		if trgm {
+			plain := query.Plain()
+
+			rank = "(" + rank + " + word_similarity(?, notes.notes.text))"
+			rankArgs = append(slices.Clone(tsArgs), plain)
+
+			match = "(" + match + " or ? <% notes.notes.text)"
+			matchArgs = append(slices.Clone(tsArgs), plain)
+		}
+
+		where = append(where, match)
+		whereArgs = append(whereArgs, matchArgs...)
+
+		headline = fmt.Sprintf("ts_headline('%s', text, %s, '%s')", textSearchConfig, tsq, headlineOptions)
+		headlineArgs = tsArgs
+	}
+
+	if excluded, excludedArgs := tsQuery(exclusionGroups(query.Exclusions())); len(excluded) > 0 {
+		where = append(where, fmt.Sprintf("not to_tsvector('%s', notes.notes.text) @@ %s", textSearchConfig, excluded))
+		whereArgs = append(whereArgs, excludedArgs...)
+	}
+
+	filterWhere, filterArgs := filterConditions(filter)
+	where = append(where, filterWhere...)
+	whereArgs = append(whereArgs, filterArgs...)
+
+	spaces := "null::json"
+	if countSpaces {
+		spaces = "(select json_object_agg(space_id, count) from (select space_id, count(*) from hits group by space_id) s)"
+	}
+
+	cursor, cursorArgs, err := cursorCondition(page)
+	if err != nil {
+		return "", nil, err
+	}
+
+	limit, limitArgs := "limit ?", []any{page.Size}
+	if len(cursorArgs) == 0 {
+		limit, limitArgs = "limit ? offset ?", []any{page.Size, page.From}
+	}
+
+	q := fmt.Sprintf(`with hits as (
+	select notes.notes.id, notes.notes.space_id, notes.notes.text, %s as rank
+	from notes.notes
+	join users.users on users.users.id = notes.notes.user_id
+	where %s
+)
+select stats.total, stats.spaces, page.id, page.rank, page.headline
+from (select count(*) as total, %s as spaces from hits) stats
+left join lateral (
+	select id, rank, %s as headline from hits
+	where %s
+	order by rank desc, id
+	%s
+) page on true
+order by page.rank desc, page.id;`, rank, strings.Join(where, "\n\tand "), spaces, headline, cursor, limit)
+
+	// аргументы в том же порядке, в котором их места встречаются в запросе
+	q, args, err := sqlx.In(q, slices.Concat(rankArgs, whereArgs, headlineArgs, cursorArgs, limitArgs)...)
+	if err != nil {
+		return "", nil, fmt.Errorf("error while creating query for search note: %w", err)
+	}
+
+	return sqlx.Rebind(sqlx.DOLLAR, q), args, nil
+}
+
+// tsQuery возвращает выражение tsquery для групп условий: в группе - хотя бы одно условие, совпасть должны все группы.
+// Фразы ищутся через phraseto_tsquery (слова подряд), остальные слова - через plainto_tsquery (все слова)
+func tsQuery(groups [][]elastic.QueryTerm) (string, []any) {
+	var (
+		res  []string
+		args []any
+	)
+
+	for _, group := range groups {
+		terms := make([]string, 0, len(group))
+
+		for _, term := range group {
+			fn := "plainto_tsquery"
+			if term.Phrase {
+				fn = "phraseto_tsquery"
+			}
+
+			terms = append(terms, fmt.Sprintf("%s('%s', ?)", fn, textSearchConfig))
+			args = append(args, term.Text)
+		}
+
+		res = append(res, "("+strings.Join(terms, " || ")+")")
+	}
+
+	return strings.Join(res, " && "), args
+}
+
+// exclusionGroups собирает исключения в одну группу: заметка не должна содержать ни одного из них
+func exclusionGroups(excluded []elastic.QueryTerm) [][]elastic.QueryTerm {
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	return [][]elastic.QueryTerm{excluded}
+}
+
+// filterConditions возвращает условия фильтра для where
+func filterConditions(f elastic.SearchFilter) ([]string, []any) {
+	var (
+		res  []string
+		args []any
+	)
+
+	if len(f.Types) > 0 {
+		res = append(res, "notes.notes.type in (?)")
+		args = append(args, f.Types)
+	}
+
+	if f.CreatedFrom != nil {
+		res = append(res, "notes.notes.created >= ?")
+		args = append(args, *f.CreatedFrom)
+	}
+
+	if f.CreatedTo != nil {
+		res = append(res, "notes.notes.created <= ?")
+		args = append(args, *f.CreatedTo)
+	}
+
+	if f.AuthorID != 0 {
+		res = append(res, "users.users.tg_id = ?")
+		args = append(args, f.AuthorID)
+	}
+
+	if f.HasFile != nil {
+		if *f.HasFile {
+			res = append(res, "coalesce(notes.notes.file, '') <> ''")
+		} else {
+			res = append(res, "coalesce(notes.notes.file, '') = ''")
+		}
+	}
+
+	return res, args
+}
+
+// cursorCondition возвращает условие для страницы после курсора: значения сортировки последней записи
+// предыдущей страницы - релевантность и id. Без курсора страница задается через offset
+func cursorCondition(page elastic.Page) (string, []any, error) {
+	if len(page.SearchAfter) == 0 {
+		return "true", nil, nil
+	}
+
+	if len(page.SearchAfter) != 2 {
+		return "", nil, model.ErrInvalidSearchCursor
+	}
+
+	rank, ok := page.SearchAfter[0].(float64)
+	if !ok {
+		return "", nil, model.ErrInvalidSearchCursor
+	}
+
+	idStr, ok := page.SearchAfter[1].(string)
+	if !ok {
+		return "", nil, model.ErrInvalidSearchCursor
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return "", nil, model.ErrInvalidSearchCursor
+	}
+
+	return "(rank < ?::real or (rank = ?::real and id > ?))", []any{rank, rank, id}, nil
+}
+
+// spaceCounts разбирает количество найденных записей по пространствам: {"space_id": count}
+func spaceCounts(data []byte) ([]elastic.SpaceCount, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var counts map[uuid.UUID]int64
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, err
+	}
+
+	res := make([]elastic.SpaceCount, 0, len(counts))
+	for id, count := range counts {
+		res = append(res, elastic.SpaceCount{SpaceID: id, Count: count})
+	}
+
+	return res, nil
+}
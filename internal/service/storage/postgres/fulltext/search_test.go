@@ -0,0 +1,173 @@
+package fulltext
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQuery(t *testing.T) {
+	spaceA := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	spaceB := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := elastic.ParseSearchQuery(`купить молоко "белый хлеб" -сыр`)
+	require.NoError(t, err)
+
+	filter := elastic.SearchFilter{
+		Types:       []model.NoteType{model.TextNoteType, model.PhotoNoteType},
+		CreatedFrom: &from,
+		AuthorID:    123,
+	}
+
+	t.Run("with pg_trgm", func(t *testing.T) {
+		q, args, err := searchQuery(query, []uuid.UUID{spaceA, spaceB}, true, filter, elastic.Page{From: 20, Size: 10}, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{
+			// релевантность
+			"купить молоко", "белый хлеб", "купить молоко белый хлеб",
+			// пространства, совпадение, исключения, фильтры
+			spaceA, spaceB, "купить молоко", "белый хлеб", "купить молоко белый хлеб", "сыр",
+			model.TextNoteType, model.PhotoNoteType, from, int64(123),
+			// подсветка
+			"купить молоко", "белый хлеб",
+			// страница
+			10, 20,
+		}, args)
+
+		assertPlaceholders(t, q, len(args))
+
+		assert.Contains(t, q, "(plainto_tsquery('russian', $1)) && (phraseto_tsquery('russian', $2))")
+		assert.Contains(t, q, "word_similarity($3, notes.notes.text)")
+		assert.Contains(t, q, "$8 <% notes.notes.text")
+		assert.Contains(t, q, "not to_tsvector('russian', notes.notes.text) @@ (plainto_tsquery('russian', $9))")
+		assert.Contains(t, q, "notes.notes.type in ($10, $11)")
+		assert.Contains(t, q, "json_object_agg(space_id, count)")
+		assert.Contains(t, q, "limit $16 offset $17")
+	})
+
+	t.Run("without pg_trgm", func(t *testing.T) {
+		q, args, err := searchQuery(query, []uuid.UUID{spaceA}, false, elastic.SearchFilter{}, elastic.Page{Size: 10}, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{
+			"купить молоко", "белый хлеб",
+			spaceA, "купить молоко", "белый хлеб", "сыр",
+			"купить молоко", "белый хлеб",
+			10, 0,
+		}, args)
+
+		assertPlaceholders(t, q, len(args))
+
+		assert.NotContains(t, q, "word_similarity")
+		assert.Contains(t, q, "null::json as spaces")
+	})
+
+	t.Run("only exclusions and operators: all notes match", func(t *testing.T) {
+		query, err := elastic.ParseSearchQuery(`-сыр type:photo`)
+		require.NoError(t, err)
+
+		q, args, err := searchQuery(query, []uuid.UUID{spaceA}, false, query.ApplyTo(elastic.SearchFilter{}), elastic.Page{Size: 10}, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{spaceA, "сыр", model.PhotoNoteType, 10, 0}, args)
+
+		assertPlaceholders(t, q, len(args))
+
+		assert.Contains(t, q, "0::real as rank")
+		assert.Contains(t, q, "null::text as headline")
+	})
+
+	t.Run("page after cursor", func(t *testing.T) {
+		id := uuid.New()
+
+		q, args, err := searchQuery(query, []uuid.UUID{spaceA}, false, elastic.SearchFilter{}, elastic.Page{
+			From:        20, // при курсоре не используется
+			Size:        10,
+			SearchAfter: []types.FieldValue{0.25, id.String()},
+		}, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{0.25, 0.25, id.String(), 10}, args[len(args)-4:])
+
+		assertPlaceholders(t, q, len(args))
+
+		assert.Contains(t, q, "(rank < $9::real or (rank = $10::real and id > $11))")
+		assert.NotContains(t, q, "offset")
+	})
+}
+
+func TestCursorCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		searchAfter []types.FieldValue
+		err         error
+	}{
+		{
+			name:        "valid cursor",
+			searchAfter: []types.FieldValue{0.5, uuid.NewString()},
+		},
+		{
+			name:        "wrong length",
+			searchAfter: []types.FieldValue{0.5},
+			err:         model.ErrInvalidSearchCursor,
+		},
+		{
+			name:        "rank is not a number",
+			searchAfter: []types.FieldValue{"0.5", uuid.NewString()},
+			err:         model.ErrInvalidSearchCursor,
+		},
+		{
+			name:        "id is not uuid",
+			searchAfter: []types.FieldValue{0.5, "note"},
+			err:         model.ErrInvalidSearchCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := cursorCondition(elastic.Page{SearchAfter: tt.searchAfter})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPrefixQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "мол", want: "мол:*"},
+		{text: "Купить мол", want: "купить & мол:*"},
+		{text: "a & b | !c:*", want: "a & b & c:*"},
+		{text: "  ' & ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, prefixQuery(tt.text))
+		})
+	}
+}
+
+// assertPlaceholders проверяет, что в запросе ровно count разных мест для аргументов: $1..$count
+func assertPlaceholders(t *testing.T, q string, count int) {
+	t.Helper()
+
+	for i := 1; i <= count; i++ {
+		assert.Contains(t, q, fmt.Sprintf("$%d", i))
+	}
+
+	assert.NotContains(t, q, fmt.Sprintf("$%d", count+1))
+	assert.False(t, strings.Contains(q, "?"), "query has unbound placeholders")
+}
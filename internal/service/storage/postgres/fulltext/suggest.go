@@ -0,0 +1,79 @@
+package fulltext
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"webserver/internal/model/elastic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// suggestTimeout - сколько ищем подсказки. Подсказки нужны во время ввода, ждать их дольше нет смысла
+const suggestTimeout = 500 * time.Millisecond
+
+// параметры фрагмента текста в подсказке: один короткий фрагмент с подсвеченным совпадением
+var suggestHeadlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=1, MaxWords=15, MinWords=5",
+	elastic.HighlightPreTag, elastic.HighlightPostTag)
+
+// Suggest возвращает подсказки для введенного текста: заметки, в которых есть все введенные слова,
+// а последнее слово может быть введено не до конца. Если подходящих заметок нет, возвращает пустой список
+func (db *Repo) Suggest(ctx context.Context, data elastic.Data, size int) ([]elastic.Suggestion, error) {
+	n, err := note(data)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := prefixQuery(n.Text)
+	if len(prefix) == 0 {
+		return []elastic.Suggestion{}, nil
+	}
+
+	logrus.WithField("spaceID", n.SpaceID).Debug("suggesting notes in postgres")
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+
+	// конфигурация simple: слова не приводятся к начальной форме, иначе не найдется начало слова
+	rows, err := db.db.Reader().QueryContext(ctx, `select notes.notes.id, notes.notes.type, ts_headline('simple', notes.notes.text, q, $1)
+from notes.notes, to_tsquery('simple', $2) q
+where notes.notes.space_id = $3 and notes.notes.deleted_at is null and to_tsvector('simple', notes.notes.text) @@ q
+order by ts_rank(to_tsvector('simple', notes.notes.text), q) desc, notes.notes.created desc
+limit $4;`, suggestHeadlineOptions, prefix, n.SpaceID, size)
+	if err != nil {
+		return nil, fmt.Errorf("error searching suggestions in postgres: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]elastic.Suggestion, 0, size)
+
+	for rows.Next() {
+		var suggestion elastic.Suggestion
+
+		if err := rows.Scan(&suggestion.ID, &suggestion.Type, &suggestion.Snippet); err != nil {
+			return nil, fmt.Errorf("error scanning suggestion: %w", err)
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, rows.Err()
+}
+
+// prefixQuery переводит введенный текст в запрос для to_tsquery: все слова, последнее - как начало слова.
+// Из текста остаются только буквы и цифры, чтобы ввод не ломал синтаксис tsquery
+func prefixQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	words[len(words)-1] += ":*"
+
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,103 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+)
+
+// sqlFiles - миграции схемы базы. Файлы называются <версия>_<название>.up.sql и <версия>_<название>.down.sql,
+// версии идут по порядку с 1 без пропусков
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// Migration - одна версия схемы
+type Migration struct {
+	Version int
+	Name    string
+	Up      string // запросы, которые переводят схему на эту версию
+	Down    string // запросы, которые возвращают схему на предыдущую версию
+}
+
+var (
+	ErrInvalidMigrationName = errors.New("invalid migration file name")
+	ErrMissingMigration     = errors.New("missing migration")
+	ErrDuplicateMigration   = errors.New("duplicate migration")
+)
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Embedded возвращает миграции, встроенные в бинарник, по возрастанию версии
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return load(sub)
+}
+
+// load читает миграции из корня fsys и проверяет, что у каждой версии есть up и down файлы
+func load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+
+	for _, file := range files {
+		match := fileNameRe.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, fmt.Errorf("%w: `%s`", ErrInvalidMigrationName, file)
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: `%s`: version must be a positive number", ErrInvalidMigrationName, file)
+		}
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration `%s`: %w", file, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d: `%s` and `%s`", ErrDuplicateMigration, version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	res := make([]Migration, 0, len(byVersion))
+
+	for version := 1; version <= len(byVersion); version++ {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d", ErrMissingMigration, version)
+		}
+
+		if len(m.Up) == 0 || len(m.Down) == 0 {
+			return nil, fmt.Errorf("%w: version %d (%s) must have both up and down files", ErrMissingMigration, version, m.Name)
+		}
+
+		res = append(res, *m)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,166 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	migrations, err := Embedded()
+	require.NoError(t, err)
+
+	require.Len(t, migrations, 3)
+	assert.Equal(t, 3, latest(migrations))
+
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version)
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+
+	// корзина: колонка, по которой репозитории отличают удаленные заметки, должна быть nullable
+	assert.Contains(t, migrations[1].Up, "add column if not exists deleted_at timestamptz;")
+}
+
+func TestLoad(t *testing.T) {
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(data)}
+	}
+
+	type test struct {
+		name     string
+		fs       fstest.MapFS
+		versions []int
+		err      error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			fs: fstest.MapFS{
+				"0002_second.down.sql": file("drop table b;"),
+				"0001_first.up.sql":    file("create table a();"),
+				"0002_second.up.sql":   file("create table b();"),
+				"0001_first.down.sql":  file("drop table a;"),
+			},
+			versions: []int{1, 2},
+		},
+		{
+			name:     "no migrations",
+			fs:       fstest.MapFS{},
+			versions: []int{},
+		},
+		{
+			name: "down file missing",
+			fs: fstest.MapFS{
+				"0001_first.up.sql": file("create table a();"),
+			},
+			err: ErrMissingMigration,
+		},
+		{
+			name: "version skipped",
+			fs: fstest.MapFS{
+				"0001_first.up.sql":   file("create table a();"),
+				"0001_first.down.sql": file("drop table a;"),
+				"0003_third.up.sql":   file("create table c();"),
+				"0003_third.down.sql": file("drop table c;"),
+			},
+			err: ErrMissingMigration,
+		},
+		{
+			name: "two migrations with one version",
+			fs: fstest.MapFS{
+				"0001_first.up.sql":   file("create table a();"),
+				"0001_other.down.sql": file("drop table a;"),
+			},
+			err: ErrDuplicateMigration,
+		},
+		{
+			name: "invalid file name",
+			fs: fstest.MapFS{
+				"first.up.sql": file("create table a();"),
+			},
+			err: ErrInvalidMigrationName,
+		},
+		{
+			name: "zero version",
+			fs: fstest.MapFS{
+				"0000_zero.up.sql": file("create table a();"),
+			},
+			err: ErrInvalidMigrationName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.fs)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			versions := []int{}
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+
+			assert.Equal(t, tt.versions, versions)
+		})
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	type test struct {
+		name    string
+		version int
+		err     error
+	}
+
+	tests := []test{
+		{name: "same version", version: 3},
+		{name: "never migrated", version: 0, err: ErrSchemaOutdated},
+		{name: "not all migrations applied", version: 2, err: ErrSchemaOutdated},
+		{name: "migrated by newer server", version: 4, err: ErrSchemaTooNew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compatible(tt.version, 3)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReversible(t *testing.T) {
+	type test struct {
+		name    string
+		version int
+		err     error
+	}
+
+	tests := []test{
+		{name: "latest migration", version: 3},
+		{name: "baseline migration", version: 1, err: ErrIrreversible},
+		{name: "migrated by newer server", version: 4, err: ErrSchemaTooNew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reversible(tt.version, 3)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
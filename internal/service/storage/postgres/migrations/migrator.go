@@ -0,0 +1,327 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// lockID - ключ advisory lock, под которым применяются миграции: если несколько экземпляров
+// запустят миграции одновременно, каждую версию применит только один из них
+const lockID = 7_202_405_150
+
+var (
+	// ErrSchemaOutdated - в базе не применены миграции, которые нужны серверу
+	ErrSchemaOutdated = errors.New("db schema is outdated")
+	// ErrSchemaTooNew - база мигрирована более новой версией сервера
+	ErrSchemaTooNew = errors.New("db schema is newer than supported")
+	// ErrIrreversible - миграцию нельзя откатить
+	ErrIrreversible = errors.New("migration is irreversible")
+)
+
+// baselineVersion - первая миграция. Она принимает базы, созданные до появления миграций, поэтому ее откат
+// удалил бы все данные: откатить ее нельзя
+const baselineVersion = 1
+
+// Status - состояние одной миграции в базе
+type Status struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Applied   bool      `json:"applied"`
+	AppliedAt time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator применяет и откатывает миграции. Примененные версии хранятся в таблице public.schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB) (*Migrator, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
+	}
+
+	migrations, err := Embedded()
+	if err != nil {
+		return nil, fmt.Errorf("error loading migrations: %w", err)
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest - версия схемы, с которой работает сервер
+func (m *Migrator) Latest() int {
+	return latest(m.migrations)
+}
+
+// Version возвращает текущую версию схемы в базе. Если миграции еще не применялись, возвращает 0
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var exists bool
+
+	err := m.db.QueryRowContext(ctx, "select to_regclass('public.schema_migrations') is not null").Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("error checking migrations table: %w", err)
+	}
+
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+
+	err = m.db.QueryRowContext(ctx, "select coalesce(max(version), 0) from public.schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("error getting schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// Check проверяет, что версия схемы в базе совпадает с версией, с которой работает сервер
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	return compatible(version, m.Latest())
+}
+
+// compatible сравнивает версию схемы в базе с версией, которая нужна серверу
+func compatible(version, latest int) error {
+	switch {
+	case version < latest:
+		return fmt.Errorf("%w: version %d, expected %d: run `migrate up`", ErrSchemaOutdated, version, latest)
+	case version > latest:
+		return fmt.Errorf("%w: version %d, expected %d: update the server or run `migrate down` with the newer one", ErrSchemaTooNew, version, latest)
+	}
+
+	return nil
+}
+
+// Up применяет все непримененные миграции по порядку. Каждая миграция применяется в своей транзакции.
+// Возвращает примененные миграции
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	for _, migration := range m.migrations {
+		ok, err := m.apply(ctx, migration)
+		if err != nil {
+			return applied, fmt.Errorf("error applying migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		if ok {
+			logrus.Infof("applied migration %d (%s)", migration.Version, migration.Name)
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// Down откатывает steps последних примененных миграций. Возвращает откаченные миграции.
+// Первая миграция не откатывается: дойдя до нее, Down возвращает ErrIrreversible
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	for range steps {
+		migration, ok, err := m.revertLast(ctx)
+		if err != nil {
+			return reverted, err
+		}
+
+		if !ok {
+			break
+		}
+
+		logrus.Infof("reverted migration %d (%s)", migration.Version, migration.Name)
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status возвращает состояние всех миграций
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedAt := map[int]time.Time{}
+
+	if version > 0 {
+		rows, err := m.db.QueryContext(ctx, "select version, applied_at from public.schema_migrations")
+		if err != nil {
+			return nil, fmt.Errorf("error getting applied migrations: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				v int
+				t time.Time
+			)
+
+			if err := rows.Scan(&v, &t); err != nil {
+				return nil, fmt.Errorf("error scanning applied migration: %w", err)
+			}
+
+			appliedAt[v] = t
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]Status, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		t, ok := appliedAt[migration.Version]
+
+		res = append(res, Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: t,
+		})
+	}
+
+	return res, nil
+}
+
+// apply применяет миграцию, если она еще не применена. Возвращает true, если миграция применена сейчас
+func (m *Migrator) apply(ctx context.Context, migration Migration) (bool, error) {
+	applied := false
+
+	err := m.inLockedTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+
+		err := tx.QueryRowContext(ctx, "select exists(select 1 from public.schema_migrations where version = $1)", migration.Version).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "insert into public.schema_migrations (version, name) values ($1, $2)", migration.Version, migration.Name)
+		if err != nil {
+			return err
+		}
+
+		applied = true
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// revertLast откатывает последнюю примененную миграцию. Возвращает false, если откатывать нечего
+func (m *Migrator) revertLast(ctx context.Context) (Migration, bool, error) {
+	var (
+		migration Migration
+		reverted  bool
+	)
+
+	err := m.inLockedTx(ctx, func(tx *sql.Tx) error {
+		var version int
+
+		err := tx.QueryRowContext(ctx, "select coalesce(max(version), 0) from public.schema_migrations").Scan(&version)
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return nil
+		}
+
+		if err := reversible(version, len(m.migrations)); err != nil {
+			return err
+		}
+
+		migration = m.migrations[version-1]
+
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("error reverting migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "delete from public.schema_migrations where version = $1", version); err != nil {
+			return err
+		}
+
+		reverted = true
+
+		return nil
+	})
+
+	return migration, reverted, err
+}
+
+// inLockedTx выполняет fn в транзакции под advisory lock, создав таблицу с версиями, если ее нет
+func (m *Migrator) inLockedTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "select pg_advisory_xact_lock($1)", lockID); err != nil {
+		rollback(tx)
+		return fmt.Errorf("error acquiring migrations lock: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `create table if not exists public.schema_migrations (
+	version    integer primary key,
+	name       text not null,
+	applied_at timestamptz not null default now()
+)`)
+	if err != nil {
+		rollback(tx)
+		return fmt.Errorf("error creating migrations table: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logrus.Errorf("error rollback tx: %+v", err)
+	}
+}
+
+// reversible проверяет, что миграцию version можно откатить сервером, который знает latest миграций
+func reversible(version, latest int) error {
+	switch {
+	case version == baselineVersion:
+		return fmt.Errorf("%w: version %d creates the schema, reverting it would drop all data", ErrIrreversible, version)
+	case version > latest:
+		return fmt.Errorf("%w: version %d", ErrSchemaTooNew, version)
+	}
+
+	return nil
+}
+
+func latest(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+
+	return migrations[len(migrations)-1].Version
+}
@@ -0,0 +1,3 @@
+-- первая миграция не откатывается: она принимает базы, созданные до появления миграций,
+-- и откат удалил бы все данные. Migrator отказывается ее откатывать, а этот файл ничего не делает
+select 1;
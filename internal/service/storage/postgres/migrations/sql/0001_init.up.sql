@@ -0,0 +1,65 @@
+-- схема, с которой работает сервер. Таблицы создаются, только если их еще нет:
+-- так первая миграция подходит и для пустой базы, и для базы, созданной до появления миграций
+create schema if not exists users;
+create schema if not exists shared_spaces;
+create schema if not exists notes;
+
+create table if not exists users.users (
+    id       bigint generated by default as identity primary key,
+    tg_id    bigint not null unique,
+    username text,
+    space_id uuid -- личное пространство пользователя
+);
+
+create table if not exists users.timezones (
+    user_id  bigint primary key references users.users (id) on delete cascade,
+    timezone text not null
+);
+
+create table if not exists shared_spaces.shared_spaces (
+    id       uuid primary key default gen_random_uuid(),
+    name     text not null,
+    created  timestamptz not null default now(),
+    creator  bigint not null references users.users (id),
+    personal boolean not null default false
+);
+
+-- состояние участника пространства: 2 - принял приглашение
+create table if not exists shared_spaces.participant_states (
+    id   smallint primary key,
+    name text not null unique
+);
+
+insert into shared_spaces.participant_states (id, name)
+values (1, 'invited'), (2, 'accepted'), (3, 'declined')
+on conflict do nothing;
+
+create table if not exists shared_spaces.participants (
+    user_id  bigint not null references users.users (id) on delete cascade,
+    space_id uuid not null references shared_spaces.shared_spaces (id) on delete cascade,
+    state_id smallint not null references shared_spaces.participant_states (id),
+    primary key (user_id, space_id)
+);
+
+create table if not exists shared_spaces.invitations (
+    "from"   bigint not null references users.users (id) on delete cascade,
+    "to"     bigint not null references users.users (id) on delete cascade,
+    space_id uuid not null references shared_spaces.shared_spaces (id) on delete cascade,
+    created  timestamptz not null default now(),
+    primary key ("from", "to", space_id)
+);
+
+create table if not exists notes.notes (
+    id        uuid primary key default gen_random_uuid(),
+    user_id   bigint not null references users.users (id),
+    text      text not null,
+    space_id  uuid not null references shared_spaces.shared_spaces (id) on delete cascade,
+    created   timestamptz not null default now(),
+    last_edit timestamptz,
+    type      text not null default 'text',
+    file      text -- название файла в Minio
+);
+
+create index if not exists notes_space_id_idx on notes.notes (space_id);
+create index if not exists notes_user_id_idx on notes.notes (user_id);
+create index if not exists participants_space_id_idx on shared_spaces.participants (space_id);
@@ -0,0 +1,3 @@
+drop index if exists notes.notes_trash_idx;
+
+alter table notes.notes drop column if exists deleted_at;
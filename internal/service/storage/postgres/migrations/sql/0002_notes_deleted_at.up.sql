@@ -0,0 +1,5 @@
+-- корзина: у удаленной заметки заполнено время удаления, у остальных - null
+alter table notes.notes add column if not exists deleted_at timestamptz;
+
+-- заметки в корзине пространства, от недавно удаленных к давно удаленным
+create index if not exists notes_trash_idx on notes.notes (space_id, deleted_at desc) where deleted_at is not null;
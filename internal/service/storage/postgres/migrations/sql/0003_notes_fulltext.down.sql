@@ -0,0 +1,3 @@
+drop index if exists notes.notes_text_trgm_idx;
+drop index if exists notes.notes_text_simple_idx;
+drop index if exists notes.notes_text_russian_idx;
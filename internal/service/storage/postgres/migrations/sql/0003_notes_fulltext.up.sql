@@ -0,0 +1,16 @@
+-- индексы для полнотекстового поиска в постгресе (когда эластик недоступен).
+-- Выражения совпадают с запросами репозитория fulltext, иначе индексы не используются
+create index if not exists notes_text_russian_idx on notes.notes using gin (to_tsvector('russian', text));
+create index if not exists notes_text_simple_idx on notes.notes using gin (to_tsvector('simple', text));
+
+-- pg_trgm нужен для поиска с опечатками. Если прав на установку расширения нет,
+-- миграция не падает: поиск работает без учета опечаток
+do $$
+begin
+    create extension if not exists pg_trgm;
+    create index if not exists notes_text_trgm_idx on notes.notes using gin (text gin_trgm_ops);
+exception
+    when insufficient_privilege then
+        raise notice 'pg_trgm is not installed: %', sqlerrm;
+end
+$$;
@@ -0,0 +1,225 @@
+package pool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+	"webserver/internal/service/storage/postgres/querylog"
+
+	"github.com/ex-rate/logger"
+	_ "github.com/lib/pq"
+	sqldblogger "github.com/simukti/sqldb-logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultReplicaCheckInterval - как часто проверять доступность реплик, если не задано в конфиге
+	DefaultReplicaCheckInterval = 10 * time.Second
+
+	pingTimeout = 2 * time.Second
+)
+
+// Config - параметры пула соединений
+type Config struct {
+	DSN      string   // основная база
+	Replicas []string // реплики для чтения
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	StatementTimeout time.Duration // добавляется в DSN основной базы и реплик, если в них не задан statement_timeout
+
+	ReplicaCheckInterval time.Duration
+
+	Log      *logger.Logger  // логгер для запросов. Если не задан, запросы не логируются
+	QueryLog querylog.Config // уровень логов запросов, порог медленных запросов и скрытие параметров
+}
+
+// DB - пул соединений с основной базой и репликами, общий для всех репозиториев.
+// Запись и транзакции идут в основную базу, чтение - в доступные реплики по очереди
+type DB struct {
+	primary       *sql.DB
+	replicas      []*replica
+	next          atomic.Uint64
+	checkInterval time.Duration
+}
+
+type replica struct {
+	db      *sql.DB
+	host    string // для логов: DSN содержит пароль
+	healthy atomic.Bool
+	checked atomic.Bool // доступность уже проверялась: о недоступной при запуске реплике тоже нужно написать в лог
+}
+
+// Open подключается к основной базе и репликам. Недоступная основная база - ошибка,
+// недоступные реплики не используются, пока не станут доступны
+func Open(ctx context.Context, cfg Config) (*DB, error) {
+	primary, err := open(cfg.DSN, "primary", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = primary.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to a db: %w", err)
+	}
+
+	db := &DB{
+		primary:       primary,
+		checkInterval: cfg.ReplicaCheckInterval,
+	}
+
+	if db.checkInterval == 0 {
+		db.checkInterval = DefaultReplicaCheckInterval
+	}
+
+	for _, dsn := range cfg.Replicas {
+		conn, err := open(dsn, "replica", cfg)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("error opening replica: %w", err)
+		}
+
+		db.replicas = append(db.replicas, &replica{db: conn, host: host(dsn)})
+	}
+
+	db.checkReplicas(ctx)
+
+	return db, nil
+}
+
+func open(dsn, role string, cfg Config) (*sql.DB, error) {
+	dsn, err := withStatementTimeout(dsn, cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect open a db driver: %w", err)
+	}
+
+	if cfg.Log != nil {
+		queryLog := querylog.New(cfg.Log, role, cfg.QueryLog)
+		db = sqldblogger.OpenDriver(dsn, db.Driver(), queryLog, queryLog.Options()...) // db is STILL *sql.DB
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// withStatementTimeout добавляет в DSN statement_timeout, если он задан в конфиге, но не в самом DSN.
+// lib/pq передает неизвестные ему параметры DSN в постгрес как параметры сессии
+func withStatementTimeout(dsn string, timeout time.Duration) (string, error) {
+	if timeout == 0 {
+		return dsn, nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid postgres DSN: %w", err)
+	}
+
+	query := u.Query()
+	if query.Has("statement_timeout") {
+		return dsn, nil
+	}
+
+	query.Set("statement_timeout", strconv.FormatInt(timeout.Milliseconds(), 10))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func host(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "unknown"
+	}
+
+	return u.Host
+}
+
+// Primary возвращает соединение с основной базой: для записи, транзакций и чтения, которому важна актуальность данных
+func (d *DB) Primary() *sql.DB {
+	return d.primary
+}
+
+// Reader возвращает соединение для запросов на чтение: следующую доступную реплику,
+// а если реплик нет или все недоступны - основную базу
+func (d *DB) Reader() *sql.DB {
+	n := len(d.replicas)
+
+	start := d.next.Add(1)
+	for i := range n {
+		r := d.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return d.primary
+}
+
+// Run проверяет доступность реплик, пока не отменят ctx
+func (d *DB) Run(ctx context.Context) {
+	if len(d.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.checkReplicas(ctx)
+		}
+	}
+}
+
+// checkReplicas проверяет доступность реплик и пишет в лог, если она изменилась
+func (d *DB) checkReplicas(ctx context.Context) {
+	for _, r := range d.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+
+		checked := r.checked.Swap(true)
+		if r.healthy.Swap(healthy) == healthy && checked {
+			continue
+		}
+
+		if healthy {
+			logrus.Infof("postgres replica %s is available, reading from it", r.host)
+		} else {
+			logrus.Warnf("postgres replica %s is unavailable, reading from other replicas or primary: %+v", r.host, err)
+		}
+	}
+}
+
+func (d *DB) Close() {
+	if err := d.primary.Close(); err != nil {
+		logrus.Errorf("error on closing postgres: %v", err)
+	}
+
+	for _, r := range d.replicas {
+		if err := r.db.Close(); err != nil {
+			logrus.Errorf("error on closing postgres replica %s: %v", r.host, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package querylog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// метрики запросов к базе отдаются сервером на /metrics вместе с метриками http
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "webserver",
+		Subsystem: "postgres",
+		Name:      "query_duration_seconds",
+		Help:      "Длительность запросов к базе по базе (primary, replica) и имени запроса",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"db", "statement"})
+
+	slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "webserver",
+		Subsystem: "postgres",
+		Name:      "slow_queries_total",
+		Help:      "Количество медленных запросов к базе по базе (primary, replica) и имени запроса",
+	}, []string{"db", "statement"})
+)
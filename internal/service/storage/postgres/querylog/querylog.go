@@ -0,0 +1,167 @@
+package querylog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ex-rate/logger"
+	sqldblogger "github.com/simukti/sqldb-logger"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultSlowThreshold - после какой длительности запрос считается медленным, если не задано в конфиге
+const DefaultSlowThreshold = 500 * time.Millisecond
+
+// Config - параметры логирования запросов
+type Config struct {
+	Level         logrus.Level  // минимальный уровень логов: запросы пишутся в debug, медленные запросы в warn, ошибки в error
+	SlowThreshold time.Duration // после какой длительности запрос считается медленным
+	LogArgs       bool          // писать значения параметров запросов. Иначе вместо значений пишутся только их типы
+}
+
+// Logger пишет запросы к базе в лог приложения и собирает метрики их длительности.
+// Реализует интерфейс логгера sqldblogger
+type Logger struct {
+	log  *logger.Logger
+	cfg  Config
+	role string // primary или replica
+}
+
+func New(log *logger.Logger, role string, cfg Config) *Logger {
+	if cfg.SlowThreshold == 0 {
+		cfg.SlowThreshold = DefaultSlowThreshold
+	}
+
+	return &Logger{log: log, cfg: cfg, role: role}
+}
+
+// Options - настройки sqldblogger: события ниже уровня debug (чтение каждой строки и т.п.) не нужны,
+// а параметры запросов он передает всегда, чтобы их можно было скрыть здесь
+func (l *Logger) Options() []sqldblogger.Option {
+	return []sqldblogger.Option{
+		sqldblogger.WithMinimumLevel(sqldblogger.LevelDebug),
+		sqldblogger.WithDurationUnit(sqldblogger.DurationMillisecond),
+		sqldblogger.WithLogArguments(true),
+	}
+}
+
+// queryEvents - события sqldblogger, в которых выполняется запрос
+var queryEvents = map[string]bool{
+	"Query":            true,
+	"QueryContext":     true,
+	"Exec":             true,
+	"ExecContext":      true,
+	"StmtQuery":        true,
+	"StmtQueryContext": true,
+	"StmtExec":         true,
+	"StmtExecContext":  true,
+}
+
+func (l *Logger) Log(_ context.Context, level sqldblogger.Level, msg string, data map[string]any) {
+	duration, _ := data["duration"].(float64) // в миллисекундах
+	query, _ := data["query"].(string)
+
+	isQuery := queryEvents[msg] && len(query) > 0
+	slow := isQuery && duration >= float64(l.cfg.SlowThreshold.Milliseconds())
+
+	if isQuery {
+		statement := StatementName(query)
+
+		queryDuration.WithLabelValues(l.role, statement).Observe(duration / 1000)
+
+		if slow {
+			slowQueries.WithLabelValues(l.role, statement).Inc()
+		}
+	}
+
+	lvl, ok := l.entryLevel(level, slow)
+	if !ok {
+		return
+	}
+
+	if slow && lvl == logrus.WarnLevel {
+		msg = "slow query"
+	}
+
+	fields := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		fields[k] = v
+	}
+
+	delete(fields, "time") // время записи добавляет логгер
+	fields["db"] = l.role
+
+	if args, ok := fields["args"].([]any); ok && !l.cfg.LogArgs {
+		fields["args"] = redact(args)
+	}
+
+	entry := l.log.WithFields(fields)
+
+	switch lvl {
+	case logrus.ErrorLevel:
+		entry.Error(msg)
+	case logrus.WarnLevel:
+		entry.Warn(msg)
+	default:
+		entry.Debug(msg)
+	}
+}
+
+// entryLevel возвращает уровень, с которым событие пишется в лог, или false, если по конфигу событие не пишется
+func (l *Logger) entryLevel(level sqldblogger.Level, slow bool) (logrus.Level, bool) {
+	lvl := logrus.DebugLevel
+
+	switch {
+	case level == sqldblogger.LevelError:
+		lvl = logrus.ErrorLevel
+	case slow:
+		lvl = logrus.WarnLevel
+	case level < sqldblogger.LevelDebug:
+		return 0, false
+	}
+
+	return lvl, lvl <= l.cfg.Level
+}
+
+// redact заменяет значения параметров их типами: в параметрах бывают тексты заметок и другие данные пользователей
+func redact(args []any) []string {
+	res := make([]string, len(args))
+
+	for i, arg := range args {
+		if arg == nil {
+			res[i] = "null"
+			continue
+		}
+
+		res[i] = fmt.Sprintf("%T", arg)
+	}
+
+	return res
+}
+
+var (
+	commentRe = regexp.MustCompile(`--[^\n]*`)
+	spacesRe  = regexp.MustCompile(`\s+`)
+	// первая таблица, с которой работает запрос: после from, into или update
+	tableRe = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+([a-z_][\w.]*)`)
+)
+
+// StatementName - имя запроса для метрик: команда и таблица, с которой он работает (select notes.notes).
+// Значения параметров в имя не попадают, поэтому число имен ограничено числом разных запросов в репозиториях
+func StatementName(query string) string {
+	query = commentRe.ReplaceAllString(query, "")
+	query = strings.TrimSpace(spacesRe.ReplaceAllString(query, " "))
+
+	command, _, _ := strings.Cut(query, " ")
+	command = strings.ToLower(strings.Trim(command, "("))
+
+	match := tableRe.FindStringSubmatch(query)
+	if match == nil {
+		return command
+	}
+
+	return command + " " + strings.ToLower(match[1])
+}
@@ -0,0 +1,118 @@
+package querylog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ex-rate/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	sqldblogger "github.com/simukti/sqldb-logger"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "select id, name, created, creator, personal from shared_spaces.shared_spaces where id = $1",
+			want:  "select shared_spaces.shared_spaces",
+		},
+		{
+			query: "select exists(select 1 from users.users where tg_id = $1)",
+			want:  "select users.users",
+		},
+		{
+			query: `update notes.notes set text = $1, last_edit = now()
+	where id = $2 and user_id = (select id from users.users where tg_id = $3) returning id`,
+			want: "update notes.notes",
+		},
+		{
+			query: "insert into public.schema_migrations (version, name) values ($1, $2)",
+			want:  "insert public.schema_migrations",
+		},
+		{
+			query: "-- корзина\nalter table notes.notes add column if not exists deleted_at timestamptz;",
+			want:  "alter",
+		},
+		{
+			query: "select pg_advisory_xact_lock($1)",
+			want:  "select",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, StatementName(tt.query))
+		})
+	}
+}
+
+func TestEntryLevel(t *testing.T) {
+	type test struct {
+		name   string
+		cfg    logrus.Level
+		level  sqldblogger.Level
+		slow   bool
+		want   logrus.Level
+		logged bool
+	}
+
+	tests := []test{
+		{name: "query at debug", cfg: logrus.DebugLevel, level: sqldblogger.LevelInfo, want: logrus.DebugLevel, logged: true},
+		{name: "query skipped at warn", cfg: logrus.WarnLevel, level: sqldblogger.LevelInfo},
+		{name: "slow query at warn", cfg: logrus.WarnLevel, level: sqldblogger.LevelInfo, slow: true, want: logrus.WarnLevel, logged: true},
+		{name: "slow query skipped at error", cfg: logrus.ErrorLevel, level: sqldblogger.LevelInfo, slow: true},
+		{name: "error", cfg: logrus.ErrorLevel, level: sqldblogger.LevelError, want: logrus.ErrorLevel, logged: true},
+		{name: "slow error is error", cfg: logrus.WarnLevel, level: sqldblogger.LevelError, slow: true, want: logrus.ErrorLevel, logged: true},
+		{name: "trace never logged", cfg: logrus.DebugLevel, level: sqldblogger.LevelTrace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Logger{cfg: Config{Level: tt.cfg}}
+
+			lvl, ok := l.entryLevel(tt.level, tt.slow)
+			assert.Equal(t, tt.logged, ok)
+
+			if tt.logged {
+				assert.Equal(t, tt.want, lvl)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	args := []any{"секретный текст заметки", int64(12345), nil, time.Time{}}
+
+	assert.Equal(t, []string{"string", "int64", "null", "time.Time"}, redact(args))
+}
+
+func TestLog_Metrics(t *testing.T) {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	l := New(log, "test", Config{Level: logrus.WarnLevel, SlowThreshold: 100 * time.Millisecond})
+
+	query := "select id from notes.notes where id = $1"
+
+	l.Log(t.Context(), sqldblogger.LevelInfo, "QueryContext", map[string]any{"query": query, "duration": 10.0, "args": []any{"id"}})
+	l.Log(t.Context(), sqldblogger.LevelInfo, "QueryContext", map[string]any{"query": query, "duration": 250.0, "args": []any{"id"}})
+	// не запрос: метрики не меняются
+	l.Log(t.Context(), sqldblogger.LevelInfo, "Commit", map[string]any{"duration": 500.0})
+
+	var metric dto.Metric
+	require.NoError(t, queryDuration.WithLabelValues("test", "select notes.notes").(prometheus.Histogram).Write(&metric))
+
+	assert.Equal(t, uint64(2), metric.GetHistogram().GetSampleCount())
+	assert.InDelta(t, 0.26, metric.GetHistogram().GetSampleSum(), 1e-9)
+	assert.Equal(t, 1.0, testutil.ToFloat64(slowQueries.WithLabelValues("test", "select notes.notes")))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 	api_errors "webserver/internal/errors"
 	"webserver/internal/model"
 
@@ -11,10 +13,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// GetSpaceByID возвращает пространство по айди. Результат кэшируется, поэтому читается из основной базы:
+// реплика может отставать и вернуть пространство без изменений, из-за которых оно только что удалено из кэша
 func (db *Repo) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
 	res := model.Space{}
 
-	err := db.db.QueryRowContext(ctx, "select id, name, created, creator, personal from shared_spaces.shared_spaces where id = $1", id).
+	err := db.conn(ctx).QueryRowContext(ctx, "select id, name, created, creator, personal from shared_spaces.shared_spaces where id = $1", id).
 		Scan(&res.ID, &res.Name, &res.Created, &res.Creator, &res.Personal)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -29,7 +33,7 @@ func (db *Repo) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, er
 
 func (db *Repo) IsSpacePersonal(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	var personal bool
-	err := db.db.QueryRowContext(ctx, "select personal from shared_spaces.shared_spaces where id = $1", spaceID).
+	err := db.reader(ctx).QueryRowContext(ctx, "select personal from shared_spaces.shared_spaces where id = $1", spaceID).
 		Scan(&personal)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -48,7 +52,7 @@ func (db *Repo) IsSpacePersonal(ctx context.Context, spaceID uuid.UUID) (bool, e
 
 func (db *Repo) IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, error) {
 	var exists bool
-	err := db.db.QueryRowContext(ctx, "select exists(select 1 from shared_spaces.shared_spaces where id = $1)", spaceID).
+	err := db.reader(ctx).QueryRowContext(ctx, "select exists(select 1 from shared_spaces.shared_spaces where id = $1)", spaceID).
 		Scan(&exists)
 	if err != nil {
 		return false, err
@@ -61,7 +65,7 @@ func (db *Repo) IsSpaceExists(ctx context.Context, spaceID uuid.UUID) (bool, err
 
 func (db *Repo) CheckInvitation(ctx context.Context, from, to int64, spaceID uuid.UUID) (bool, error) {
 	var exists bool
-	err := db.db.QueryRowContext(ctx, `select exists(select 1 from shared_spaces.invitations 
+	err := db.reader(ctx).QueryRowContext(ctx, `select exists(select 1 from shared_spaces.invitations 
 where "from" = (select id from users.users where tg_id = $1)
 and "to" = (select id from users.users where tg_id = $2)
 and space_id = $3);`, from, to, spaceID).
@@ -80,55 +84,122 @@ and space_id = $3);`, from, to, spaceID).
 func (db *Repo) CheckParticipant(ctx context.Context, userID int64, spaceID uuid.UUID) (bool, error) {
 	logrus.WithField("userID", userID).WithField("spaceID", spaceID).Debug("checking participant")
 
-	space, err := db.GetSpaceByID(ctx, spaceID) // получаем информацию о пространстве (проверить, личное ли оно)
+	members, err := db.GetSpaceMembers(ctx, spaceID)
 	if err != nil {
 		return false, err
 	}
 
-	if space.Personal {
-		var userSpaceID uuid.UUID
+	return slices.Contains(members, userID), nil
+}
+
+// GetSpaceMembers возвращает телеграм айди всех участников пространства одним запросом.
+// В личном пространстве участник - только его владелец. В совместном - принявшие приглашение участники и создатель.
+// Участники кэшируются, поэтому читаются из основной базы: с отстающей реплики в кэш попал бы список
+// без только что добавленного участника
+func (db *Repo) GetSpaceMembers(ctx context.Context, spaceID uuid.UUID) ([]int64, error) {
+	logrus.WithField("spaceID", spaceID).Debug("getting space members")
+
+	// строка с пустым tg_id - пространство без участников, ни одной строки - пространства не существует
+	rows, err := db.conn(ctx).QueryContext(ctx, `select members.tg_id from shared_spaces.shared_spaces spaces
+left join lateral (
+	select users.users.tg_id from users.users
+	where spaces.personal and users.users.space_id = spaces.id
+	union
+	select users.users.tg_id from shared_spaces.participants participants
+	join users.users on users.users.id = participants.user_id
+	where not spaces.personal and participants.space_id = spaces.id and participants.state_id = 2
+	union
+	select users.users.tg_id from users.users
+	where not spaces.personal and users.users.id = spaces.creator
+) members on true
+where spaces.id = $1`, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting space members: %w", err)
+	}
+	defer rows.Close()
 
-		// выясняем айди личного пространства пользователя
-		err := db.db.QueryRowContext(ctx, "select space_id from users.users where tg_id = $1", userID).
-			Scan(&userSpaceID)
-		if err != nil {
-			return false, err
+	var (
+		members []int64
+		found   bool
+	)
+
+	for rows.Next() {
+		var tgID sql.NullInt64
+
+		if err := rows.Scan(&tgID); err != nil {
+			return nil, fmt.Errorf("error scanning space member: %w", err)
 		}
 
-		if userSpaceID == spaceID {
-			return true, nil
+		found = true
+
+		if tgID.Valid {
+			members = append(members, tgID.Int64)
 		}
+	}
 
-		return false, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	var exists bool
+	if !found {
+		return nil, api_errors.ErrSpaceNotExists
+	}
 
-	err = db.db.QueryRowContext(ctx, `select exists(select 1 from shared_spaces.participants 
-where user_id = (select id from users.users where tg_id = $1) 
-and space_id = $2
-and state_id = 2)`, userID, spaceID).
-		Scan(&exists)
+	return members, nil
+}
+
+// GetUserSpaceIDs возвращает айди всех пространств, в которых состоит пользователь:
+// его личное пространство, совместные пространства, где он участник, и совместные пространства, которые он создал.
+// Правила те же, что и в CheckParticipant, но все пространства достаются одним запросом
+func (db *Repo) GetUserSpaceIDs(ctx context.Context, userID int64) ([]uuid.UUID, error) {
+	logrus.WithField("userID", userID).Debug("getting user's spaces")
+
+	rows, err := db.reader(ctx).QueryContext(ctx, `select space_id from users.users where tg_id = $1 and space_id is not null
+union
+select participants.space_id from shared_spaces.participants participants
+join users.users on users.users.id = participants.user_id
+join shared_spaces.shared_spaces on shared_spaces.shared_spaces.id = participants.space_id
+where users.users.tg_id = $1 and participants.state_id = 2 and not shared_spaces.shared_spaces.personal
+union
+select shared_spaces.shared_spaces.id from shared_spaces.shared_spaces
+join users.users on users.users.id = shared_spaces.shared_spaces.creator
+where users.users.tg_id = $1 and not shared_spaces.shared_spaces.personal`, userID)
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("error getting user's spaces: %w", err)
 	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
 
-	if exists {
-		return true, nil
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning user's space: %w", err)
+		}
+
+		ids = append(ids, id)
 	}
 
-	var creator, tgID int64
-	// выясняем, не является ли пользователь создателем пространства
-	err = db.db.QueryRowContext(ctx, `select creator, tg_id from shared_spaces.shared_spaces
-join users.users on users.users.id = shared_spaces.shared_spaces.creator
-where  shared_spaces.shared_spaces.id = $1`, spaceID).Scan(&creator, &tgID) // creator - айди в БД, tgID - айди в телеге
+	return ids, rows.Err()
+}
+
+// GetUserTimezone возвращает часовой пояс пользователя по telegram id. Если часовой пояс не сохранен, возвращает пустую строку
+func (db *Repo) GetUserTimezone(ctx context.Context, userID int64) (string, error) {
+	logrus.WithField("userID", userID).Debug("getting user's timezone")
+
+	var timezone sql.NullString
+
+	err := db.reader(ctx).QueryRowContext(ctx, `select users.timezones.timezone from users.users
+left join users.timezones on users.timezones.user_id = users.users.id
+where users.users.tg_id = $1`, userID).Scan(&timezone)
 	if err != nil {
-		return false, err
-	}
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", api_errors.ErrUnknownUser
+		}
 
-	if userID == tgID {
-		return true, nil
+		return "", fmt.Errorf("error getting user's timezone: %w", err)
 	}
 
-	return false, nil
+	return timezone.String, nil
 }
@@ -9,9 +9,9 @@ import (
 	"webserver/internal/model"
 	"webserver/internal/model/elastic"
 	"webserver/internal/model/rabbit"
+	"webserver/internal/service/storage/postgres/uow"
 
 	api_errors "webserver/internal/errors"
-	"webserver/internal/service/storage/elasticsearch"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -24,12 +24,12 @@ func (db *Repo) GetAllNotesBySpaceIDFull(ctx context.Context, spaceID uuid.UUID)
 
 	res := []model.Note{}
 
-	rows, err := db.db.QueryContext(ctx, `select  notes.notes.id as note_id, text as note_text, notes.notes.created as note_created, 
+	rows, err := db.reader(ctx).QueryContext(ctx, `select  notes.notes.id as note_id, text as note_text, notes.notes.created as note_created, 
 	last_edit as note_last_edit, shared_spaces.shared_spaces.id as space_id,  shared_spaces.shared_spaces.name as space_name, 
 	shared_spaces.shared_spaces.personal, shared_spaces.shared_spaces.creator,shared_spaces.shared_spaces.created as space_created, 
 	users.users.tg_id,  users.users.username,  users.users.space_id as users_personal_space, users.timezones.timezone 
 	from shared_spaces.shared_spaces
-left join notes.notes on shared_spaces.shared_spaces.id = notes.notes.space_id
+left join notes.notes on shared_spaces.shared_spaces.id = notes.notes.space_id and notes.notes.deleted_at is null
 left join users.users on users.users.id = notes.notes.user_id
 left join users.timezones on users.timezones.user_id = notes.notes.user_id
 where shared_spaces.shared_spaces.id = $1;`, spaceID)
@@ -73,8 +73,8 @@ func (db *Repo) GetAllNotesBySpaceID(ctx context.Context, spaceID uuid.UUID) ([]
 
 	res := []model.GetNote{}
 
-	rows, err := db.db.QueryContext(ctx, `select  notes.notes.id as note_id, text as note_text, notes.notes.created as note_created, last_edit as note_last_edit, shared_spaces.shared_spaces.id as space_id,  users.users.tg_id from shared_spaces.shared_spaces
-left join notes.notes on shared_spaces.shared_spaces.id = notes.notes.space_id
+	rows, err := db.reader(ctx).QueryContext(ctx, `select  notes.notes.id as note_id, text as note_text, notes.notes.created as note_created, last_edit as note_last_edit, shared_spaces.shared_spaces.id as space_id,  users.users.tg_id from shared_spaces.shared_spaces
+left join notes.notes on shared_spaces.shared_spaces.id = notes.notes.space_id and notes.notes.deleted_at is null
 left join users.users on users.users.id = notes.notes.user_id
 left join users.timezones on users.timezones.user_id = notes.notes.user_id
 where shared_spaces.shared_spaces.id = $1;`, spaceID)
@@ -107,43 +107,37 @@ where shared_spaces.shared_spaces.id = $1;`, spaceID)
 	return res, nil
 }
 
+// UpdateNote обновляет текст заметки в базе. Эластик обновляется после коммита транзакции
 func (db *Repo) UpdateNote(ctx context.Context, update rabbit.UpdateNoteRequest) error {
 	logrus.WithField("update", update.NoteID).Debug("updating note")
 
-	tx, err := db.tx(ctx)
-	if err != nil {
-		return err
-	}
-
-	var id uuid.UUID
-	err = tx.QueryRowContext(ctx, `update notes.notes set text = $1, last_edit = now()
+	return db.uow.Do(ctx, func(ctx context.Context) error {
+		var id uuid.UUID
+		err := db.conn(ctx).QueryRowContext(ctx, `update notes.notes set text = $1, last_edit = now()
 	where id = $2 and user_id = (select id from users.users where tg_id = $3) returning id`,
-		update.Text, update.NoteID, update.UserID).Scan(&id)
-	if err != nil {
-		return fmt.Errorf("error while updating note: %+v", err)
-	}
-
-	data := elastic.Data{
-		Index: elastic.NoteIndex,
-		Model: &elastic.Note{
-			ID:      id,
-			TgID:    update.UserID,
-			Text:    update.Text,
-			SpaceID: update.SpaceID,
-		},
-	}
+			update.Text, update.NoteID, update.UserID).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("error while updating note: %+v", err)
+		}
 
-	err = db.elasticClient.UpdateNote(ctx, data)
-	if err != nil {
-		rollBackErr := tx.Rollback()
-		if rollBackErr != nil {
-			logrus.Errorf("error rollback tx: %+v", rollBackErr)
+		data := elastic.Data{
+			Index: elastic.NoteIndex,
+			Model: &elastic.Note{
+				ID:      id,
+				TgID:    update.UserID,
+				Text:    update.Text,
+				SpaceID: update.SpaceID,
+			},
 		}
 
-		return fmt.Errorf("error while updating note in elastic: %+v", err)
-	}
+		return uow.AfterCommit(ctx, func(ctx context.Context) error {
+			if err := db.elasticClient.UpdateNote(ctx, data); err != nil {
+				return fmt.Errorf("error while updating note in elastic: %w", err)
+			}
 
-	return tx.Commit()
+			return nil
+		})
+	})
 }
 
 // GetNoteByID возвращает заметку по айди, либо ошибку о том, что такой заметки не существует
@@ -152,12 +146,12 @@ func (db *Repo) GetNoteByID(ctx context.Context, noteID uuid.UUID) (model.GetNot
 
 	var note model.GetNote
 
-	row := db.db.QueryRowContext(ctx, `select notes.notes.id, tg_id, text, notes.notes.space_id, created, last_edit, type
+	row := db.reader(ctx).QueryRowContext(ctx, `select notes.notes.id, tg_id, text, notes.notes.space_id, created, last_edit, type, file
 	 from notes.notes 
 left join users.users on users.users.id = notes.notes.user_id
-where notes.notes.id = $1;`, noteID)
+where notes.notes.id = $1 and notes.notes.deleted_at is null;`, noteID)
 
-	err := row.Scan(&note.ID, &note.UserID, &note.Text, &note.SpaceID, &note.Created, &note.LastEdit, &note.Type)
+	err := row.Scan(&note.ID, &note.UserID, &note.Text, &note.SpaceID, &note.Created, &note.LastEdit, &note.Type, &note.File)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.GetNote{}, api_errors.ErrNoteNotFound
@@ -179,7 +173,7 @@ func (db *Repo) GetNotesTypes(ctx context.Context, spaceID uuid.UUID) ([]model.N
 
 	res := []model.NoteTypeResponse{}
 
-	rows, err := db.db.QueryContext(ctx, "select count(*), type from notes.notes group by type, notes.space_id having space_id = $1;", spaceID)
+	rows, err := db.reader(ctx).QueryContext(ctx, "select count(*), type from notes.notes where space_id = $1 and deleted_at is null group by type;", spaceID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting note types: %+v", err)
 	}
@@ -208,10 +202,10 @@ func (db *Repo) GetNotesByType(ctx context.Context, spaceID uuid.UUID, noteType
 
 	res := []model.GetNote{}
 
-	rows, err := db.db.QueryContext(ctx, `select notes.notes.id, users.users.tg_id, text, created, last_edit, file 
+	rows, err := db.reader(ctx).QueryContext(ctx, `select notes.notes.id, users.users.tg_id, text, created, last_edit, file 
 from notes.notes
 join users.users on users.users.id = notes.notes.user_id
-where notes.notes.space_id = $1 and type = $2;`, spaceID, noteType)
+where notes.notes.space_id = $1 and type = $2 and notes.notes.deleted_at is null;`, spaceID, noteType)
 	if err != nil {
 		return nil, fmt.Errorf("error getting note types: %+v", err)
 	}
@@ -237,58 +231,40 @@ where notes.notes.space_id = $1 and type = $2;`, spaceID, noteType)
 	return res, nil
 }
 
-func (db *Repo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) ([]model.GetNote, error) {
-	logrus.Debug("searching note by text")
-
-	search := elastic.Data{
-		Index: elastic.NoteIndex,
-		Model: &elastic.Note{
-			SpaceID: req.SpaceID,
-			Text:    req.Text,
-			Type:    req.Type,
-		},
-	}
+// GetNotesByIDs возвращает заметки с указанными айди. Заметки, которых не существует, в результат не попадают
+func (db *Repo) GetNotesByIDs(ctx context.Context, ids []uuid.UUID) ([]model.GetNote, error) {
+	logrus.WithField("count", len(ids)).Debug("getting notes by IDs")
 
-	ids, err := db.elasticClient.SearchByText(ctx, search)
-	if err != nil {
-		if errors.Is(err, elasticsearch.ErrRecordsNotFound) {
-			return nil, api_errors.ErrNoNotesFoundByText
-		}
+	notes := []model.GetNote{}
 
-		return nil, err
+	if len(ids) == 0 {
+		return notes, nil
 	}
 
-	var notes []model.GetNote
-
-	q, args, err := sqlx.In(`select notes.notes.id, users.users.tg_id, text, created, last_edit, type, file from notes.notes
+	q, args, err := sqlx.In(`select notes.notes.id, users.users.tg_id, text, notes.notes.space_id, created, last_edit, type, file from notes.notes
 	join users.users on users.users.id = notes.notes.user_id
-	where notes.notes.id IN(?);`, ids)
+	where notes.notes.id IN(?) and notes.notes.deleted_at is null;`, ids)
 	if err != nil {
-		return nil, fmt.Errorf("error while creating query while searching notes: %+v", err)
+		return nil, fmt.Errorf("error while creating query while getting notes by IDs: %+v", err)
 	}
 
 	q = sqlx.Rebind(sqlx.DOLLAR, q)
-	rows, err := db.db.Query(q, args...)
+	rows, err := db.reader(ctx).QueryContext(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error while searching notes by text: %w", err)
+		return nil, fmt.Errorf("error while getting notes by IDs: %w", err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		note := model.GetNote{
-			SpaceID: req.SpaceID,
-		}
+		var note model.GetNote
 
-		err := rows.Scan(&note.ID, &note.UserID, &note.Text, &note.Created, &note.LastEdit, &note.Type, &note.File)
+		err := rows.Scan(&note.ID, &note.UserID, &note.Text, &note.SpaceID, &note.Created, &note.LastEdit, &note.Type, &note.File)
 		if err != nil {
-			return nil, fmt.Errorf("error while scanning note (search by text): %w", err)
+			return nil, fmt.Errorf("error while scanning note (get by IDs): %w", err)
 		}
 
 		notes = append(notes, note)
 	}
 
-	if len(notes) == 0 {
-		return nil, api_errors.ErrNoNotesFoundByText
-	}
-
-	return notes, nil
+	return notes, rows.Err()
 }
@@ -0,0 +1,98 @@
+package space
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"webserver/internal/model/elastic"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// GetNotesForIndex возвращает до limit заметок с ID больше afterID, отсортированных по ID, в виде документов для эластика.
+// Заметки из корзины тоже возвращаются: в индексе они помечаются как удаленные
+func (db *Repo) GetNotesForIndex(ctx context.Context, afterID uuid.UUID, limit int) ([]elastic.Note, error) {
+	logrus.WithField("afterID", afterID).WithField("limit", limit).Debug("getting notes for index")
+
+	rows, err := db.conn(ctx).QueryContext(ctx, `select notes.notes.id, users.users.tg_id, text, notes.notes.space_id, created, type, file, deleted_at is not null
+from notes.notes
+join users.users on users.users.id = notes.notes.user_id
+where notes.notes.id > $1
+order by notes.notes.id
+limit $2;`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notes for index: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotesForIndex(rows, limit)
+}
+
+// GetNotesForIndexByIDs возвращает заметки с указанными ID, отсортированные по ID, в виде документов для эластика.
+// Заметки читаются из основной базы: реплика может отставать, а по результату исправляется индекс.
+// Заметки, которых уже нет в базе, в результат не попадают, заметки из корзины возвращаются
+func (db *Repo) GetNotesForIndexByIDs(ctx context.Context, ids []uuid.UUID) ([]elastic.Note, error) {
+	logrus.WithField("count", len(ids)).Debug("getting notes for index by IDs")
+
+	if len(ids) == 0 {
+		return []elastic.Note{}, nil
+	}
+
+	q, args, err := sqlx.In(`select notes.notes.id, users.users.tg_id, text, notes.notes.space_id, created, type, file, deleted_at is not null
+from notes.notes
+join users.users on users.users.id = notes.notes.user_id
+where notes.notes.id IN(?)
+order by notes.notes.id;`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error creating query for notes for index by IDs: %w", err)
+	}
+
+	rows, err := db.conn(ctx).QueryContext(ctx, sqlx.Rebind(sqlx.DOLLAR, q), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notes for index by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotesForIndex(rows, len(ids))
+}
+
+// scanNotesForIndex читает заметки из результата запроса в виде документов для эластика
+func scanNotesForIndex(rows *sql.Rows, size int) ([]elastic.Note, error) {
+	notes := make([]elastic.Note, 0, size)
+
+	for rows.Next() {
+		var (
+			note    elastic.Note
+			created time.Time
+			file    sql.NullString
+		)
+
+		err := rows.Scan(&note.ID, &note.TgID, &note.Text, &note.SpaceID, &created, &note.Type, &file, &note.Deleted)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning note for index: %w", err)
+		}
+
+		note.Created = created.Unix()
+		note.File = file.String
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// CountNotes возвращает количество заметок, которые вернет GetNotesForIndex, включая заметки в корзине
+func (db *Repo) CountNotes(ctx context.Context) (int, error) {
+	var count int
+
+	err := db.conn(ctx).QueryRowContext(ctx, `select count(*) from notes.notes
+join users.users on users.users.id = notes.notes.user_id;`).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting notes: %w", err)
+	}
+
+	return count, nil
+}
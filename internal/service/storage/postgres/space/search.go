@@ -0,0 +1,225 @@
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/storage/elasticsearch"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SearchNoteByText ищет заметки по тексту в эластике и достает найденные заметки из базы.
+// Порядок заметок соответствует релевантности; заметки, которых уже нет в базе, пропускаются
+func (db *Repo) SearchNoteByText(ctx context.Context, req model.SearchNoteByTextRequest) (model.SearchNoteResponse, error) {
+	logrus.Debug("searching note by text")
+
+	search := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{
+			SpaceID: req.SpaceID,
+			Text:    req.Text,
+			Type:    req.Type,
+		},
+		Filter: searchFilter(req.SearchFilters),
+	}
+
+	page, err := searchPage(req.SearchPage)
+	if err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
+	res, err := db.elasticClient.SearchByText(ctx, search, page)
+	if err != nil {
+		if errors.Is(err, elasticsearch.ErrRecordsNotFound) {
+			return model.SearchNoteResponse{}, api_errors.ErrNoNotesFoundByText
+		}
+
+		return model.SearchNoteResponse{}, err
+	}
+
+	return db.searchResponse(ctx, res, req.Size, []uuid.UUID{req.SpaceID})
+}
+
+// SearchAllNotes ищет заметки по тексту сразу во всех переданных пространствах.
+// Заметки, которые по данным базы лежат в других пространствах, в результат не попадают
+func (db *Repo) SearchAllNotes(ctx context.Context, req model.SearchAllNotesRequest, spaceIDs []uuid.UUID) (model.SearchNoteResponse, error) {
+	logrus.WithField("spaces", len(spaceIDs)).Debug("searching note by text in all user's spaces")
+
+	search := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{
+			Text: req.Text,
+			Type: req.Type,
+		},
+		Filter: searchFilter(req.SearchFilters),
+	}
+
+	page, err := searchPage(req.SearchPage)
+	if err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
+	res, err := db.elasticClient.SearchByTextInSpaces(ctx, search, spaceIDs, page)
+	if err != nil {
+		if errors.Is(err, elasticsearch.ErrRecordsNotFound) {
+			return model.SearchNoteResponse{}, api_errors.ErrNoNotesFoundByText
+		}
+
+		return model.SearchNoteResponse{}, err
+	}
+
+	resp, err := db.searchResponse(ctx, res, req.Size, spaceIDs)
+	if err != nil {
+		return model.SearchNoteResponse{}, err
+	}
+
+	resp.Spaces = make([]model.SpaceHits, 0, len(res.Spaces))
+	for _, space := range res.Spaces {
+		resp.Spaces = append(resp.Spaces, model.SpaceHits{SpaceID: space.SpaceID, Count: space.Count})
+	}
+
+	return resp, nil
+}
+
+// SuggestNotes возвращает подсказки для введенного текста. Заметки из базы не достаются:
+// id, тип и фрагмент текста берутся из эластика, чтобы уложиться во время ответа на ввод
+func (db *Repo) SuggestNotes(ctx context.Context, req model.SuggestNotesRequest) (model.SuggestNotesResponse, error) {
+	logrus.WithField("spaceID", req.SpaceID).Debug("suggesting notes")
+
+	search := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{
+			SpaceID: req.SpaceID,
+			Text:    req.Query,
+		},
+	}
+
+	suggestions, err := db.elasticClient.Suggest(ctx, search, req.Size)
+	if err != nil {
+		return model.SuggestNotesResponse{}, err
+	}
+
+	resp := model.SuggestNotesResponse{
+		Suggestions: make([]model.NoteSuggestion, 0, len(suggestions)),
+	}
+
+	for _, suggestion := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, model.NoteSuggestion{
+			ID:      suggestion.ID,
+			Type:    suggestion.Type,
+			Snippet: suggestion.Snippet,
+		})
+	}
+
+	return resp, nil
+}
+
+// searchFilter переводит фильтры из запроса в фильтры для эластика
+func searchFilter(req model.SearchFilters) elastic.SearchFilter {
+	return elastic.SearchFilter{
+		Types:       req.Types,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		AuthorID:    req.AuthorID,
+		HasFile:     req.HasFile,
+		Location:    req.Location,
+	}
+}
+
+// searchPage переводит параметры страницы из запроса в параметры для эластика
+func searchPage(req model.SearchPage) (elastic.Page, error) {
+	page := elastic.Page{
+		From: req.From,
+		Size: req.Size,
+	}
+
+	if len(req.SearchAfter) > 0 {
+		values, err := model.DecodeSearchCursor(req.SearchAfter)
+		if err != nil {
+			return elastic.Page{}, err
+		}
+
+		for _, val := range values {
+			page.SearchAfter = append(page.SearchAfter, val)
+		}
+	}
+
+	return page, nil
+}
+
+// searchResponse достает из базы найденные в эластике заметки в порядке релевантности.
+// Пропускает заметки, которых уже нет в базе, и заметки не из переданных пространств
+func (db *Repo) searchResponse(ctx context.Context, res elastic.SearchResult, size int, spaceIDs []uuid.UUID) (model.SearchNoteResponse, error) {
+	ids := make([]uuid.UUID, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ID)
+	}
+
+	notes, err := db.GetNotesByIDs(ctx, ids)
+	if err != nil {
+		return model.SearchNoteResponse{}, fmt.Errorf("error while getting found notes: %w", err)
+	}
+
+	allowed := make(map[uuid.UUID]struct{}, len(spaceIDs))
+	for _, id := range spaceIDs {
+		allowed[id] = struct{}{}
+	}
+
+	notesByID := make(map[uuid.UUID]model.GetNote, len(notes))
+	for _, note := range notes {
+		// индекс мог не успеть обновиться после переноса заметки в другое пространство
+		if _, ok := allowed[note.SpaceID]; !ok {
+			continue
+		}
+
+		notesByID[note.ID] = note
+	}
+
+	resp := model.SearchNoteResponse{
+		Total: res.Total,
+		Hits:  make([]model.SearchNoteHit, 0, len(res.Hits)),
+	}
+
+	var dropped []uuid.UUID
+
+	for _, hit := range res.Hits {
+		note, ok := notesByID[hit.ID]
+		if !ok { // заметка удалена из базы, но еще не из эластика
+			dropped = append(dropped, hit.ID)
+			continue
+		}
+
+		resp.Hits = append(resp.Hits, model.SearchNoteHit{
+			GetNote:    note,
+			Score:      hit.Score,
+			Highlights: hit.Highlights,
+		})
+	}
+
+	// если индекс не догоняет базу долго, расхождения найдет проверка согласованности, а исправит команда consistency -repair
+	if len(dropped) > 0 {
+		logrus.WithField("notes", dropped).Warnf("search: %d found notes are missing in db or belong to other spaces", len(dropped))
+	}
+
+	// курсор на следующую страницу отдаем, только если страница заполнена целиком
+	if len(res.Hits) > 0 && len(res.Hits) == size {
+		last := res.Hits[len(res.Hits)-1].Sort
+
+		values := make([]any, 0, len(last))
+		for _, val := range last {
+			values = append(values, val)
+		}
+
+		resp.SearchAfter, err = model.EncodeSearchCursor(values)
+		if err != nil {
+			return model.SearchNoteResponse{}, fmt.Errorf("error while creating search cursor: %w", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -2,80 +2,51 @@ package space
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
+	"errors"
 	"webserver/internal/model/elastic"
+	"webserver/internal/service/storage/postgres/pool"
+	"webserver/internal/service/storage/postgres/uow"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
-	sqldblogger "github.com/simukti/sqldb-logger"
-	"github.com/simukti/sqldb-logger/logadapter/logrusadapter"
-	"github.com/sirupsen/logrus"
 )
 
 type Repo struct {
-	db            *sql.DB
-	currentTx     *sql.Tx
+	db            *pool.DB
+	uow           *uow.UnitOfWork
 	elasticClient elasticClient
 }
 
 //go:generate mockgen -source ./space.go -destination=../.././mocks/elastic.go -package=mocks
 type elasticClient interface {
 	Save(ctx context.Context, search elastic.Data) error
-	// SearchByText производит поиск по тексту (названию). Возвращает ID из базы подходящих записей
-	SearchByText(ctx context.Context, search elastic.Data) ([]uuid.UUID, error)
-	// // SearchByID производит поиск по ID из базы. Возвращает ID  из эластика подходящих записей
-	// SearchByID(ctx context.Context, search elastic.Data) ([]string, error)
-	// Delete(ctx context.Context, search elastic.Data) error
-	// DeleteAllByUserID(ctx context.Context, data elastic.Data) error
+	// SearchByText производит поиск по тексту (названию). Возвращает страницу подходящих записей, отсортированную по релевантности
+	SearchByText(ctx context.Context, search elastic.Data, page elastic.Page) (elastic.SearchResult, error)
+	// SearchByTextInSpaces производит поиск по тексту сразу в нескольких пространствах
+	SearchByTextInSpaces(ctx context.Context, search elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error)
+	// Suggest возвращает подсказки по началу текста
+	Suggest(ctx context.Context, search elastic.Data, size int) ([]elastic.Suggestion, error)
 	UpdateNote(ctx context.Context, search elastic.Data) error
 }
 
-func New(addr string, elasticClient elasticClient) (*Repo, error) {
-	db, err := sql.Open("postgres", addr)
-	if err != nil {
-		return nil, fmt.Errorf("connect open a db driver: %w", err)
+func New(db *pool.DB, elasticClient elasticClient) (*Repo, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
 	}
 
-	logger := logrus.New()
-	logger.Level = logrus.DebugLevel           // miminum level
-	logger.Formatter = &logrus.JSONFormatter{} // logrus automatically add time field
-
-	db = sqldblogger.OpenDriver(addr, db.Driver(), logrusadapter.New(logger) /*, using_default_options*/) // db is STILL *sql.DB
-	err = db.Ping()
-	if err != nil {
-		return nil, fmt.Errorf("cannot connect to a db: %w", err)
-	} // to check connectivity and DSN correctness
-
-	return &Repo{db, nil, elasticClient}, nil
+	return &Repo{db, uow.New(db.Primary()), elasticClient}, nil
 }
 
-func (db *Repo) Close() {
-	if err := db.db.Close(); err != nil {
-		logrus.Errorf("error on closing space repo: %v", err)
-	}
+// Transaction выполняет fn в одной транзакции. Запросы репозиториев, вызванные с контекстом fn, выполняются в ней же
+func (db *Repo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.uow.Do(ctx, fn)
 }
 
-func (db *Repo) tx(ctx context.Context) (*sql.Tx, error) {
-	if db.currentTx != nil {
-		return db.currentTx, nil
-	}
-
-	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelReadCommitted,
-		ReadOnly:  false,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	db.currentTx = tx
-
-	return tx, nil
+// conn возвращает транзакцию запроса, если она есть, иначе соединение с основной базой
+func (db *Repo) conn(ctx context.Context) uow.Querier {
+	return uow.Conn(ctx, db.db.Primary())
 }
 
-// func (db *spaceRepo) commit() error {
-// 	tx := db.currentTx
-// 	db.currentTx = nil
-// 	return tx.Commit()
-// }
+// reader возвращает транзакцию запроса, если она есть, иначе соединение для чтения (реплику, если она доступна)
+func (db *Repo) reader(ctx context.Context) uow.Querier {
+	return uow.Conn(ctx, db.db.Reader())
+}
@@ -0,0 +1,82 @@
+package space
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"webserver/internal/model"
+
+	api_errors "webserver/internal/errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// GetTrashBySpaceID возвращает заметки из корзины пространства, перемещенные в корзину после deletedAfter.
+// Заметки, удаленные раньше, считаются окончательно удаленными и не возвращаются
+func (db *Repo) GetTrashBySpaceID(ctx context.Context, spaceID uuid.UUID, deletedAfter time.Time) ([]model.TrashNote, error) {
+	logrus.WithField("spaceID", spaceID).Debug("getting trash by space ID")
+
+	res := []model.TrashNote{}
+
+	rows, err := db.reader(ctx).QueryContext(ctx, `select notes.notes.id, users.users.tg_id, text, created, last_edit, type, file, deleted_at
+from notes.notes
+join users.users on users.users.id = notes.notes.user_id
+where notes.notes.space_id = $1 and deleted_at > $2
+order by deleted_at desc;`, spaceID, deletedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting trash by space id: %+v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		note := model.TrashNote{
+			GetNote: model.GetNote{
+				SpaceID: spaceID,
+			},
+		}
+
+		err := rows.Scan(&note.ID, &note.UserID, &note.Text, &note.Created, &note.LastEdit, &note.Type, &note.File, &note.Deleted)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning note from trash: %+v", err)
+		}
+
+		res = append(res, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading notes from trash: %w", err)
+	}
+
+	if len(res) == 0 {
+		return nil, api_errors.ErrNoNotesInTrash
+	}
+
+	return res, nil
+}
+
+// GetTrashNoteByID возвращает заметку из корзины, перемещенную в корзину после deletedAfter,
+// либо ошибку о том, что такой заметки в корзине нет
+func (db *Repo) GetTrashNoteByID(ctx context.Context, noteID uuid.UUID, deletedAfter time.Time) (model.TrashNote, error) {
+	logrus.WithField("noteID", noteID).Debug("getting note from trash by ID")
+
+	var note model.TrashNote
+
+	row := db.reader(ctx).QueryRowContext(ctx, `select notes.notes.id, tg_id, text, notes.notes.space_id, created, last_edit, type, file, deleted_at
+from notes.notes
+left join users.users on users.users.id = notes.notes.user_id
+where notes.notes.id = $1 and deleted_at > $2;`, noteID, deletedAfter)
+
+	err := row.Scan(&note.ID, &note.UserID, &note.Text, &note.SpaceID, &note.Created, &note.LastEdit, &note.Type, &note.File, &note.Deleted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.TrashNote{}, api_errors.ErrNoteNotFoundInTrash
+		}
+
+		return model.TrashNote{}, err
+	}
+
+	return note, nil
+}
@@ -0,0 +1,113 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Querier - то, через что репозитории выполняют запросы: *sql.DB или *sql.Tx
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Hook - действие, которое выполняется после коммита транзакции (например, обновление индекса в эластике)
+type Hook func(ctx context.Context) error
+
+// UnitOfWork выполняет операции в одной транзакции. Транзакция передается через context запроса,
+// поэтому операции разных репозиториев, вызванные внутри Do, попадают в нее же.
+// Коммит или откат делает только самый внешний Do
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+type txKey struct{}
+
+// work - транзакция запроса и действия после ее коммита
+type work struct {
+	tx    *sql.Tx
+	hooks []Hook
+}
+
+func New(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do выполняет fn в транзакции. Если в ctx уже есть транзакция, fn выполняется в ней, а коммит и откат
+// остаются внешнему Do. Иначе начинает транзакцию: коммитит ее, если fn вернула nil, и откатывает при ошибке или панике.
+// После коммита выполняет действия, зарегистрированные через AfterCommit
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*work); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("error beginning tx: %w", err)
+	}
+
+	w := &work{tx: tx}
+
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(tx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, w)); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tx: %w", err)
+	}
+
+	// транзакция уже закоммичена, поэтому ошибки действий после нее не отменяют ее, а только логируются.
+	// Сами расхождения с эластиком не исправятся: периодическая проверка согласованности только сообщает о них,
+	// исправляет их команда consistency -repair
+	hookCtx := context.WithoutCancel(ctx)
+	for _, hook := range w.hooks {
+		if err := hook(hookCtx); err != nil {
+			logrus.Errorf("error running after-commit hook: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+// AfterCommit регистрирует действие, которое выполнится после коммита транзакции из ctx.
+// Если транзакции нет, действие выполняется сразу, и его ошибка возвращается
+func AfterCommit(ctx context.Context, hook Hook) error {
+	w, ok := ctx.Value(txKey{}).(*work)
+	if !ok {
+		return hook(ctx)
+	}
+
+	w.hooks = append(w.hooks, hook)
+
+	return nil
+}
+
+// Conn возвращает транзакцию из ctx, а если ее нет - db
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if w, ok := ctx.Value(txKey{}).(*work); ok {
+		return w.tx
+	}
+
+	return db
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logrus.Errorf("error rollback tx: %+v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver - драйвер базы, который только запоминает начала, коммиты и откаты транзакций и выполненные запросы
+type fakeDriver struct {
+	mu        sync.Mutex
+	events    []string
+	commitErr error
+}
+
+func (d *fakeDriver) record(event string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.events = append(d.events, event)
+}
+
+func (d *fakeDriver) Events() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.events...)
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx нужен, чтобы database/sql разрешил уровень изоляции, отличный от уровня по умолчанию
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.d.record("begin")
+	return &fakeTx{d: c.d}, nil
+}
+
+type fakeTx struct {
+	d *fakeDriver
+}
+
+func (t *fakeTx) Commit() error {
+	t.d.record("commit")
+	return t.d.commitErr
+}
+
+func (t *fakeTx) Rollback() error {
+	t.d.record("rollback")
+	return nil
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.d.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.record(s.query)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	t.Helper()
+
+	d := &fakeDriver{}
+	db := sql.OpenDB(connector{d})
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, d
+}
+
+type connector struct {
+	d *fakeDriver
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.d }
+
+func TestDo(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	type test struct {
+		name      string
+		fn        func(ctx context.Context, db *sql.DB, u *UnitOfWork) error
+		commitErr error
+		err       error
+		events    []string
+		hookRuns  int
+	}
+
+	tests := []test{
+		{
+			name: "positive case: commit, then hooks",
+			fn: func(ctx context.Context, db *sql.DB, _ *UnitOfWork) error {
+				_, err := Conn(ctx, db).ExecContext(ctx, "update")
+				return err
+			},
+			events:   []string{"begin", "update", "commit"},
+			hookRuns: 1,
+		},
+		{
+			name: "error: rollback, no hooks",
+			fn: func(ctx context.Context, db *sql.DB, _ *UnitOfWork) error {
+				_, err := Conn(ctx, db).ExecContext(ctx, "update")
+				require.NoError(t, err)
+
+				return errFn
+			},
+			err:    errFn,
+			events: []string{"begin", "update", "rollback"},
+		},
+		{
+			name: "nested: one transaction, outer commits",
+			fn: func(ctx context.Context, db *sql.DB, u *UnitOfWork) error {
+				return u.Do(ctx, func(ctx context.Context) error {
+					_, err := Conn(ctx, db).ExecContext(ctx, "nested update")
+					return err
+				})
+			},
+			events:   []string{"begin", "nested update", "commit"},
+			hookRuns: 1,
+		},
+		{
+			name: "nested error: outer rolls back",
+			fn: func(ctx context.Context, _ *sql.DB, u *UnitOfWork) error {
+				return u.Do(ctx, func(context.Context) error {
+					return errFn
+				})
+			},
+			err:    errFn,
+			events: []string{"begin", "rollback"},
+		},
+		{
+			name:      "commit failed: no hooks",
+			fn:        func(context.Context, *sql.DB, *UnitOfWork) error { return nil },
+			commitErr: errFn,
+			err:       errFn,
+			events:    []string{"begin", "commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, d := newFakeDB(t)
+			d.commitErr = tt.commitErr
+
+			u := New(db)
+
+			hookRuns := 0
+
+			err := u.Do(context.Background(), func(ctx context.Context) error {
+				require.NoError(t, AfterCommit(ctx, func(context.Context) error {
+					// на момент вызова транзакция уже закоммичена
+					assert.Equal(t, "commit", d.Events()[len(d.Events())-1])
+					hookRuns++
+
+					return nil
+				}))
+
+				return tt.fn(ctx, db, u)
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.events, d.Events())
+			assert.Equal(t, tt.hookRuns, hookRuns)
+		})
+	}
+}
+
+func TestDo_Panic(t *testing.T) {
+	db, d := newFakeDB(t)
+
+	assert.Panics(t, func() {
+		_ = New(db).Do(context.Background(), func(context.Context) error {
+			panic("boom")
+		})
+	})
+
+	assert.Equal(t, []string{"begin", "rollback"}, d.Events())
+}
+
+func TestDo_HookError(t *testing.T) {
+	db, d := newFakeDB(t)
+
+	secondRun := false
+
+	err := New(db).Do(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, AfterCommit(ctx, func(context.Context) error { return errors.New("elastic unavailable") }))
+		require.NoError(t, AfterCommit(ctx, func(context.Context) error {
+			secondRun = true
+			return nil
+		}))
+
+		return nil
+	})
+
+	// ошибка действия после коммита не возвращается: данные в базе уже сохранены
+	require.NoError(t, err)
+	assert.True(t, secondRun)
+	assert.Equal(t, []string{"begin", "commit"}, d.Events())
+}
+
+func TestWithoutTransaction(t *testing.T) {
+	db, d := newFakeDB(t)
+	ctx := context.Background()
+
+	assert.Same(t, db, Conn(ctx, db))
+
+	_, err := Conn(ctx, db).ExecContext(ctx, "update")
+	require.NoError(t, err)
+
+	errHook := errors.New("hook failed")
+	err = AfterCommit(ctx, func(context.Context) error { return errHook })
+	assert.ErrorIs(t, err, errHook)
+
+	assert.Equal(t, []string{"update"}, d.Events())
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	db, _ := newFakeDB(t)
+	u := New(db)
+
+	// у каждого запроса своя транзакция
+	txs := make(chan Querier, 2)
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := u.Do(context.Background(), func(ctx context.Context) error {
+				txs <- Conn(ctx, db)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+	close(txs)
+
+	first, second := <-txs, <-txs
+	assert.IsType(t, &sql.Tx{}, first)
+	assert.NotSame(t, first, second)
+}
@@ -8,12 +8,13 @@ import (
 	"webserver/internal/model"
 )
 
+// GetUser возвращает пользователя по телеграм айди. Результат кэшируется, поэтому читается из основной базы
 func (db *Repo) GetUser(ctx context.Context, tgID int64) (model.User, error) {
 	res := model.User{
 		PersonalSpace: &model.Space{},
 	}
 
-	err := db.db.QueryRowContext(ctx, "select id, tg_id, username, space_id from users.users where tg_id = $1", tgID).
+	err := db.conn(ctx).QueryRowContext(ctx, "select id, tg_id, username, space_id from users.users where tg_id = $1", tgID).
 		Scan(&res.ID, &res.TgID, &res.UsernameSQL, &res.PersonalSpace.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -30,7 +31,7 @@ func (db *Repo) GetUser(ctx context.Context, tgID int64) (model.User, error) {
 
 func (db *Repo) CheckUser(ctx context.Context, tgID int64) (bool, error) {
 	var exists bool
-	err := db.db.QueryRowContext(ctx, "select exists(select 1 from users.users where tg_id = $1)", tgID).
+	err := db.reader(ctx).QueryRowContext(ctx, "select exists(select 1 from users.users where tg_id = $1)", tgID).
 		Scan(&exists)
 	if err != nil {
 		return false, err
@@ -1,64 +1,36 @@
 package user
 
 import (
-	"database/sql"
-	"fmt"
-
-	sqldblogger "github.com/simukti/sqldb-logger"
-	"github.com/simukti/sqldb-logger/logadapter/logrusadapter"
-	"github.com/sirupsen/logrus"
+	"context"
+	"errors"
+	"webserver/internal/service/storage/postgres/pool"
+	"webserver/internal/service/storage/postgres/uow"
 )
 
 type Repo struct {
-	db        *sql.DB
-	currentTx *sql.Tx
+	db  *pool.DB
+	uow *uow.UnitOfWork
 }
 
-func New(addr string) (*Repo, error) {
-	db, err := sql.Open("postgres", addr)
-	if err != nil {
-		return nil, fmt.Errorf("connect open a db driver: %w", err)
+func New(db *pool.DB) (*Repo, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
 	}
 
-	logger := logrus.New()
-	logger.Level = logrus.DebugLevel           // miminum level
-	logger.Formatter = &logrus.JSONFormatter{} // logrus automatically add time field
-
-	db = sqldblogger.OpenDriver(addr, db.Driver(), logrusadapter.New(logger) /*, using_default_options*/) // db is STILL *sql.DB
-	err = db.Ping()
-	if err != nil {
-		return nil, fmt.Errorf("cannot connect to a db: %w", err)
-	} // to check connectivity and DSN correctness
-
-	return &Repo{db, nil}, nil
+	return &Repo{db, uow.New(db.Primary())}, nil
 }
 
-func (db *Repo) Close() {
-	if err := db.db.Close(); err != nil {
-		logrus.Errorf("error on closing space repo: %v", err)
-	}
+// Transaction выполняет fn в одной транзакции. Запросы репозиториев, вызванные с контекстом fn, выполняются в ней же
+func (db *Repo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.uow.Do(ctx, fn)
 }
 
-// func (db *userRepo) tx(ctx context.Context) (*sql.Tx, error) {
-// 	if db.currentTx != nil {
-// 		return db.currentTx, nil
-// 	}
-
-// 	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{
-// 		Isolation: sql.LevelReadCommitted,
-// 		ReadOnly:  false,
-// 	})
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	db.currentTx = tx
-
-// 	return tx, nil
-// }
+// conn возвращает транзакцию запроса, если она есть, иначе соединение с основной базой
+func (db *Repo) conn(ctx context.Context) uow.Querier {
+	return uow.Conn(ctx, db.db.Primary())
+}
 
-// func (db *userRepo) commit() error {
-// 	tx := db.currentTx
-// 	db.currentTx = nil
-// 	return tx.Commit()
-// }
+// reader возвращает транзакцию запроса, если она есть, иначе соединение для чтения (реплику, если она доступна)
+func (db *Repo) reader(ctx context.Context) uow.Querier {
+	return uow.Conn(ctx, db.db.Reader())
+}
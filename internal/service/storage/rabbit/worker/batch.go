@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"webserver/internal/model/rabbit"
+)
+
+// BatchNotes отправляет пакет операций над заметками одним сообщением
+func (s *Worker) BatchNotes(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("sending notes batch")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.BatchOp, bodyJSON, req.GetID())
+}
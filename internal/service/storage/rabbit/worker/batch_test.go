@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	api_model "webserver/internal/model"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/service/storage/rabbit/worker/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchNotes(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.BatchNotesRequest
+		err  error
+	}
+
+	spaceID := uuid.New()
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.BatchNotesRequest{
+				ID:      uuid.New(),
+				SpaceID: spaceID,
+				Items: []rabbit.BatchNoteItem{
+					{
+						Create: &rabbit.CreateNoteRequest{
+							ID:        uuid.New(),
+							UserID:    1,
+							SpaceID:   spaceID,
+							Text:      "new note",
+							Type:      api_model.TextNoteType,
+							Created:   5678,
+							Operation: rabbit.CreateOp,
+						},
+					},
+					{
+						Delete: &rabbit.DeleteNoteRequest{
+							ID:        uuid.New(),
+							SpaceID:   spaceID,
+							NoteID:    uuid.New(),
+							Created:   5678,
+							Operation: rabbit.DeleteOp,
+						},
+					},
+				},
+				Created:   5678,
+				Operation: rabbit.BatchOp,
+			},
+		},
+		{
+			name: "invalid request",
+			req: rabbit.BatchNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   spaceID,
+				Created:   5678,
+				Operation: rabbit.BatchOp,
+			},
+			err: api_model.ErrEmptyBatch,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.BatchOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.BatchNotes(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"webserver/internal/model/rabbit"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// eventTimeout - сколько ждать удаления записей из кэша по одному событию
+const eventTimeout = 5 * time.Second
+
+// пауза между попытками переподписаться на события растет от minEventsBackoff до maxEventsBackoff
+const (
+	minEventsBackoff = time.Second
+	maxEventsBackoff = 30 * time.Second
+)
+
+// errEventsClosed - воркер закрыт, переподписываться на события не нужно
+var errEventsClosed = errors.New("rabbit: events channel is closed")
+
+// ConsumeEvents начинает читать события об изменениях от db-worker и удалять по ним устаревшие записи из кэша.
+// Каждый экземпляр вебсервера читает события из своей временной очереди, чтобы все экземпляры получили все события.
+// Сообщения подтверждаются сразу: если запись не удалось удалить, она устареет по TTL.
+// Если канал закроется, воркер переподпишется на события в новом канале
+func (s *Worker) ConsumeEvents(ctx context.Context) error {
+	if s.config.eventsExchange == "" {
+		return errors.New("rabbit: events exchange is not set")
+	}
+
+	deliveries, err := s.subscribeEvents(s.events)
+	if err != nil {
+		return err
+	}
+
+	go s.consumeEvents(ctx, deliveries)
+
+	return nil
+}
+
+// subscribeEvents объявляет exchange событий и временную очередь в канале ch и начинает читать очередь
+func (s *Worker) subscribeEvents(ch channel) (<-chan amqp.Delivery, error) {
+	err := ch.ExchangeDeclare(
+		s.config.eventsExchange, // name
+		"topic",                 // type
+		true,                    // durable
+		false,                   // auto-deleted
+		false,                   // internal
+		false,                   // no-wait
+		nil,                     // arguments
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating exchange %s: %+v", s.config.eventsExchange, err)
+	}
+
+	queue, err := ch.QueueDeclare(
+		"",    // name: генерирует rabbit
+		false, // durable
+		true,  // auto-deleted
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating events queue: %+v", err)
+	}
+
+	err = ch.QueueBind(queue.Name, "#", s.config.eventsExchange, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error binding events queue to exchange %s: %+v", s.config.eventsExchange, err)
+	}
+
+	deliveries, err := ch.Consume(
+		queue.Name, // queue
+		"",         // consumer
+		true,       // auto-ack
+		true,       // exclusive
+		false,      // no-local
+		false,      // no-wait
+		nil,        // args
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error consuming events queue: %+v", err)
+	}
+
+	s.logger.WithField("exchange", s.config.eventsExchange).WithField("queue", queue.Name).Info("consuming change events")
+
+	return deliveries, nil
+}
+
+// consumeEvents обрабатывает события, пока не отменят ctx или не закроют воркер.
+// Если канал закрылся, переподписывается на события
+func (s *Worker) consumeEvents(ctx context.Context, deliveries <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d, ok := <-deliveries:
+			if !ok {
+				deliveries = s.resubscribeEvents(ctx)
+				if deliveries == nil {
+					return
+				}
+
+				continue
+			}
+
+			if err := s.handleEvent(ctx, d.Body); err != nil {
+				s.logger.WithField("routing_key", d.RoutingKey).Errorf("error handling change event: %+v", err)
+			}
+		}
+	}
+}
+
+// resubscribeEvents открывает новый канал и подписывается на события, повторяя попытки с растущей паузой.
+// События, пришедшие, пока подписки не было, теряются: такие записи устареют по TTL.
+// Возвращает nil, если отменили ctx или закрыли воркер
+func (s *Worker) resubscribeEvents(ctx context.Context) <-chan amqp.Delivery {
+	backoff := cmp.Or(s.eventsBackoff, minEventsBackoff)
+
+	s.logger.Warnf("events channel closed, resubscribing in %s", backoff)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		deliveries, err := s.reopenEvents()
+		if errors.Is(err, errEventsClosed) {
+			return nil
+		}
+
+		if err == nil {
+			return deliveries
+		}
+
+		backoff = min(backoff*2, maxEventsBackoff)
+
+		s.logger.Errorf("error resubscribing to change events, retrying in %s: %+v", backoff, err)
+	}
+}
+
+// reopenEvents заменяет закрытый канал событий новым и подписывается на события в нем
+func (s *Worker) reopenEvents() (<-chan amqp.Delivery, error) {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
+	if s.eventsClosed {
+		return nil, errEventsClosed
+	}
+
+	if s.events != nil {
+		// канал уже закрыт rabbit, ошибка о повторном закрытии не важна
+		_ = s.events.Close()
+		s.events = nil
+	}
+
+	ch, err := s.newEventsChannel()
+	if err != nil {
+		return nil, fmt.Errorf("error opening events channel: %w", err)
+	}
+
+	s.events = ch
+
+	return s.subscribeEvents(ch)
+}
+
+// handleEvent удаляет из кэша записи, которые изменились по событию
+func (s *Worker) handleEvent(ctx context.Context, body []byte) error {
+	var event rabbit.ChangeEvent
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("error decoding event: %w", err)
+	}
+
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	s.logger.WithField("event", event).Debug("handling change event")
+
+	ctx, cancel := context.WithTimeout(ctx, eventTimeout)
+	defer cancel()
+
+	switch event.Type {
+	case rabbit.SpaceUpdatedEvent, rabbit.SpaceDeletedEvent:
+		return s.spaceCache.DeleteSpace(ctx, event.SpaceID)
+	case rabbit.ParticipantAddedEvent, rabbit.ParticipantRemovedEvent:
+		return s.spaceCache.DeleteMembers(ctx, event.SpaceID)
+	case rabbit.UserCreatedEvent, rabbit.UserUpdatedEvent:
+		return s.userCache.DeleteUser(ctx, event.UserID)
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/service/storage/rabbit/worker/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumeEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+	spaceCache := mocks.NewMockspaceCache(ctrl)
+	userCache := mocks.NewMockuserCache(ctrl)
+
+	w := createTestEventsWorker(t, ch, spaceCache, userCache)
+
+	spaceID := uuid.New()
+	deliveries := make(chan amqp.Delivery)
+	handled := make(chan struct{})
+
+	gomock.InOrder(
+		ch.EXPECT().ExchangeDeclare("events", "topic", true, false, false, false, gomock.Any()).Return(nil),
+		ch.EXPECT().QueueDeclare("", false, true, true, false, gomock.Any()).Return(amqp.Queue{Name: "amq.gen-1"}, nil),
+		ch.EXPECT().QueueBind("amq.gen-1", "#", "events", false, gomock.Any()).Return(nil),
+		ch.EXPECT().Consume("amq.gen-1", "", true, true, false, false, gomock.Any()).Return((<-chan amqp.Delivery)(deliveries), nil),
+	)
+
+	spaceCache.EXPECT().DeleteSpace(gomock.Any(), spaceID).DoAndReturn(func(context.Context, uuid.UUID) error {
+		close(handled)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := w.ConsumeEvents(ctx)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(rabbit.ChangeEvent{Type: rabbit.SpaceUpdatedEvent, SpaceID: spaceID})
+	require.NoError(t, err)
+
+	deliveries <- amqp.Delivery{RoutingKey: string(rabbit.SpaceUpdatedEvent), Body: body}
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("event was not handled")
+	}
+}
+
+func TestConsumeEvents_Resubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	closed, reopened := mocks.NewMockchannel(ctrl), mocks.NewMockchannel(ctrl)
+	spaceCache := mocks.NewMockspaceCache(ctrl)
+
+	w := createTestEventsWorker(t, closed, spaceCache, mocks.NewMockuserCache(ctrl))
+	w.eventsBackoff = time.Millisecond
+
+	// первая попытка открыть канал не удалась, вторая - удалась
+	opened := 0
+	w.openEvents = func() (channel, error) {
+		opened++
+		if opened == 1 {
+			return nil, errors.New("connection refused")
+		}
+
+		return reopened, nil
+	}
+
+	spaceID := uuid.New()
+	first, second := make(chan amqp.Delivery), make(chan amqp.Delivery)
+	handled := make(chan struct{})
+
+	closed.EXPECT().ExchangeDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	closed.EXPECT().QueueDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(amqp.Queue{Name: "amq.gen-1"}, nil)
+	closed.EXPECT().QueueBind("amq.gen-1", "#", "events", false, gomock.Any()).Return(nil)
+	closed.EXPECT().Consume("amq.gen-1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return((<-chan amqp.Delivery)(first), nil)
+	closed.EXPECT().Close().Return(amqp.ErrClosed)
+
+	reopened.EXPECT().ExchangeDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	reopened.EXPECT().QueueDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(amqp.Queue{Name: "amq.gen-2"}, nil)
+	reopened.EXPECT().QueueBind("amq.gen-2", "#", "events", false, gomock.Any()).Return(nil)
+	reopened.EXPECT().Consume("amq.gen-2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return((<-chan amqp.Delivery)(second), nil)
+
+	spaceCache.EXPECT().DeleteMembers(gomock.Any(), spaceID).DoAndReturn(func(context.Context, uuid.UUID) error {
+		close(handled)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, w.ConsumeEvents(ctx))
+
+	// rabbit закрыл канал
+	close(first)
+
+	body, err := json.Marshal(rabbit.ChangeEvent{Type: rabbit.ParticipantAddedEvent, SpaceID: spaceID, UserID: 123})
+	require.NoError(t, err)
+
+	select {
+	case second <- amqp.Delivery{RoutingKey: string(rabbit.ParticipantAddedEvent), Body: body}:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not resubscribe to events")
+	}
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("event was not handled after resubscribe")
+	}
+
+	assert.Equal(t, 2, opened)
+}
+
+func TestResubscribeEvents_Closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := createTestEventsWorker(t, mocks.NewMockchannel(ctrl), mocks.NewMockspaceCache(ctrl), mocks.NewMockuserCache(ctrl))
+	w.eventsBackoff = time.Millisecond
+	w.eventsClosed = true
+	w.openEvents = func() (channel, error) {
+		t.Error("closed worker must not open events channel")
+		return nil, nil
+	}
+
+	// воркер закрыт при остановке сервера - переподписываться не нужно
+	assert.Nil(t, w.resubscribeEvents(context.Background()))
+}
+
+func TestConsumeEvents_Errors(t *testing.T) {
+	t.Run("events exchange not set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		w := createTestWorker(t, mocks.NewMockchannel(ctrl))
+
+		assert.Error(t, w.ConsumeEvents(context.Background()))
+	})
+
+	t.Run("queue declare error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ch := mocks.NewMockchannel(ctrl)
+		w := createTestEventsWorker(t, ch, mocks.NewMockspaceCache(ctrl), mocks.NewMockuserCache(ctrl))
+
+		ch.EXPECT().ExchangeDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		ch.EXPECT().QueueDeclare(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(amqp.Queue{}, errors.New("channel closed"))
+
+		assert.Error(t, w.ConsumeEvents(context.Background()))
+	})
+}
+
+func TestHandleEvent(t *testing.T) {
+	type fields struct {
+		spaceCache *mocks.MockspaceCache
+		userCache  *mocks.MockuserCache
+	}
+
+	type test struct {
+		name       string
+		event      rabbit.ChangeEvent
+		setupMocks func(f *fields)
+		err        error
+	}
+
+	spaceID := uuid.New()
+
+	tests := []test{
+		{
+			name:  "space updated: space and members evicted",
+			event: rabbit.ChangeEvent{Type: rabbit.SpaceUpdatedEvent, SpaceID: spaceID},
+			setupMocks: func(f *fields) {
+				f.spaceCache.EXPECT().DeleteSpace(gomock.Any(), spaceID).Return(nil)
+			},
+		},
+		{
+			name:  "space deleted",
+			event: rabbit.ChangeEvent{Type: rabbit.SpaceDeletedEvent, SpaceID: spaceID},
+			setupMocks: func(f *fields) {
+				f.spaceCache.EXPECT().DeleteSpace(gomock.Any(), spaceID).Return(nil)
+			},
+		},
+		{
+			name:  "participant added: members evicted",
+			event: rabbit.ChangeEvent{Type: rabbit.ParticipantAddedEvent, SpaceID: spaceID, UserID: 123},
+			setupMocks: func(f *fields) {
+				f.spaceCache.EXPECT().DeleteMembers(gomock.Any(), spaceID).Return(nil)
+			},
+		},
+		{
+			name:  "participant removed",
+			event: rabbit.ChangeEvent{Type: rabbit.ParticipantRemovedEvent, SpaceID: spaceID, UserID: 123},
+			setupMocks: func(f *fields) {
+				f.spaceCache.EXPECT().DeleteMembers(gomock.Any(), spaceID).Return(nil)
+			},
+		},
+		{
+			name:  "user created",
+			event: rabbit.ChangeEvent{Type: rabbit.UserCreatedEvent, UserID: 123},
+			setupMocks: func(f *fields) {
+				f.userCache.EXPECT().DeleteUser(gomock.Any(), int64(123)).Return(nil)
+			},
+		},
+		{
+			name:  "user updated",
+			event: rabbit.ChangeEvent{Type: rabbit.UserUpdatedEvent, UserID: 123},
+			setupMocks: func(f *fields) {
+				f.userCache.EXPECT().DeleteUser(gomock.Any(), int64(123)).Return(nil)
+			},
+		},
+		{
+			name:       "unknown event: nothing evicted",
+			event:      rabbit.ChangeEvent{Type: "note.created"},
+			setupMocks: func(f *fields) {},
+			err:        rabbit.ErrUnknownEvent,
+		},
+		{
+			name:  "cache error",
+			event: rabbit.ChangeEvent{Type: rabbit.UserUpdatedEvent, UserID: 123},
+			setupMocks: func(f *fields) {
+				f.userCache.EXPECT().DeleteUser(gomock.Any(), int64(123)).Return(errors.New("redis error"))
+			},
+			err: errors.New("redis error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := &fields{spaceCache: mocks.NewMockspaceCache(ctrl), userCache: mocks.NewMockuserCache(ctrl)}
+			tt.setupMocks(f)
+
+			w := createTestEventsWorker(t, mocks.NewMockchannel(ctrl), f.spaceCache, f.userCache)
+
+			body, err := json.Marshal(tt.event)
+			require.NoError(t, err)
+
+			err = w.handleEvent(context.Background(), body)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		w := createTestEventsWorker(t, mocks.NewMockchannel(ctrl), mocks.NewMockspaceCache(ctrl), mocks.NewMockuserCache(ctrl))
+
+		assert.Error(t, w.handleEvent(context.Background(), []byte("{")))
+	})
+}
+
+func createTestEventsWorker(t *testing.T, ch *mocks.Mockchannel, spaceCache *mocks.MockspaceCache, userCache *mocks.MockuserCache) *Worker {
+	t.Helper()
+
+	w := createTestWorker(t, ch)
+
+	w.config.eventsExchange = "events"
+	w.events = ch
+	w.spaceCache = spaceCache
+	w.userCache = userCache
+
+	return w
+}
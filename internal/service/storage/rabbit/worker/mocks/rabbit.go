@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	amqp091 "github.com/rabbitmq/amqp091-go"
 )
 
@@ -49,6 +50,35 @@ func (mr *MockchannelMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*Mockchannel)(nil).Close))
 }
 
+// Consume mocks base method.
+func (m *Mockchannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp091.Table) (<-chan amqp091.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+	ret0, _ := ret[0].(<-chan amqp091.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockchannelMockRecorder) Consume(queue, consumer, autoAck, exclusive, noLocal, noWait, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*Mockchannel)(nil).Consume), queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+}
+
+// ExchangeDeclare mocks base method.
+func (m *Mockchannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp091.Table) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeDeclare", name, kind, durable, autoDelete, internal, noWait, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExchangeDeclare indicates an expected call of ExchangeDeclare.
+func (mr *MockchannelMockRecorder) ExchangeDeclare(name, kind, durable, autoDelete, internal, noWait, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeDeclare", reflect.TypeOf((*Mockchannel)(nil).ExchangeDeclare), name, kind, durable, autoDelete, internal, noWait, args)
+}
+
 // PublishWithContext mocks base method.
 func (m *Mockchannel) PublishWithContext(arg0 context.Context, exchange, key string, mandatory, immediate bool, msg amqp091.Publishing) error {
 	m.ctrl.T.Helper()
@@ -62,3 +92,120 @@ func (mr *MockchannelMockRecorder) PublishWithContext(arg0, exchange, key, manda
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishWithContext", reflect.TypeOf((*Mockchannel)(nil).PublishWithContext), arg0, exchange, key, mandatory, immediate, msg)
 }
+
+// QueueBind mocks base method.
+func (m *Mockchannel) QueueBind(name, key, exchange string, noWait bool, args amqp091.Table) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueBind", name, key, exchange, noWait, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueBind indicates an expected call of QueueBind.
+func (mr *MockchannelMockRecorder) QueueBind(name, key, exchange, noWait, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueBind", reflect.TypeOf((*Mockchannel)(nil).QueueBind), name, key, exchange, noWait, args)
+}
+
+// QueueDeclare mocks base method.
+func (m *Mockchannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) (amqp091.Queue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueDeclare", name, durable, autoDelete, exclusive, noWait, args)
+	ret0, _ := ret[0].(amqp091.Queue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueDeclare indicates an expected call of QueueDeclare.
+func (mr *MockchannelMockRecorder) QueueDeclare(name, durable, autoDelete, exclusive, noWait, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueDeclare", reflect.TypeOf((*Mockchannel)(nil).QueueDeclare), name, durable, autoDelete, exclusive, noWait, args)
+}
+
+// MockspaceCache is a mock of spaceCache interface.
+type MockspaceCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockspaceCacheMockRecorder
+}
+
+// MockspaceCacheMockRecorder is the mock recorder for MockspaceCache.
+type MockspaceCacheMockRecorder struct {
+	mock *MockspaceCache
+}
+
+// NewMockspaceCache creates a new mock instance.
+func NewMockspaceCache(ctrl *gomock.Controller) *MockspaceCache {
+	mock := &MockspaceCache{ctrl: ctrl}
+	mock.recorder = &MockspaceCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspaceCache) EXPECT() *MockspaceCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteMembers mocks base method.
+func (m *MockspaceCache) DeleteMembers(ctx context.Context, spaceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembers", ctx, spaceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMembers indicates an expected call of DeleteMembers.
+func (mr *MockspaceCacheMockRecorder) DeleteMembers(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembers", reflect.TypeOf((*MockspaceCache)(nil).DeleteMembers), ctx, spaceID)
+}
+
+// DeleteSpace mocks base method.
+func (m *MockspaceCache) DeleteSpace(ctx context.Context, spaceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSpace", ctx, spaceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSpace indicates an expected call of DeleteSpace.
+func (mr *MockspaceCacheMockRecorder) DeleteSpace(ctx, spaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSpace", reflect.TypeOf((*MockspaceCache)(nil).DeleteSpace), ctx, spaceID)
+}
+
+// MockuserCache is a mock of userCache interface.
+type MockuserCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserCacheMockRecorder
+}
+
+// MockuserCacheMockRecorder is the mock recorder for MockuserCache.
+type MockuserCacheMockRecorder struct {
+	mock *MockuserCache
+}
+
+// NewMockuserCache creates a new mock instance.
+func NewMockuserCache(ctrl *gomock.Controller) *MockuserCache {
+	mock := &MockuserCache{ctrl: ctrl}
+	mock.recorder = &MockuserCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserCache) EXPECT() *MockuserCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockuserCache) DeleteUser(ctx context.Context, tgID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, tgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockuserCacheMockRecorder) DeleteUser(ctx, tgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockuserCache)(nil).DeleteUser), ctx, tgID)
+}
@@ -65,3 +65,18 @@ func (s *Worker) DeleteAllNotes(ctx context.Context, req rabbit.Model) error {
 
 	return s.publish(ctx, s.config.notesExchange, rabbit.DeleteAllOp, bodyJSON, req.GetID())
 }
+
+func (s *Worker) MoveNote(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("moving note")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.MoveOp, bodyJSON, req.GetID())
+}
@@ -220,6 +220,77 @@ func TestDeleteNote(t *testing.T) {
 	}
 }
 
+func TestMoveNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.MoveNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.MoveNoteRequest{
+				ID:            uuid.New(),
+				UserID:        1,
+				NoteID:        uuid.New(),
+				SpaceID:       uuid.New(),
+				TargetSpaceID: uuid.New(),
+				Created:       5678,
+				Operation:     rabbit.MoveOp,
+			},
+		},
+		{
+			name: "invalid note",
+			req: rabbit.MoveNoteRequest{
+				ID:        uuid.New(),
+				UserID:    1,
+				NoteID:    uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   5678,
+				Operation: rabbit.MoveOp,
+			},
+			err: api_model.ErrInvalidSpaceID,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.MoveOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.MoveNote(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func createTestWorker(t *testing.T, ch *mocks.Mockchannel) *Worker {
 	t.Helper()
 
@@ -236,6 +307,7 @@ func createTestWorker(t *testing.T, ch *mocks.Mockchannel) *Worker {
 			address        string
 			notesExchange  string
 			spacesExchange string
+			eventsExchange string
 		}{
 			address:        "amqp://localhost:5672/",
 			notesExchange:  "notes",
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"webserver/internal/model/rabbit"
+)
+
+func (s *Worker) RestoreNote(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("restoring note")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.RestoreOp, bodyJSON, req.GetID())
+}
+
+func (s *Worker) RestoreAllNotes(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("restoring all notes")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.RestoreAllOp, bodyJSON, req.GetID())
+}
+
+func (s *Worker) PurgeNote(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("purging note")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.PurgeOp, bodyJSON, req.GetID())
+}
+
+func (s *Worker) PurgeAllNotes(ctx context.Context, req rabbit.Model) error {
+	s.logger.WithField("request_id", req.GetID()).Debug("purging all notes")
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	bodyJSON, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, s.config.notesExchange, rabbit.PurgeAllOp, bodyJSON, req.GetID())
+}
@@ -0,0 +1,280 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	api_model "webserver/internal/model"
+	"webserver/internal/model/rabbit"
+	"webserver/internal/service/storage/rabbit/worker/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.RestoreNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.RestoreNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   5678,
+				Operation: rabbit.RestoreOp,
+			},
+		},
+		{
+			name: "invalid request",
+			req: rabbit.RestoreNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   5678,
+				Operation: rabbit.RestoreOp,
+			},
+			err: api_model.ErrInvalidSpaceID,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.RestoreOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.RestoreNote(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreAllNotes(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.RestoreAllNotesRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   5678,
+				Operation: rabbit.RestoreAllOp,
+			},
+		},
+		{
+			name: "invalid request",
+			req: rabbit.RestoreAllNotesRequest{
+				ID:        uuid.New(),
+				Created:   5678,
+				Operation: rabbit.RestoreAllOp,
+			},
+			err: api_model.ErrInvalidSpaceID,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.RestoreAllOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.RestoreAllNotes(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeNote(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.PurgeNoteRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.PurgeNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   5678,
+				Operation: rabbit.PurgeOp,
+			},
+		},
+		{
+			name: "invalid request",
+			req: rabbit.PurgeNoteRequest{
+				ID:        uuid.New(),
+				NoteID:    uuid.New(),
+				Created:   5678,
+				Operation: rabbit.PurgeOp,
+			},
+			err: api_model.ErrInvalidSpaceID,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.PurgeOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.PurgeNote(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeAllNotes(t *testing.T) {
+	type test struct {
+		name string
+		req  rabbit.PurgeAllNotesRequest
+		err  error
+	}
+
+	tests := []test{
+		{
+			name: "positive case",
+			req: rabbit.PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				SpaceID:   uuid.New(),
+				Created:   5678,
+				Operation: rabbit.PurgeAllOp,
+			},
+		},
+		{
+			name: "invalid request",
+			req: rabbit.PurgeAllNotesRequest{
+				ID:        uuid.New(),
+				Created:   5678,
+				Operation: rabbit.PurgeAllOp,
+			},
+			err: api_model.ErrInvalidSpaceID,
+		},
+	}
+
+	notesExchangeName := "notes"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := mocks.NewMockchannel(ctrl)
+
+	w := createTestWorker(t, ch)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				ch.EXPECT().PublishWithContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) {
+						assert.Equal(t, notesExchangeName, exchange)
+						assert.Equal(t, string(rabbit.PurgeAllOp), key)
+						assert.False(t, mandatory)
+						assert.False(t, immediate)
+						assert.Equal(t, "application/json", msg.ContentType)
+
+						actualBody, err := json.Marshal(tt.req)
+						require.NoError(t, err)
+
+						assert.Equal(t, actualBody, msg.Body)
+					}).Return(nil)
+			}
+
+			err := w.PurgeAllNotes(context.Background(), &tt.req)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 	"webserver/internal/model/rabbit"
 
 	"github.com/ex-rate/logger"
@@ -17,20 +19,50 @@ type Worker struct {
 		// exchanges
 		notesExchange  string
 		spacesExchange string
+		eventsExchange string // события об изменениях от db-worker. Если не задан, события не читаются
 	}
 
 	conn    *amqp.Connection
 	channel channel
 
+	// события читаются в отдельном соединении, чтобы чтение не мешало публикации
+	// и соединение можно было открыть заново, если оно оборвется
+	eventsMu      sync.Mutex // защищает events, eventsConn и eventsClosed при переподписке
+	events        channel
+	eventsConn    *amqp.Connection
+	eventsClosed  bool
+	openEvents    func() (channel, error) // открывает канал для событий. Если не задана, dialEvents
+	eventsBackoff time.Duration           // пауза перед первой попыткой переподписаться на события. Если не задана, minEventsBackoff
+
+	spaceCache spaceCache
+	userCache  userCache
+
 	logger *logger.Logger
 }
 
 //go:generate mockgen -source ./worker.go -destination=./mocks/rabbit.go -package=mocks
 type channel interface {
 	PublishWithContext(_ context.Context, exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) error
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
 	Close() error
 }
 
+// spaceCache - кэш пространств, из которого удаляются устаревшие записи по событиям
+type spaceCache interface {
+	// DeleteSpace удаляет из кэша пространство вместе с его участниками
+	DeleteSpace(ctx context.Context, spaceID uuid.UUID) error
+	// DeleteMembers удаляет из кэша участников пространства
+	DeleteMembers(ctx context.Context, spaceID uuid.UUID) error
+}
+
+// userCache - кэш пользователей, из которого удаляются устаревшие записи по событиям
+type userCache interface {
+	DeleteUser(ctx context.Context, tgID int64) error
+}
+
 type RabbitOption func(*Worker)
 
 func WithAddress(address string) RabbitOption {
@@ -51,6 +83,25 @@ func WithSpacesExchange(spacesExchange string) RabbitOption {
 	}
 }
 
+// WithEventsExchange включает чтение событий об изменениях от db-worker. Нужны кэши, из которых удаляются записи
+func WithEventsExchange(eventsExchange string) RabbitOption {
+	return func(w *Worker) {
+		w.config.eventsExchange = eventsExchange
+	}
+}
+
+func WithSpaceCache(cache spaceCache) RabbitOption {
+	return func(w *Worker) {
+		w.spaceCache = cache
+	}
+}
+
+func WithUserCache(cache userCache) RabbitOption {
+	return func(w *Worker) {
+		w.userCache = cache
+	}
+}
+
 func WithLogger(logger *logger.Logger) RabbitOption {
 	return func(w *Worker) {
 		w.logger = logger
@@ -80,6 +131,14 @@ func New(opts ...RabbitOption) (*Worker, error) {
 		return nil, fmt.Errorf("rabbit: logger is required")
 	}
 
+	if w.config.eventsExchange != "" && w.spaceCache == nil {
+		return nil, fmt.Errorf("rabbit: space cache is required to consume events")
+	}
+
+	if w.config.eventsExchange != "" && w.userCache == nil {
+		return nil, fmt.Errorf("rabbit: user cache is required to consume events")
+	}
+
 	return w, nil
 }
 
@@ -126,15 +185,65 @@ func (s *Worker) Connect() error {
 		return fmt.Errorf("error creating exchange %s: %+v", s.config.spacesExchange, err)
 	}
 
+	if s.config.eventsExchange == "" {
+		return nil
+	}
+
+	events, err := s.newEventsChannel()
+	if err != nil {
+		return err
+	}
+
+	s.events = events
+
 	return nil
 }
 
+func (s *Worker) newEventsChannel() (channel, error) {
+	if s.openEvents != nil {
+		return s.openEvents()
+	}
+
+	return s.dialEvents()
+}
+
+// dialEvents открывает канал для чтения событий. Если соединения для событий нет или оно закрыто, подключается заново
+func (s *Worker) dialEvents() (channel, error) {
+	if s.eventsConn == nil || s.eventsConn.IsClosed() {
+		conn, err := amqp.Dial(s.config.address)
+		if err != nil {
+			return nil, err
+		}
+
+		s.eventsConn = conn
+	}
+
+	return s.eventsConn.Channel()
+}
+
 func (s *Worker) Close() error {
 	err := s.channel.Close()
 	if err != nil {
 		s.logger.Errorf("error closing channel rabbit mq: %+v", err)
 	}
 
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
+	s.eventsClosed = true
+
+	if s.events != nil {
+		if err := s.events.Close(); err != nil {
+			s.logger.Errorf("error closing events channel rabbit mq: %+v", err)
+		}
+	}
+
+	if s.eventsConn != nil {
+		if err := s.eventsConn.Close(); err != nil {
+			s.logger.Errorf("error closing events connection rabbit mq: %+v", err)
+		}
+	}
+
 	return s.conn.Close()
 }
 
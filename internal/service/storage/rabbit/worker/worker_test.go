@@ -3,8 +3,10 @@ package worker
 import (
 	"fmt"
 	"testing"
+	"webserver/internal/service/storage/rabbit/worker/mocks"
 
 	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,6 +19,12 @@ func TestNew(t *testing.T) {
 
 	workerLogger := logger.WithService("worker")
 
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spaceCache := mocks.NewMockspaceCache(ctrl)
+	userCache := mocks.NewMockuserCache(ctrl)
+
 	tests := []struct {
 		name string
 		opts []RabbitOption
@@ -36,6 +44,7 @@ func TestNew(t *testing.T) {
 					address        string
 					notesExchange  string
 					spacesExchange string
+					eventsExchange string
 				}{
 					address:        "amqp://localhost:5672/",
 					notesExchange:  "notes",
@@ -71,6 +80,58 @@ func TestNew(t *testing.T) {
 			},
 			err: fmt.Errorf("rabbit: spaces exchange is required"),
 		},
+		{
+			name: "positive case: with events",
+			opts: []RabbitOption{
+				WithAddress("amqp://localhost:5672/"),
+				WithNotesExchange("notes"),
+				WithSpacesExchange("spaces"),
+				WithEventsExchange("events"),
+				WithSpaceCache(spaceCache),
+				WithUserCache(userCache),
+				WithLogger(workerLogger),
+			},
+			want: &Worker{
+				config: struct {
+					address        string
+					notesExchange  string
+					spacesExchange string
+					eventsExchange string
+				}{
+					address:        "amqp://localhost:5672/",
+					notesExchange:  "notes",
+					spacesExchange: "spaces",
+					eventsExchange: "events",
+				},
+				spaceCache: spaceCache,
+				userCache:  userCache,
+				logger:     workerLogger,
+			},
+		},
+		{
+			name: "negative case: space cache is required to consume events",
+			opts: []RabbitOption{
+				WithAddress("amqp://localhost:5672/"),
+				WithNotesExchange("notes"),
+				WithSpacesExchange("spaces"),
+				WithEventsExchange("events"),
+				WithUserCache(userCache),
+				WithLogger(workerLogger),
+			},
+			err: fmt.Errorf("rabbit: space cache is required to consume events"),
+		},
+		{
+			name: "negative case: user cache is required to consume events",
+			opts: []RabbitOption{
+				WithAddress("amqp://localhost:5672/"),
+				WithNotesExchange("notes"),
+				WithSpacesExchange("spaces"),
+				WithEventsExchange("events"),
+				WithSpaceCache(spaceCache),
+				WithLogger(workerLogger),
+			},
+			err: fmt.Errorf("rabbit: user cache is required to consume events"),
+		},
 		{
 			name: "negative case: logger is required",
 			opts: []RabbitOption{
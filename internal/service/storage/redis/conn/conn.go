@@ -0,0 +1,142 @@
+package conn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const pingTimeout = 5 * time.Second
+
+// Config - параметры подключения к редису
+type Config struct {
+	Addresses  []string // один адрес - отдельный сервер, несколько - узлы кластера
+	Cluster    bool     // кластер, даже если задан один адрес
+	MasterName string   // имя мастера в sentinel. Если задано, Addresses - адреса sentinel
+
+	// Авторизация: пароль или пользователь ACL с паролем
+	Username         string
+	Password         string
+	SentinelUsername string
+	SentinelPassword string
+
+	DB int
+
+	TLS        bool
+	CACertFile string // путь к сертификату CA в формате PEM. Если не задан, используются системные CA
+	CertFile   string // клиентский сертификат и ключ в формате PEM
+	KeyFile    string
+	ServerName string
+
+	// Если не заданы, используются значения по умолчанию клиента редиса
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	PoolTimeout  time.Duration
+}
+
+// Open подключается к редису. Клиент общий для всех кэшей: закрывать его нужно при остановке приложения
+func Open(ctx context.Context, cfg Config) (redis.UniversalClient, error) {
+	opts, err := options(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var client redis.UniversalClient
+
+	// NewUniversalClient считает кластером только несколько адресов
+	if cfg.Cluster && len(cfg.MasterName) == 0 {
+		client = redis.NewClusterClient(opts.Cluster())
+	} else {
+		client = redis.NewUniversalClient(opts)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	if err := client.Ping(pingCtx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error connecting redis: %w", err)
+	}
+
+	return client, nil
+}
+
+// options переводит параметры подключения в параметры клиента редиса
+func options(cfg Config) (*redis.UniversalOptions, error) {
+	if len(cfg.Addresses) == 0 {
+		return nil, errors.New("redis address is required")
+	}
+
+	cluster := len(cfg.MasterName) == 0 && (cfg.Cluster || len(cfg.Addresses) > 1)
+	if cluster && cfg.DB != 0 {
+		return nil, errors.New("redis cluster supports only db 0")
+	}
+
+	tlsConfig, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redis.UniversalOptions{
+		Addrs:            cfg.Addresses,
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		TLSConfig:        tlsConfig,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+		PoolTimeout:      cfg.PoolTimeout,
+	}, nil
+}
+
+// tlsConfig возвращает настройки TLS или nil, если TLS выключен
+func tlsConfig(cfg Config) (*tls.Config, error) {
+	if !cfg.TLS {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if len(cfg.CACertFile) > 0 {
+		ca, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading redis CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates in redis CA file %s", cfg.CACertFile)
+		}
+
+		tlsCfg.RootCAs = pool
+	}
+
+	if len(cfg.CertFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading redis client certificate: %w", err)
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
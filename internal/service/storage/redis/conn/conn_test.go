@@ -0,0 +1,106 @@
+package conn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	type test struct {
+		name string
+		cfg  Config
+		err  string
+	}
+
+	tests := []test{
+		{
+			name: "single node with acl user and db",
+			cfg:  Config{Addresses: []string{"localhost:6379"}, Username: "webserver", Password: "password", DB: 2, PoolSize: 20},
+		},
+		{
+			name: "sentinel with db",
+			cfg:  Config{Addresses: []string{"sentinel-1:26379", "sentinel-2:26379"}, MasterName: "mymaster", DB: 2},
+		},
+		{
+			name: "cluster",
+			cfg:  Config{Addresses: []string{"redis-1:6379", "redis-2:6379"}},
+		},
+		{
+			name: "no address",
+			cfg:  Config{},
+			err:  "redis address is required",
+		},
+		{
+			name: "cluster with db",
+			cfg:  Config{Addresses: []string{"redis-1:6379", "redis-2:6379"}, DB: 1},
+			err:  "redis cluster supports only db 0",
+		},
+		{
+			name: "single node cluster with db",
+			cfg:  Config{Addresses: []string{"redis-1:6379"}, Cluster: true, DB: 1},
+			err:  "redis cluster supports only db 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := options(tt.cfg)
+			if len(tt.err) > 0 {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.cfg.Addresses, opts.Addrs)
+			assert.Equal(t, tt.cfg.MasterName, opts.MasterName)
+			assert.Equal(t, tt.cfg.Username, opts.Username)
+			assert.Equal(t, tt.cfg.Password, opts.Password)
+			assert.Equal(t, tt.cfg.DB, opts.DB)
+			assert.Equal(t, tt.cfg.PoolSize, opts.PoolSize)
+			assert.Nil(t, opts.TLSConfig)
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	t.Run("custom CA", func(t *testing.T) {
+		cfg, err := tlsConfig(Config{TLS: true, CACertFile: "testdata/ca.pem", ServerName: "redis.internal"})
+		require.NoError(t, err)
+
+		assert.NotNil(t, cfg.RootCAs)
+		assert.Equal(t, "redis.internal", cfg.ServerName)
+	})
+
+	t.Run("system CA", func(t *testing.T) {
+		cfg, err := tlsConfig(Config{TLS: true})
+		require.NoError(t, err)
+
+		assert.Nil(t, cfg.RootCAs)
+	})
+
+	t.Run("CA file not found", func(t *testing.T) {
+		_, err := tlsConfig(Config{TLS: true, CACertFile: "testdata/missing.pem"})
+		assert.ErrorContains(t, err, "error reading redis CA certificate")
+	})
+
+	t.Run("CA file without certificates", func(t *testing.T) {
+		_, err := tlsConfig(Config{TLS: true, CACertFile: "conn.go"})
+		assert.ErrorContains(t, err, "no certificates in redis CA file")
+	})
+
+	t.Run("client certificate not found", func(t *testing.T) {
+		_, err := tlsConfig(Config{TLS: true, CertFile: "testdata/missing.pem", KeyFile: "testdata/missing.key"})
+		assert.ErrorContains(t, err, "error loading redis client certificate")
+	})
+}
+
+func TestOpen_Unavailable(t *testing.T) {
+	// на порту 1 никто не слушает
+	_, err := Open(context.Background(), Config{Addresses: []string{"127.0.0.1:1"}, DialTimeout: 100 * time.Millisecond})
+	assert.ErrorContains(t, err, "error connecting redis")
+}
@@ -0,0 +1,38 @@
+package expiry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// TTL - время жизни записи в кэше. К базовому времени добавляется случайная добавка до Jitter,
+// чтобы записи, сохраненные одновременно, не истекали одновременно и не нагружали базу разом
+type TTL struct {
+	Base   time.Duration
+	Jitter time.Duration
+}
+
+// DefaultTTL - время жизни записей, если оно не задано в конфиге
+const DefaultTTL = time.Hour
+
+// New возвращает TTL. Если base не задано, используется DefaultTTL, если jitter не задан - добавка до 10% от базового времени
+func New(base, jitter time.Duration) TTL {
+	if base == 0 {
+		base = DefaultTTL
+	}
+
+	if jitter == 0 {
+		jitter = base / 10
+	}
+
+	return TTL{Base: base, Jitter: jitter}
+}
+
+// Next возвращает время жизни для очередной записи
+func (t TTL) Next() time.Duration {
+	if t.Jitter <= 0 {
+		return t.Base
+	}
+
+	return t.Base + rand.N(t.Jitter)
+}
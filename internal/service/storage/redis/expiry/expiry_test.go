@@ -0,0 +1,32 @@
+package expiry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	t.Run("jitter within bounds", func(t *testing.T) {
+		ttl := New(time.Hour, 5*time.Minute)
+
+		for range 100 {
+			got := ttl.Next()
+			assert.GreaterOrEqual(t, got, time.Hour)
+			assert.Less(t, got, time.Hour+5*time.Minute)
+		}
+	})
+
+	t.Run("default jitter: 10% of base", func(t *testing.T) {
+		assert.Equal(t, 6*time.Minute, New(time.Hour, 0).Jitter)
+	})
+
+	t.Run("default base", func(t *testing.T) {
+		assert.Equal(t, DefaultTTL, New(0, 0).Base)
+	})
+
+	t.Run("no jitter", func(t *testing.T) {
+		assert.Equal(t, time.Minute, TTL{Base: time.Minute}.Next())
+	})
+}
@@ -2,47 +2,53 @@ package space
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 	"webserver/internal/model"
+	"webserver/internal/service/storage/redis/expiry"
 
 	api_errors "webserver/internal/errors"
 
 	"github.com/ex-rate/logger"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 type Cache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	logger *logger.Logger
+	ttl    expiry.TTL
+	loads  singleflight.Group // одновременные промахи по одному пространству идут в базу одним запросом
 }
 
-func New(ctx context.Context, addr string, logger *logger.Logger) (*Cache, error) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: "", // no password set
-		DB:       0,  // use default DB
-	})
-
-	status := redisClient.Ping(ctx)
-
-	if err := status.Err(); err != nil {
-		return nil, err
+func New(client redis.UniversalClient, ttl expiry.TTL, logger *logger.Logger) (*Cache, error) {
+	if client == nil {
+		return nil, errors.New("client is nil")
 	}
 
-	logger.WithField("addr", addr).Info("successfully connected redis")
+	if logger == nil {
+		return nil, errors.New("logger is nil")
+	}
 
 	return &Cache{
-		client: redisClient,
+		client: client,
 		logger: logger,
+		ttl:    ttl,
 	}, nil
 }
 
 const (
 	// запрос получить пользователя по айди. пример: HGETALL user:297850813
 	spaceKey = "space:%s"
+	// участники пространства (телеграм айди). пример: SMEMBERS space:fd1b1c9e-...:members
+	membersKey = "space:%s:members"
+	// noMember - служебный элемент множества участников: пустое множество в редисе не хранится,
+	// а пространство без участников тоже нужно кэшировать. Телеграм айди не бывает нулевым
+	noMember = 0
 
 	// ключи, хранящиеся в редисе
 	idKey       = "id"
@@ -50,6 +56,8 @@ const (
 	createdKey  = "created"
 	personalKey = "personal"
 	creatorKey  = "creator"
+
+	createdLayout = "2006-01-2 15:04:05"
 )
 
 func (s *Cache) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, error) {
@@ -72,9 +80,151 @@ func (s *Cache) GetSpaceByID(ctx context.Context, id uuid.UUID) (model.Space, er
 	return parseSpace(res)
 }
 
+// LoadSpace возвращает пространство из кэша. Если его там нет, загружает его через load и сохраняет в кэш.
+// Если кэш недоступен, пространство загружается через load без кэша
+func (s *Cache) LoadSpace(ctx context.Context, id uuid.UUID, load func(ctx context.Context, id uuid.UUID) (model.Space, error)) (model.Space, error) {
+	space, err := s.GetSpaceByID(ctx, id)
+	if err == nil {
+		return space, nil
+	}
+
+	if !errors.Is(err, api_errors.ErrSpaceNotExists) {
+		s.logger.WithField("id", id).Warnf("error getting space from redis, loading from db: %+v", err)
+	}
+
+	key := fmt.Sprintf(spaceKey, id.String())
+
+	// загрузка не зависит от отмены запроса, который ее начал: ее результат ждут и другие запросы
+	res, err, _ := s.loads.Do(key, func() (any, error) {
+		space, err := load(context.WithoutCancel(ctx), id)
+		if err != nil {
+			return model.Space{}, err
+		}
+
+		if err := s.SaveSpace(context.WithoutCancel(ctx), space); err != nil {
+			s.logger.WithField("id", id).Warnf("error saving space to redis: %+v", err)
+		}
+
+		return space, nil
+	})
+	if err != nil {
+		return model.Space{}, err
+	}
+
+	return res.(model.Space), nil
+}
+
+// SaveSpace сохраняет пространство в кэш в том же виде, в каком его читает GetSpaceByID
+func (s *Cache) SaveSpace(ctx context.Context, space model.Space) error {
+	key := fmt.Sprintf(spaceKey, space.ID.String())
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, spaceFields(space))
+		pipe.Expire(ctx, key, s.ttl.Next())
+
+		return nil
+	})
+
+	return err
+}
+
+// IsMember проверяет по кэшу, состоит ли пользователь в пространстве. Если участников пространства нет в кэше,
+// загружает их через load и сохраняет в кэш. Если кэш недоступен, участники загружаются через load без кэша
+func (s *Cache) IsMember(ctx context.Context, spaceID uuid.UUID, userID int64, load func(ctx context.Context, spaceID uuid.UUID) ([]int64, error)) (bool, error) {
+	key := fmt.Sprintf(membersKey, spaceID.String())
+
+	var (
+		isMember *redis.BoolCmd
+		exists   *redis.IntCmd
+	)
+
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		isMember = pipe.SIsMember(ctx, key, userID)
+		exists = pipe.Exists(ctx, key)
+
+		return nil
+	})
+	if err == nil && exists.Val() > 0 {
+		return isMember.Val(), nil
+	}
+
+	if err != nil {
+		s.logger.WithField("space_id", spaceID).Warnf("error getting space members from redis, loading from db: %+v", err)
+	}
+
+	// загрузка не зависит от отмены запроса, который ее начал: ее результат ждут и другие запросы
+	res, err, _ := s.loads.Do(key, func() (any, error) {
+		members, err := load(context.WithoutCancel(ctx), spaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.SaveMembers(context.WithoutCancel(ctx), spaceID, members); err != nil {
+			s.logger.WithField("space_id", spaceID).Warnf("error saving space members to redis: %+v", err)
+		}
+
+		return members, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(res.([]int64), userID), nil
+}
+
+// SaveMembers заменяет участников пространства в кэше
+func (s *Cache) SaveMembers(ctx context.Context, spaceID uuid.UUID, members []int64) error {
+	key := fmt.Sprintf(membersKey, spaceID.String())
+
+	values := make([]any, 0, len(members)+1)
+	values = append(values, noMember)
+
+	for _, member := range members {
+		values = append(values, member)
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.SAdd(ctx, key, values...)
+		pipe.Expire(ctx, key, s.ttl.Next())
+
+		return nil
+	})
+
+	return err
+}
+
+// DeleteSpace удаляет из кэша пространство вместе с его участниками.
+// Ключи удаляются разными командами: в кластере они могут лежать на разных узлах
+func (s *Cache) DeleteSpace(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, fmt.Sprintf(spaceKey, id.String()))
+		pipe.Del(ctx, fmt.Sprintf(membersKey, id.String()))
+
+		return nil
+	})
+
+	return err
+}
+
+// DeleteMembers удаляет из кэша участников пространства
+func (s *Cache) DeleteMembers(ctx context.Context, id uuid.UUID) error {
+	return s.client.Del(ctx, fmt.Sprintf(membersKey, id.String())).Err()
+}
+
+// spaceFields - поля хэша пространства, которые разбирает parseSpace
+func spaceFields(space model.Space) map[string]string {
+	return map[string]string{
+		idKey:       space.ID.String(),
+		nameKey:     space.Name,
+		createdKey:  space.Created.UTC().Format(createdLayout),
+		personalKey: strconv.FormatBool(space.Personal),
+		creatorKey:  strconv.FormatInt(space.Creator, 10),
+	}
+}
+
 func parseSpace(res map[string]string) (model.Space, error) {
-	layout := "2006-01-2 15:04:05"
-	created, err := time.Parse(layout, res[createdKey])
+	created, err := time.Parse(createdLayout, res[createdKey])
 	if err != nil {
 		return model.Space{}, fmt.Errorf("error converting string created '%s' to time: %+v", res[createdKey], err)
 	}
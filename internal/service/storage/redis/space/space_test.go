@@ -0,0 +1,80 @@
+package space
+
+import (
+	"context"
+	"testing"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/service/storage/redis/expiry"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpaceFields(t *testing.T) {
+	space := model.Space{
+		ID:       uuid.New(),
+		Name:     "test",
+		Created:  time.Date(2025, 3, 7, 10, 4, 5, 0, time.UTC),
+		Creator:  297850813,
+		Personal: true,
+	}
+
+	got, err := parseSpace(spaceFields(space))
+	require.NoError(t, err)
+
+	assert.Equal(t, space, got)
+}
+
+func TestLoadSpace_CacheUnavailable(t *testing.T) {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	// на порту 1 никто не слушает: чтение и запись в кэш завершаются ошибкой
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	cache, err := New(client, expiry.New(time.Minute, 0), log.WithService("space_cache"))
+	require.NoError(t, err)
+
+	space := model.Space{ID: uuid.New(), Name: "test"}
+
+	t.Run("loaded from db", func(t *testing.T) {
+		got, err := cache.LoadSpace(context.Background(), space.ID, func(_ context.Context, id uuid.UUID) (model.Space, error) {
+			assert.Equal(t, space.ID, id)
+			return space, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, space, got)
+	})
+
+	t.Run("members loaded from db", func(t *testing.T) {
+		load := func(context.Context, uuid.UUID) ([]int64, error) {
+			return []int64{1, 2}, nil
+		}
+
+		ok, err := cache.IsMember(context.Background(), space.ID, 2, load)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = cache.IsMember(context.Background(), space.ID, 3, load)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		_, err := cache.LoadSpace(context.Background(), space.ID, func(context.Context, uuid.UUID) (model.Space, error) {
+			return model.Space{}, api_errors.ErrSpaceNotExists
+		})
+
+		assert.ErrorIs(t, err, api_errors.ErrSpaceNotExists)
+	})
+}
@@ -2,47 +2,43 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"webserver/internal/model"
+	"webserver/internal/service/storage/redis/expiry"
 
 	api_errors "webserver/internal/errors"
 
 	"github.com/ex-rate/logger"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 type Cache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	logger *logger.Logger
+	ttl    expiry.TTL
+	loads  singleflight.Group // одновременные промахи по одному пользователю идут в базу одним запросом
 }
 
-func New(ctx context.Context, addr string, logger *logger.Logger) (*Cache, error) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: "", // no password set
-		DB:       0,  // use default DB
-	})
-
-	status := redisClient.Ping(ctx)
-
-	if err := status.Err(); err != nil {
-		return nil, err
+func New(client redis.UniversalClient, ttl expiry.TTL, logger *logger.Logger) (*Cache, error) {
+	if client == nil {
+		return nil, errors.New("client is nil")
 	}
 
-	logger.WithField("addr", addr).Info("successfully connected redis")
+	if logger == nil {
+		return nil, errors.New("logger is nil")
+	}
 
 	return &Cache{
-		client: redisClient,
+		client: client,
 		logger: logger,
+		ttl:    ttl,
 	}, nil
 }
 
-func (u *Cache) Save(ctx context.Context, user model.User) error {
-	return u.client.Set(ctx, fmt.Sprintf("%d", user.TgID), user, 0).Err()
-}
-
 const (
 	// запрос получить пользователя по айди. пример: HGETALL user:297850813
 	userKey = "user:%d"
@@ -83,6 +79,79 @@ func (u *Cache) CheckUser(ctx context.Context, tgID int64) (bool, error) {
 	return user.ID != 0, nil
 }
 
+// LoadUser возвращает пользователя из кэша. Если его там нет, загружает его через load и сохраняет в кэш.
+// Если кэш недоступен, пользователь загружается через load без кэша
+func (u *Cache) LoadUser(ctx context.Context, tgID int64, load func(ctx context.Context, tgID int64) (model.User, error)) (model.User, error) {
+	user, err := u.GetUser(ctx, tgID)
+	if err == nil {
+		return user, nil
+	}
+
+	if !errors.Is(err, api_errors.ErrUnknownUser) {
+		u.logger.WithField("tg_id", tgID).Warnf("error getting user from redis, loading from db: %+v", err)
+	}
+
+	key := fmt.Sprintf(userKey, tgID)
+
+	// загрузка не зависит от отмены запроса, который ее начал: ее результат ждут и другие запросы
+	res, err, _ := u.loads.Do(key, func() (any, error) {
+		user, err := load(context.WithoutCancel(ctx), tgID)
+		if err != nil {
+			return model.User{}, err
+		}
+
+		if err := u.SaveUser(context.WithoutCancel(ctx), user); err != nil {
+			u.logger.WithField("tg_id", tgID).Warnf("error saving user to redis: %+v", err)
+		}
+
+		return user, nil
+	})
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return res.(model.User), nil
+}
+
+// SaveUser сохраняет пользователя в кэш в том же виде, в каком его читает GetUser.
+// Пустой часовой пояс не сохраняется, чтобы не затереть часовой пояс, сохраненный другим сервисом
+func (u *Cache) SaveUser(ctx context.Context, user model.User) error {
+	key := fmt.Sprintf(userKey, user.TgID)
+
+	_, err := u.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, userFields(user))
+		pipe.Expire(ctx, key, u.ttl.Next())
+
+		return nil
+	})
+
+	return err
+}
+
+// DeleteUser удаляет пользователя из кэша
+func (u *Cache) DeleteUser(ctx context.Context, tgID int64) error {
+	return u.client.Del(ctx, fmt.Sprintf(userKey, tgID)).Err()
+}
+
+// userFields - поля хэша пользователя, которые разбирает parseUser
+func userFields(user model.User) map[string]string {
+	fields := map[string]string{
+		idKey:         strconv.Itoa(user.ID),
+		telegramIDKey: strconv.FormatInt(user.TgID, 10),
+		usernameKey:   user.Username,
+	}
+
+	if user.PersonalSpace != nil {
+		fields[personalSpaceIDKey] = user.PersonalSpace.ID.String()
+	}
+
+	if len(user.Timezone) > 0 {
+		fields[timezoneKey] = user.Timezone
+	}
+
+	return fields
+}
+
 func parseUser(res map[string]string) (model.User, error) {
 	id, err := strconv.Atoi(res[idKey])
 	if err != nil {
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
+	"webserver/internal/service/storage/redis/expiry"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserFields(t *testing.T) {
+	type test struct {
+		name string
+		user model.User
+	}
+
+	tests := []test{
+		{
+			name: "with timezone",
+			user: model.User{
+				ID:            1,
+				TgID:          297850813,
+				Username:      "user",
+				PersonalSpace: &model.Space{ID: uuid.New()},
+				Timezone:      "Europe/Moscow",
+			},
+		},
+		{
+			name: "without timezone",
+			user: model.User{
+				ID:            1,
+				TgID:          297850813,
+				Username:      "user",
+				PersonalSpace: &model.Space{ID: uuid.New()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := userFields(tt.user)
+
+			_, ok := fields[timezoneKey]
+			assert.Equal(t, len(tt.user.Timezone) > 0, ok)
+
+			got, err := parseUser(fields)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.user, got)
+		})
+	}
+}
+
+func TestLoadUser_CacheUnavailable(t *testing.T) {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	// на порту 1 никто не слушает: чтение и запись в кэш завершаются ошибкой
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	cache, err := New(client, expiry.New(time.Minute, 0), log.WithService("user_cache"))
+	require.NoError(t, err)
+
+	user := model.User{ID: 1, TgID: 297850813, PersonalSpace: &model.Space{ID: uuid.New()}}
+
+	t.Run("loaded from db", func(t *testing.T) {
+		got, err := cache.LoadUser(context.Background(), user.TgID, func(_ context.Context, tgID int64) (model.User, error) {
+			assert.Equal(t, user.TgID, tgID)
+			return user, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, user, got)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		_, err := cache.LoadUser(context.Background(), user.TgID, func(context.Context, int64) (model.User, error) {
+			return model.User{}, api_errors.ErrUnknownUser
+		})
+
+		assert.ErrorIs(t, err, api_errors.ErrUnknownUser)
+	})
+}
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// unavailable сообщает, что поиск не удался из-за эластика, а не из-за запроса: эластик не отвечает,
+// перегружен или сломан. Если запрос отменил клиент, запасной поиск тоже не нужен
+func unavailable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var esErr *types.ElasticsearchError
+	if errors.As(err, &esErr) {
+		return esErr.Status >= http.StatusInternalServerError || esErr.Status == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./selector.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	elastic "webserver/internal/model/elastic"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// Mocksearcher is a mock of searcher interface.
+type Mocksearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearcherMockRecorder
+}
+
+// MocksearcherMockRecorder is the mock recorder for Mocksearcher.
+type MocksearcherMockRecorder struct {
+	mock *Mocksearcher
+}
+
+// NewMocksearcher creates a new mock instance.
+func NewMocksearcher(ctrl *gomock.Controller) *Mocksearcher {
+	mock := &Mocksearcher{ctrl: ctrl}
+	mock.recorder = &MocksearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksearcher) EXPECT() *MocksearcherMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *Mocksearcher) Save(ctx context.Context, search elastic.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MocksearcherMockRecorder) Save(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*Mocksearcher)(nil).Save), ctx, search)
+}
+
+// SearchByText mocks base method.
+func (m *Mocksearcher) SearchByText(ctx context.Context, search elastic.Data, page elastic.Page) (elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByText", ctx, search, page)
+	ret0, _ := ret[0].(elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByText indicates an expected call of SearchByText.
+func (mr *MocksearcherMockRecorder) SearchByText(ctx, search, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByText", reflect.TypeOf((*Mocksearcher)(nil).SearchByText), ctx, search, page)
+}
+
+// SearchByTextInSpaces mocks base method.
+func (m *Mocksearcher) SearchByTextInSpaces(ctx context.Context, search elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByTextInSpaces", ctx, search, spaceIDs, page)
+	ret0, _ := ret[0].(elastic.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByTextInSpaces indicates an expected call of SearchByTextInSpaces.
+func (mr *MocksearcherMockRecorder) SearchByTextInSpaces(ctx, search, spaceIDs, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTextInSpaces", reflect.TypeOf((*Mocksearcher)(nil).SearchByTextInSpaces), ctx, search, spaceIDs, page)
+}
+
+// Suggest mocks base method.
+func (m *Mocksearcher) Suggest(ctx context.Context, search elastic.Data, size int) ([]elastic.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, search, size)
+	ret0, _ := ret[0].([]elastic.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MocksearcherMockRecorder) Suggest(ctx, search, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*Mocksearcher)(nil).Suggest), ctx, search, size)
+}
+
+// UpdateNote mocks base method.
+func (m *Mocksearcher) UpdateNote(ctx context.Context, search elastic.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MocksearcherMockRecorder) UpdateNote(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*Mocksearcher)(nil).UpdateNote), ctx, search)
+}
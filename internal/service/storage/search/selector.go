@@ -0,0 +1,185 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"webserver/internal/model/elastic"
+
+	"github.com/ex-rate/logger"
+	"github.com/google/uuid"
+)
+
+// DefaultCooldown - сколько после отказа эластика поиск идет сразу в запасной поиск, не дожидаясь ошибки от эластика
+const DefaultCooldown = 30 * time.Second
+
+// Selector выбирает, где искать заметки: в эластике или, если он недоступен, в запасном поиске (постгрес).
+// Запись всегда идет в эластик: запасной поиск ищет прямо в базе, ему индекс не нужен
+type Selector struct {
+	primary  searcher
+	fallback searcher
+	logger   *logger.Logger
+
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	downUntil time.Time // до какого момента не обращаемся к эластику. Нулевое - эластик доступен
+}
+
+//go:generate mockgen -source ./selector.go -destination=./mocks/search.go -package=mocks
+type searcher interface {
+	Save(ctx context.Context, search elastic.Data) error
+	// SearchByText производит поиск по тексту в пространстве заметки
+	SearchByText(ctx context.Context, search elastic.Data, page elastic.Page) (elastic.SearchResult, error)
+	// SearchByTextInSpaces производит поиск по тексту сразу в нескольких пространствах
+	SearchByTextInSpaces(ctx context.Context, search elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error)
+	// Suggest возвращает подсказки по началу текста
+	Suggest(ctx context.Context, search elastic.Data, size int) ([]elastic.Suggestion, error)
+	UpdateNote(ctx context.Context, search elastic.Data) error
+}
+
+type SelectorOption func(*Selector)
+
+// WithPrimary задает основной поиск (эластик)
+func WithPrimary(primary searcher) SelectorOption {
+	return func(s *Selector) {
+		s.primary = primary
+	}
+}
+
+// WithFallback задает запасной поиск, который используется, пока основной недоступен
+func WithFallback(fallback searcher) SelectorOption {
+	return func(s *Selector) {
+		s.fallback = fallback
+	}
+}
+
+func WithLogger(logger *logger.Logger) SelectorOption {
+	return func(s *Selector) {
+		s.logger = logger
+	}
+}
+
+// WithCooldown задает, сколько после отказа основного поиска не обращаться к нему. По умолчанию DefaultCooldown
+func WithCooldown(cooldown time.Duration) SelectorOption {
+	return func(s *Selector) {
+		s.cooldown = cooldown
+	}
+}
+
+func New(opts ...SelectorOption) (*Selector, error) {
+	s := &Selector{
+		cooldown: DefaultCooldown,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.primary == nil {
+		return nil, errors.New("primary is nil")
+	}
+
+	if s.fallback == nil {
+		return nil, errors.New("fallback is nil")
+	}
+
+	if s.logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+
+	if s.cooldown <= 0 {
+		return nil, errors.New("cooldown must be positive")
+	}
+
+	s.logger.Info("search selector initialized")
+
+	return s, nil
+}
+
+func (s *Selector) Save(ctx context.Context, data elastic.Data) error {
+	return s.primary.Save(ctx, data)
+}
+
+func (s *Selector) UpdateNote(ctx context.Context, data elastic.Data) error {
+	return s.primary.UpdateNote(ctx, data)
+}
+
+func (s *Selector) SearchByText(ctx context.Context, data elastic.Data, page elastic.Page) (elastic.SearchResult, error) {
+	return try(ctx, s, func(cl searcher) (elastic.SearchResult, error) {
+		return cl.SearchByText(ctx, data, page)
+	})
+}
+
+func (s *Selector) SearchByTextInSpaces(ctx context.Context, data elastic.Data, spaceIDs []uuid.UUID, page elastic.Page) (elastic.SearchResult, error) {
+	return try(ctx, s, func(cl searcher) (elastic.SearchResult, error) {
+		return cl.SearchByTextInSpaces(ctx, data, spaceIDs, page)
+	})
+}
+
+func (s *Selector) Suggest(ctx context.Context, data elastic.Data, size int) ([]elastic.Suggestion, error) {
+	return try(ctx, s, func(cl searcher) ([]elastic.Suggestion, error) {
+		return cl.Suggest(ctx, data, size)
+	})
+}
+
+// try выполняет поиск в эластике, а если он недоступен - в запасном поиске.
+// Ошибки в самом запросе (синтаксис, ничего не найдено и т.п.) возвращаются как есть
+func try[T any](ctx context.Context, s *Selector, fn func(searcher) (T, error)) (T, error) {
+	if s.isDown() {
+		return fn(s.fallback)
+	}
+
+	res, err := fn(s.primary)
+	if err == nil {
+		s.markUp()
+		return res, nil
+	}
+
+	if !unavailable(ctx, err) {
+		return res, err
+	}
+
+	s.markDown(err)
+
+	return fn(s.fallback)
+}
+
+// MarkUnavailable переключает поиск на запасной, например если эластик не ответил при запуске.
+// Эластик снова попробуем после cooldown
+func (s *Selector) MarkUnavailable(err error) {
+	s.markDown(err)
+}
+
+// isDown сообщает, что эластик недавно отказал и к нему пока не обращаемся
+func (s *Selector) isDown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now().Before(s.downUntil)
+}
+
+func (s *Selector) markDown(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.downUntil.IsZero() {
+		s.logger.Warnf("elastic is unavailable, searching in postgres: %+v", err)
+	}
+
+	s.downUntil = s.now().Add(s.cooldown)
+}
+
+func (s *Selector) markUp() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.downUntil.IsZero() {
+		s.logger.Info("elastic is available again, searching in elastic")
+	}
+
+	s.downUntil = time.Time{}
+}
@@ -0,0 +1,326 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+	"webserver/internal/model"
+	"webserver/internal/model/elastic"
+	"webserver/internal/service/storage/elasticsearch"
+	"webserver/internal/service/storage/search/mocks"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/ex-rate/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	type test struct {
+		name     string
+		primary  searcher
+		fallback searcher
+		logger   *logger.Logger
+		cooldown time.Duration
+		err      error
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMocksearcher(ctrl)
+	fallback := mocks.NewMocksearcher(ctrl)
+
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	searchLogger := log.WithService("search")
+
+	tests := []test{
+		{
+			name:     "positive case",
+			primary:  primary,
+			fallback: fallback,
+			logger:   searchLogger,
+			cooldown: DefaultCooldown,
+		},
+		{
+			name:     "error case: primary is nil",
+			fallback: fallback,
+			logger:   searchLogger,
+			cooldown: DefaultCooldown,
+			err:      errors.New("primary is nil"),
+		},
+		{
+			name:     "error case: fallback is nil",
+			primary:  primary,
+			logger:   searchLogger,
+			cooldown: DefaultCooldown,
+			err:      errors.New("fallback is nil"),
+		},
+		{
+			name:     "error case: logger is nil",
+			primary:  primary,
+			fallback: fallback,
+			cooldown: DefaultCooldown,
+			err:      errors.New("logger is nil"),
+		},
+		{
+			name:     "error case: zero cooldown",
+			primary:  primary,
+			fallback: fallback,
+			logger:   searchLogger,
+			err:      errors.New("cooldown must be positive"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(
+				WithPrimary(tt.primary),
+				WithFallback(tt.fallback),
+				WithLogger(tt.logger),
+				WithCooldown(tt.cooldown),
+			)
+			if tt.err != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.err.Error())
+				assert.Nil(t, s)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.cooldown, s.cooldown)
+			}
+		})
+	}
+}
+
+func TestSearchByText(t *testing.T) {
+	data := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{SpaceID: uuid.New(), Text: "молоко"},
+	}
+
+	page := elastic.Page{Size: 10}
+
+	esResult := elastic.SearchResult{Total: 1, Hits: []elastic.SearchHit{{ID: uuid.New()}}}
+	pgResult := elastic.SearchResult{Total: 2, Hits: []elastic.SearchHit{{ID: uuid.New()}, {ID: uuid.New()}}}
+
+	type test struct {
+		name       string
+		ctx        func() context.Context
+		primaryErr error
+		fallback   bool // поиск должен уйти в постгрес
+		want       elastic.SearchResult
+		err        error
+	}
+
+	canceled := func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		return ctx
+	}
+
+	tests := []test{
+		{
+			name: "elastic available",
+			want: esResult,
+		},
+		{
+			name:       "elastic is down: connection refused",
+			primaryErr: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			fallback:   true,
+			want:       pgResult,
+		},
+		{
+			name:       "elastic is down: 503",
+			primaryErr: &types.ElasticsearchError{Status: 503},
+			fallback:   true,
+			want:       pgResult,
+		},
+		{
+			name:       "elastic is overloaded: 429",
+			primaryErr: &types.ElasticsearchError{Status: 429},
+			fallback:   true,
+			want:       pgResult,
+		},
+		{
+			name:       "bad request: no fallback",
+			primaryErr: &types.ElasticsearchError{Status: 400},
+			err:        &types.ElasticsearchError{Status: 400},
+		},
+		{
+			name:       "nothing found: no fallback",
+			primaryErr: elasticsearch.ErrRecordsNotFound,
+			err:        elasticsearch.ErrRecordsNotFound,
+		},
+		{
+			name:       "syntax error: no fallback",
+			primaryErr: &model.SearchSyntaxError{Position: 1, Message: "bad"},
+			err:        &model.SearchSyntaxError{Position: 1, Message: "bad"},
+		},
+		{
+			name:       "request canceled by client: no fallback",
+			ctx:        canceled,
+			primaryErr: context.Canceled,
+			err:        context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			primary, fallback := mocks.NewMocksearcher(ctrl), mocks.NewMocksearcher(ctrl)
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+
+			primary.EXPECT().SearchByText(ctx, data, page).Return(esResultFor(tt.primaryErr, esResult), tt.primaryErr)
+
+			if tt.fallback {
+				fallback.EXPECT().SearchByText(ctx, data, page).Return(pgResult, nil)
+			}
+
+			s := createTestSelector(t, primary, fallback)
+
+			got, err := s.SearchByText(ctx, data, page)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.fallback, s.isDown())
+		})
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary, fallback := mocks.NewMocksearcher(ctrl), mocks.NewMocksearcher(ctrl)
+
+	data := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{SpaceID: uuid.New(), Text: "мол"},
+	}
+
+	esErr := &types.ElasticsearchError{Status: 502}
+	suggestions := []elastic.Suggestion{{ID: uuid.New(), Snippet: "<em>мол</em>око"}}
+
+	s := createTestSelector(t, primary, fallback)
+
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	gomock.InOrder(
+		// эластик отказал - ищем в постгресе
+		primary.EXPECT().Suggest(gomock.Any(), data, 5).Return(nil, esErr),
+		fallback.EXPECT().Suggest(gomock.Any(), data, 5).Return(suggestions, nil),
+		// пока не прошел cooldown, к эластику не обращаемся
+		fallback.EXPECT().Suggest(gomock.Any(), data, 5).Return(suggestions, nil),
+		// cooldown прошел - снова пробуем эластик
+		primary.EXPECT().Suggest(gomock.Any(), data, 5).Return(suggestions, nil),
+	)
+
+	for _, shift := range []time.Duration{0, DefaultCooldown - time.Second, DefaultCooldown} {
+		now = now.Add(shift)
+
+		got, err := s.Suggest(context.Background(), data, 5)
+		require.NoError(t, err)
+		assert.Equal(t, suggestions, got)
+	}
+
+	assert.False(t, s.isDown())
+}
+
+func TestMarkUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary, fallback := mocks.NewMocksearcher(ctrl), mocks.NewMocksearcher(ctrl)
+
+	data := elastic.Data{
+		Index: elastic.NoteIndex,
+		Model: &elastic.Note{SpaceID: uuid.New(), Text: "мол"},
+	}
+
+	suggestions := []elastic.Suggestion{{ID: uuid.New(), Snippet: "<em>мол</em>око"}}
+
+	s := createTestSelector(t, primary, fallback)
+
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	s.MarkUnavailable(elasticsearch.ErrUnavailable)
+
+	gomock.InOrder(
+		// эластик не ответил при запуске - сразу ищем в постгресе
+		fallback.EXPECT().Suggest(gomock.Any(), data, 5).Return(suggestions, nil),
+		// cooldown прошел - пробуем эластик
+		primary.EXPECT().Suggest(gomock.Any(), data, 5).Return(suggestions, nil),
+	)
+
+	for _, shift := range []time.Duration{0, DefaultCooldown} {
+		now = now.Add(shift)
+
+		got, err := s.Suggest(context.Background(), data, 5)
+		require.NoError(t, err)
+		assert.Equal(t, suggestions, got)
+	}
+}
+
+func TestWritesGoToPrimary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary, fallback := mocks.NewMocksearcher(ctrl), mocks.NewMocksearcher(ctrl)
+
+	data := elastic.Data{Index: elastic.NoteIndex, Model: &elastic.Note{ID: uuid.New()}}
+	esErr := &types.ElasticsearchError{Status: 503}
+
+	primary.EXPECT().UpdateNote(gomock.Any(), data).Return(esErr)
+
+	s := createTestSelector(t, primary, fallback)
+
+	assert.Equal(t, esErr, s.UpdateNote(context.Background(), data))
+}
+
+// esResultFor возвращает результат эластика: при ошибке он пустой
+func esResultFor(err error, res elastic.SearchResult) elastic.SearchResult {
+	if err != nil {
+		return elastic.SearchResult{}
+	}
+
+	return res
+}
+
+func createTestSelector(t *testing.T, primary, fallback searcher) *Selector {
+	log, err := logger.New(logger.Config{
+		Level:  logger.DebugLevel,
+		Output: logger.ConsoleOutput,
+	})
+	require.NoError(t, err)
+
+	s, err := New(
+		WithPrimary(primary),
+		WithFallback(fallback),
+		WithLogger(log.WithService("search")),
+	)
+	require.NoError(t, err)
+
+	return s
+}
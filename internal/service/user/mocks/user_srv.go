@@ -88,32 +88,17 @@ func (m *MockuserCache) EXPECT() *MockuserCacheMockRecorder {
 	return m.recorder
 }
 
-// CheckUser mocks base method.
-func (m *MockuserCache) CheckUser(ctx context.Context, tgID int64) (bool, error) {
+// LoadUser mocks base method.
+func (m *MockuserCache) LoadUser(ctx context.Context, tgID int64, load func(context.Context, int64) (model.User, error)) (model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUser", ctx, tgID)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// CheckUser indicates an expected call of CheckUser.
-func (mr *MockuserCacheMockRecorder) CheckUser(ctx, tgID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUser", reflect.TypeOf((*MockuserCache)(nil).CheckUser), ctx, tgID)
-}
-
-// GetUser mocks base method.
-func (m *MockuserCache) GetUser(ctx context.Context, tgID int64) (model.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, tgID)
+	ret := m.ctrl.Call(m, "LoadUser", ctx, tgID, load)
 	ret0, _ := ret[0].(model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockuserCacheMockRecorder) GetUser(ctx, tgID interface{}) *gomock.Call {
+// LoadUser indicates an expected call of LoadUser.
+func (mr *MockuserCacheMockRecorder) LoadUser(ctx, tgID, load interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserCache)(nil).GetUser), ctx, tgID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUser", reflect.TypeOf((*MockuserCache)(nil).LoadUser), ctx, tgID, load)
 }
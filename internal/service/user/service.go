@@ -21,8 +21,8 @@ type userRepo interface {
 	CheckUser(ctx context.Context, tgID int64) (bool, error)
 }
 type userCache interface {
-	GetUser(ctx context.Context, tgID int64) (model.User, error)
-	CheckUser(ctx context.Context, tgID int64) (bool, error)
+	// LoadUser возвращает пользователя из кэша, а если его там нет - загружает через load и сохраняет в кэш
+	LoadUser(ctx context.Context, tgID int64, load func(ctx context.Context, tgID int64) (model.User, error)) (model.User, error)
 }
 
 type UserOption func(*Service)
@@ -70,21 +70,15 @@ func WithLogger(logger *logger.Logger) UserOption {
 
 // CheckUser проверяет существование пользователя по tgID
 func (s *Service) CheckUser(ctx context.Context, tgID int64) (bool, error) {
-	// проверяем в кэшэ
-	exists, err := s.cache.CheckUser(ctx, tgID)
-	// если ошибка не связана с отсутствием пользователя, возвращаем её
-	if err != nil && !errors.Is(err, api_errors.ErrUnknownUser) {
-		return false, err
-	}
-	// если пользователь найден в кэше, возвращаем true
-	if err == nil && exists {
-		return true, nil
-	}
-
-	// проверяем в БД
-	exists, err = s.repo.CheckUser(ctx, tgID)
+	// пользователь берется из кэша, а если его там нет - из БД и сохраняется в кэш
+	user, err := s.cache.LoadUser(ctx, tgID, s.repo.GetUser)
 	if err != nil {
+		if errors.Is(err, api_errors.ErrUnknownUser) {
+			return false, nil
+		}
+
 		return false, err
 	}
-	return exists, nil
+
+	return user.ID != 0, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"testing"
+	api_errors "webserver/internal/errors"
+	"webserver/internal/model"
 	"webserver/internal/service/user/mocks"
 
 	"github.com/ex-rate/logger"
@@ -100,6 +102,11 @@ func TestCheckUser(t *testing.T) {
 		setupMocks func(mocks *fields)
 	}
 
+	// cacheMiss - поведение кэша, в котором нет пользователя: он загружается из базы
+	cacheMiss := func(ctx context.Context, tgID int64, load func(context.Context, int64) (model.User, error)) (model.User, error) {
+		return load(ctx, tgID)
+	}
+
 	tests := []test{
 		{
 			name:   "positive case: exists in cache",
@@ -108,7 +115,7 @@ func TestCheckUser(t *testing.T) {
 			exists: true,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(true, nil)
+				mocks.cache.EXPECT().LoadUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.User{ID: 1, TgID: 123}, nil)
 			},
 		},
 		{
@@ -118,18 +125,19 @@ func TestCheckUser(t *testing.T) {
 			exists: true,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(false, nil)
-				mocks.repo.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(true, nil)
+				mocks.cache.EXPECT().LoadUser(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(model.User{ID: 1, TgID: 123}, nil)
 			},
 		},
 		{
-			name:   "error case: cache error",
+			name:   "positive case: not exists",
 			tgID:   123,
-			err:    errors.New("cache error"),
+			err:    nil,
 			exists: false,
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(false, errors.New("cache error"))
+				mocks.cache.EXPECT().LoadUser(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(model.User{}, api_errors.ErrUnknownUser)
 			},
 		},
 		{
@@ -139,8 +147,8 @@ func TestCheckUser(t *testing.T) {
 			err:    errors.New("repo error"),
 			setupMocks: func(mocks *fields) {
 				t.Helper()
-				mocks.cache.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(false, nil)
-				mocks.repo.EXPECT().CheckUser(gomock.Any(), gomock.Any()).Return(false, errors.New("repo error"))
+				mocks.cache.EXPECT().LoadUser(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(cacheMiss)
+				mocks.repo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(model.User{}, errors.New("repo error"))
 			},
 		},
 	}
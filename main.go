@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	_ "time/tzdata" // часовые пояса пользователей: в образе с сервером нет системной базы часовых поясов
 	"webserver/internal/app"
+	"webserver/internal/service/reindex"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,7 +25,23 @@ func main() {
 	configPath := flag.String("config", "internal/config/config.yaml", "путь к файлу конфигурации")
 	flag.Parse()
 
-	app, err := app.NewApp(ctx, *configPath)
+	// без команды запускается сервер
+	switch cmd := flag.Arg(0); cmd {
+	case "":
+		runServer(ctx, *configPath)
+	case "reindex":
+		runReindex(ctx, *configPath, flag.Args()[1:])
+	case "consistency":
+		runConsistency(ctx, *configPath, flag.Args()[1:])
+	case "migrate":
+		runMigrate(ctx, *configPath, flag.Args()[1:])
+	default:
+		logrus.Fatalf("unknown command %q, available commands: reindex, consistency, migrate", cmd)
+	}
+}
+
+func runServer(ctx context.Context, configPath string) {
+	app, err := app.NewApp(ctx, configPath)
 	if err != nil {
 		logrus.Fatalf("error creating app: %+v", err)
 	}
@@ -48,16 +68,169 @@ func main() {
 			logrus.Errorf("error shutdown server: %+v", err)
 		}
 
-		app.SpaceRepo.Close()
 		err = app.Rabbit.Close()
 		if err != nil {
 			logrus.Errorf("error closing rabbit: %+v", err)
 		}
 
-		app.UserRepo.Close()
+		err = app.Redis.Close()
+		if err != nil {
+			logrus.Errorf("error closing redis: %+v", err)
+		}
+
+		app.Postgres.Close()
 	}(&wg)
 
 	wg.Wait()
 
 	notify()
 }
+
+// runReindex перестраивает индекс заметок по данным из базы:
+//
+//	webserver -config config.yaml reindex [-batch 500] [-dry-run] [-index notes_v1_20240515143000 -after <id заметки>]
+//
+// Изменения заметок, сделанные во время переиндексации, попадают в старый индекс, поэтому после нее нужно запустить
+// consistency -repair
+func runReindex(ctx context.Context, configPath string, args []string) {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	batchSize := flags.Int("batch", reindex.DefaultBatchSize, "сколько заметок обрабатывать за раз")
+	dryRun := flags.Bool("dry-run", false, "только прочитать заметки из базы, не меняя индекс")
+	index := flags.String("index", "", "индекс, в который продолжить прерванную переиндексацию")
+	after := flags.String("after", "", "id последней обработанной заметки, с которой продолжить переиндексацию")
+
+	if err := flags.Parse(args); err != nil {
+		logrus.Fatalf("error parsing reindex flags: %+v", err)
+	}
+
+	params := reindex.Params{
+		DryRun: *dryRun,
+		Index:  *index,
+	}
+
+	if len(*after) > 0 {
+		id, err := uuid.Parse(*after)
+		if err != nil {
+			logrus.Fatalf("invalid -after: %+v", err)
+		}
+
+		params.After = id
+	}
+
+	// по сигналу переиндексация останавливается после текущей пачки и сообщает, откуда ее продолжить
+	notifyCtx, notify := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer notify()
+
+	res, err := app.Reindex(notifyCtx, configPath, *batchSize, params)
+	if err != nil {
+		logrus.Fatalf("error reindexing notes: %+v", err)
+	}
+
+	logrus.Infof("reindex finished: %d notes in `%s`", res.Indexed, res.Index)
+
+	if !params.DryRun {
+		logrus.Warn("notes changed during reindex went to the previous index, run `consistency -repair` now to carry them over")
+	}
+}
+
+// runConsistency сверяет заметки в базе и в эластике и выводит отчет в JSON.
+// Если найдены расхождения и они не исправлены, завершается с кодом 1:
+//
+//	webserver -config config.yaml consistency [-repair] [-report report.json]
+func runConsistency(ctx context.Context, configPath string, args []string) {
+	flags := flag.NewFlagSet("consistency", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "исправить расхождения: переиндексировать заметки из базы и удалить лишние документы")
+	reportPath := flags.String("report", "", "файл для отчета (по умолчанию - stdout)")
+
+	if err := flags.Parse(args); err != nil {
+		logrus.Fatalf("error parsing consistency flags: %+v", err)
+	}
+
+	notifyCtx, notify := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer notify()
+
+	report, err := app.CheckConsistency(notifyCtx, configPath, *repair)
+	if err != nil {
+		logrus.Fatalf("error checking consistency: %+v", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		logrus.Fatalf("error marshalling consistency report: %+v", err)
+	}
+
+	if len(*reportPath) == 0 {
+		_, err = os.Stdout.Write(append(data, '\n'))
+	} else {
+		err = os.WriteFile(*reportPath, data, 0o644)
+	}
+
+	if err != nil {
+		logrus.Fatalf("error writing consistency report: %+v", err)
+	}
+
+	if report.TotalDrift() > 0 && !report.Repaired {
+		logrus.Fatalf("found %d inconsistencies between db and elastic, run with -repair to fix them", report.TotalDrift())
+	}
+}
+
+// runMigrate применяет или откатывает миграции схемы базы либо выводит их состояние в JSON:
+//
+//	webserver -config config.yaml migrate up
+//	webserver -config config.yaml migrate down [-steps 1]
+//	webserver -config config.yaml migrate status
+func runMigrate(ctx context.Context, configPath string, args []string) {
+	if len(args) == 0 {
+		logrus.Fatal("migrate: expected subcommand: up, down, status")
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	steps := flags.Int("steps", 1, "сколько последних миграций откатить (только для down)")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		logrus.Fatalf("error parsing migrate flags: %+v", err)
+	}
+
+	notifyCtx, notify := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	defer notify()
+
+	migrator := app.NewMigrator(notifyCtx, configPath)
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(notifyCtx)
+		if err != nil {
+			logrus.Fatalf("error applying migrations: %+v", err)
+		}
+
+		logrus.Infof("applied %d migrations, schema version: %d", len(applied), migrator.Latest())
+	case "down":
+		if *steps < 1 {
+			logrus.Fatalf("migrate down: -steps must be positive, got %d", *steps)
+		}
+
+		reverted, err := migrator.Down(notifyCtx, *steps)
+		if err != nil {
+			logrus.Fatalf("error reverting migrations: %+v", err)
+		}
+
+		logrus.Infof("reverted %d migrations", len(reverted))
+	case "status":
+		status, err := migrator.Status(notifyCtx)
+		if err != nil {
+			logrus.Fatalf("error getting migrations status: %+v", err)
+		}
+
+		data, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			logrus.Fatalf("error marshalling migrations status: %+v", err)
+		}
+
+		if _, err := os.Stdout.Write(append(data, '\n')); err != nil {
+			logrus.Fatalf("error writing migrations status: %+v", err)
+		}
+	default:
+		logrus.Fatalf("migrate: unknown subcommand %q, available: up, down, status", args[0])
+	}
+}